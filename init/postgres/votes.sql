@@ -10,9 +10,19 @@ CREATE TABLE votes (
   id TEXT PRIMARY KEY,    -- Unique identifier for the vote
   survey TEXT,            -- Identifier for the survey
   question INT,           -- Number of the question being voted on
-  created BIGINT          -- Timestamp of when the vote was created
+  created BIGINT,         -- Timestamp of when the vote was created
+  archived_at BIGINT,     -- Timestamp of when the survey was deleted, NULL while the vote is live
+  user_id TEXT,           -- Identifier of the authenticated user who voted, NULL for anonymous votes
+  respondent TEXT,        -- Key grouping the answers of one respondent for analytics, NULL when unknown
+  answer JSONB            -- Answer values of the vote, such as the selected options or the rating
 );
 
+-- Allow each user a single live answer per survey question
+CREATE UNIQUE INDEX votes_user_answer ON votes (survey, question, user_id) WHERE user_id IS NOT NULL AND archived_at IS NULL;
+
+-- Load the votes of a survey in time order for analytics
+CREATE INDEX votes_survey_created ON votes (survey, created);
+
 -- Create the results table
 -- This table stores the total vote count for each survey and question, along with the timestamp of the last update
 CREATE TABLE results (
@@ -20,5 +30,59 @@ CREATE TABLE results (
   question INT,           -- Number of the question being voted on
   votes INT,              -- Total number of votes for the question
   last_update BIGINT,     -- Timestamp of the last update
+  archived_at BIGINT,     -- Timestamp of when the survey was deleted, NULL while the results are live
   PRIMARY KEY(survey, question)  -- Primary key consisting of the survey and question combination
 );
+
+-- Create the vote audit table
+-- This table stores anonymous votes rejected as replays or rate limited, with the reason and the client that sent them
+CREATE TABLE vote_audit (
+  id TEXT PRIMARY KEY,    -- Unique identifier for the audit record
+  survey TEXT,            -- Identifier for the survey
+  question INT,           -- Number of the question being voted on
+  reason TEXT,            -- Reason the vote was rejected
+  client_ip TEXT,         -- IP address of the client that sent the vote
+  fingerprint TEXT,       -- Fingerprint of the client that sent the vote, empty when not sent
+  created BIGINT          -- Timestamp of when the vote was rejected
+);
+
+-- Create the sessions table
+-- This table stores the answers saved by respondents until they finalize their response session
+CREATE TABLE sessions (
+  id TEXT PRIMARY KEY,    -- Unique identifier for the session
+  token_hash TEXT UNIQUE, -- SHA-256 hash of the token used to resume the session
+  survey TEXT,            -- Identifier for the survey
+  user_id TEXT,           -- Identifier of the authenticated user who started the session, NULL for anonymous sessions
+  answers JSONB,          -- Answers saved so far, one per question
+  status TEXT,            -- Status of the session, in_progress, finalizing, completed or expired
+  created BIGINT,         -- Timestamp of when the session was started
+  updated BIGINT,         -- Timestamp of when answers were last saved
+  expires_at BIGINT,      -- Timestamp of when the session expires unless answers are saved
+  completed_at BIGINT     -- Timestamp of when the session was finalized, NULL until then
+);
+
+-- Count the sessions of a survey by status and find expired sessions quickly
+CREATE INDEX sessions_survey_status ON sessions (survey, status);
+CREATE INDEX sessions_status_expires ON sessions (status, expires_at);
+
+-- Create the files table
+-- This table stores the metadata of the files uploaded to file questions, their content is kept in the blob storage
+CREATE TABLE files (
+  id TEXT PRIMARY KEY,    -- Unique identifier for the file, also its key in the blob storage
+  survey TEXT,            -- Identifier for the survey
+  question INT,           -- Number of the question the file was uploaded for
+  name TEXT,              -- Original name of the file
+  content_type TEXT,      -- MIME type of the file
+  size BIGINT,            -- Size of the file in bytes
+  user_id TEXT,           -- Identifier of the authenticated user who uploaded the file, NULL for anonymous uploads
+  created BIGINT,         -- Timestamp of when the file was uploaded
+  attached_at BIGINT      -- Timestamp of when a vote referenced the file, NULL while no vote does
+);
+CREATE INDEX files_unattached_created ON files (created) WHERE attached_at IS NULL;
+
+-- Create the respondent token uses table
+-- This table remembers which respondent token answered which question, so replays are rejected by every vote service instance
+CREATE TABLE respondent_token_uses (
+  key TEXT PRIMARY KEY,   -- Token ID and question number
+  expires_at BIGINT       -- Timestamp of when the token expires, the row can be removed afterwards
+);
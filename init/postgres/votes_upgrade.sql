@@ -0,0 +1,73 @@
+-- Upgrade a voting database created before the current votes.sql
+-- Init scripts only run on an empty data directory, so existing databases need this script to be run once:
+--   docker-compose exec vote_storage psql -U admin -f /docker-entrypoint-initdb.d/votes_upgrade.sql
+-- Every statement is idempotent, it also runs harmlessly right after votes.sql on new databases
+
+-- Connect to the voting database
+\c voting
+
+-- Add the columns of archived surveys, voters and answers to the votes table
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS archived_at BIGINT;
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS user_id TEXT;
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS respondent TEXT;
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS answer JSONB;
+
+-- Allow each user a single live answer per survey question
+CREATE UNIQUE INDEX IF NOT EXISTS votes_user_answer ON votes (survey, question, user_id) WHERE user_id IS NOT NULL AND archived_at IS NULL;
+
+-- Load the votes of a survey in time order for analytics
+CREATE INDEX IF NOT EXISTS votes_survey_created ON votes (survey, created);
+
+-- Add the column of archived surveys to the results table
+ALTER TABLE results ADD COLUMN IF NOT EXISTS archived_at BIGINT;
+
+-- Create the vote audit table
+CREATE TABLE IF NOT EXISTS vote_audit (
+  id TEXT PRIMARY KEY,    -- Unique identifier for the audit record
+  survey TEXT,            -- Identifier for the survey
+  question INT,           -- Number of the question being voted on
+  reason TEXT,            -- Reason the vote was rejected
+  client_ip TEXT,         -- IP address of the client that sent the vote
+  fingerprint TEXT,       -- Fingerprint of the client that sent the vote, empty when not sent
+  created BIGINT          -- Timestamp of when the vote was rejected
+);
+
+-- Create the sessions table
+CREATE TABLE IF NOT EXISTS sessions (
+  id TEXT PRIMARY KEY,    -- Unique identifier for the session
+  token_hash TEXT UNIQUE, -- SHA-256 hash of the token used to resume the session
+  survey TEXT,            -- Identifier for the survey
+  user_id TEXT,           -- Identifier of the authenticated user who started the session, NULL for anonymous sessions
+  answers JSONB,          -- Answers saved so far, one per question
+  status TEXT,            -- Status of the session, in_progress, finalizing, completed or expired
+  created BIGINT,         -- Timestamp of when the session was started
+  updated BIGINT,         -- Timestamp of when answers were last saved
+  expires_at BIGINT,      -- Timestamp of when the session expires unless answers are saved
+  completed_at BIGINT     -- Timestamp of when the session was finalized, NULL until then
+);
+CREATE INDEX IF NOT EXISTS sessions_survey_status ON sessions (survey, status);
+CREATE INDEX IF NOT EXISTS sessions_status_expires ON sessions (status, expires_at);
+
+-- Create the files table
+CREATE TABLE IF NOT EXISTS files (
+  id TEXT PRIMARY KEY,    -- Unique identifier for the file, also its key in the blob storage
+  survey TEXT,            -- Identifier for the survey
+  question INT,           -- Number of the question the file was uploaded for
+  name TEXT,              -- Original name of the file
+  content_type TEXT,      -- MIME type of the file
+  size BIGINT,            -- Size of the file in bytes
+  user_id TEXT,           -- Identifier of the authenticated user who uploaded the file, NULL for anonymous uploads
+  created BIGINT,         -- Timestamp of when the file was uploaded
+  attached_at BIGINT      -- Timestamp of when a vote referenced the file, NULL while no vote does
+);
+ALTER TABLE files ADD COLUMN IF NOT EXISTS attached_at BIGINT;
+-- Files answering votes cast before attachments were tracked are not orphans
+UPDATE files SET attached_at = created WHERE attached_at IS NULL AND id IN (SELECT answer->'file'->>'id' FROM votes WHERE answer ? 'file');
+CREATE INDEX IF NOT EXISTS files_unattached_created ON files (created) WHERE attached_at IS NULL;
+
+-- Create the respondent token uses table
+-- This table remembers which respondent token answered which question, so replays are rejected by every vote service instance
+CREATE TABLE IF NOT EXISTS respondent_token_uses (
+  key TEXT PRIMARY KEY,   -- Token ID and question number
+  expires_at BIGINT       -- Timestamp of when the token expires, the row can be removed afterwards
+);
@@ -0,0 +1,149 @@
+package apikey
+
+import (
+	"testing"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// testEditor issues the test keys
+	testEditor = &survey.User{ID: "editor-1", Roles: []survey.Role{survey.RoleEditor}}
+
+	// testOtherEditor is an editor who did not issue the test keys
+	testOtherEditor = &survey.User{ID: "editor-2", Roles: []survey.Role{survey.RoleEditor}}
+
+	// testViewer is a user who can only view surveys
+	testViewer = &survey.User{ID: "viewer-1", Roles: []survey.Role{survey.RoleViewer}}
+)
+
+// testRepository is an API key repository keeping keys and owners in memory
+type testRepository struct {
+	keys   map[string]*Key
+	owners map[string]*Owner
+}
+
+// newTestRepository creates an empty test repository
+func newTestRepository() *testRepository {
+	return &testRepository{keys: make(map[string]*Key), owners: make(map[string]*Owner)}
+}
+
+// Insert stores the key
+func (r *testRepository) Insert(k *Key) error {
+	r.keys[k.ID] = k
+	return nil
+}
+
+// LoadByID returns the key with the ID
+func (r *testRepository) LoadByID(id string) (*Key, error) {
+	if k, ok := r.keys[id]; ok {
+		return k, nil
+	}
+	return nil, ErrNotFound
+}
+
+// LoadByHash returns the key with the hash
+func (r *testRepository) LoadByHash(hash string) (*Key, error) {
+	for _, k := range r.keys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// LoadByOwner returns the keys of the owner
+func (r *testRepository) LoadByOwner(ownerID string) ([]*Key, error) {
+	var keys []*Key
+	for _, k := range r.keys {
+		if k.OwnerID == ownerID {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// Update replaces the key
+func (r *testRepository) Update(k *Key) error {
+	r.keys[k.ID] = k
+	return nil
+}
+
+// SaveOwner stores the owner
+func (r *testRepository) SaveOwner(o *Owner) error {
+	r.owners[o.ID] = o
+	return nil
+}
+
+// LoadOwner returns the owner with the ID
+func (r *testRepository) LoadOwner(id string) (*Owner, error) {
+	if o, ok := r.owners[id]; ok {
+		return o, nil
+	}
+	return nil, ErrNotFound
+}
+
+// TestIssueAndVerify tests that issued keys verify until they are revoked
+func TestIssueAndVerify(t *testing.T) {
+	service := NewService(newTestRepository())
+
+	issued, err := service.Issue(testEditor, &IssueRequest{Name: "CI", Scopes: []string{auth.ScopeSurveysRead, auth.ScopeSurveysWrite}})
+	assert.NoError(t, err, "Expected no error on issue")
+	assert.NotEmpty(t, issued.Secret, "Expected the key to be returned on issue")
+	assert.NotContains(t, issued.Hash, issued.Secret, "Expected only the hash of the key to be stored")
+	assert.Equal(t, issued.Secret[:len(issued.Prefix)], issued.Prefix, "Expected the prefix to identify the key")
+
+	k, err := service.Verify(issued.Secret)
+	assert.NoError(t, err, "Expected the issued key to verify")
+	assert.Equal(t, testEditor.ID, k.OwnerID, "Expected the key to act as its owner")
+	assert.Equal(t, []string{"editor"}, k.Roles, "Expected the key to inherit the roles of its owner")
+
+	_, err = service.Verify(issued.Secret + "x")
+	assert.ErrorIs(t, err, ErrInvalidKey, "Expected unknown keys to be rejected")
+
+	assert.ErrorIs(t, service.Revoke(testOtherEditor, issued.ID), ErrForbidden, "Expected other users not to revoke the key")
+	assert.NoError(t, service.Revoke(testEditor, issued.ID), "Expected the owner to revoke the key")
+	_, err = service.Verify(issued.Secret)
+	assert.ErrorIs(t, err, ErrInvalidKey, "Expected revoked keys to be rejected")
+
+	keys, _ := service.Load(testEditor)
+	assert.Len(t, keys, 1, "Expected revoked keys to still be listed")
+}
+
+// TestIssueScopes tests that keys can only be issued with known scopes the user may use
+func TestIssueScopes(t *testing.T) {
+	service := NewService(newTestRepository())
+
+	_, err := service.Issue(testEditor, &IssueRequest{Name: "CI", Scopes: []string{"surveys:delete"}})
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected unknown scopes to be rejected")
+	_, err = service.Issue(testEditor, &IssueRequest{Name: "CI"})
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected keys without scopes to be rejected")
+	_, err = service.Issue(testViewer, &IssueRequest{Name: "CI", Scopes: []string{auth.ScopeSurveysWrite}})
+	assert.ErrorIs(t, err, ErrForbidden, "Expected viewers not to issue keys writing surveys")
+	_, err = service.Issue(testViewer, &IssueRequest{Name: "CI", Scopes: []string{auth.ScopeResultsRead}})
+	assert.NoError(t, err, "Expected viewers to issue read keys")
+	_, err = service.Issue(nil, &IssueRequest{Name: "CI", Scopes: []string{auth.ScopeResultsRead}})
+	assert.ErrorIs(t, err, ErrForbidden, "Expected anonymous users not to issue keys")
+}
+
+// TestOwnerRoles tests that keys act with the roles their owner still has
+func TestOwnerRoles(t *testing.T) {
+	service := NewService(newTestRepository())
+
+	issued, err := service.Issue(testEditor, &IssueRequest{Name: "CI", Scopes: []string{auth.ScopeSurveysWrite}})
+	assert.NoError(t, err, "Expected no error on issue")
+
+	demoted := &survey.User{ID: testEditor.ID, Roles: []survey.Role{survey.RoleViewer}}
+	assert.NoError(t, service.SyncOwner(demoted), "Expected no error on recording the roles of the owner")
+	k, err := service.Verify(issued.Secret)
+	assert.NoError(t, err, "Expected the key to verify")
+	assert.Empty(t, k.Roles, "Expected the key to lose the roles its owner lost")
+
+	promoted := &survey.User{ID: testEditor.ID, Roles: []survey.Role{survey.RoleEditor, survey.RoleAdmin}}
+	assert.NoError(t, service.SyncOwner(promoted), "Expected no error on recording the roles of the owner")
+	k, err = service.Verify(issued.Secret)
+	assert.NoError(t, err, "Expected the key to verify")
+	assert.Equal(t, []string{"editor"}, k.Roles, "Expected the key not to gain roles it was not issued with")
+}
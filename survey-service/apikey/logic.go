@@ -0,0 +1,243 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/go-playground/validator"
+	"github.com/teris-io/shortid"
+)
+
+var (
+	// ErrNotFound indicates that a requested API key was not found
+	ErrNotFound = errors.New("API key not found")
+
+	// ErrInvalidRequest indicates that an invalid API key request was provided
+	ErrInvalidRequest = errors.New("invalid API key input")
+
+	// ErrForbidden indicates that the user is not allowed to manage the API key
+	ErrForbidden = errors.New("access to API key forbidden")
+
+	// ErrInvalidKey indicates that an API key is unknown or has been revoked
+	ErrInvalidKey = errors.New("invalid API key")
+)
+
+// Format of issued keys
+const (
+	keyPrefix    = "sk_" // Prefix marking survey service API keys
+	keyBytes     = 32    // Random bytes of a key
+	prefixLength = 10    // Characters of a key stored to recognize it
+)
+
+// keyService implements the Service interface for managing API keys
+type keyService struct {
+	repository Repository
+	validator  *validator.Validate
+	owners     map[string]string // Roles last recorded for each owner, to skip unchanged writes
+	mutex      sync.Mutex
+}
+
+// NewService creates a new API key service
+// This function initializes a new keyService with the given repository and a validator
+func NewService(repository Repository) Service {
+	return &keyService{
+		repository: repository,
+		validator:  validator.New(),
+		owners:     make(map[string]string),
+	}
+}
+
+// Issue validates the request and issues a new API key
+// Keys inherit the roles of their owner, and only users who can create surveys can issue keys writing surveys
+func (s *keyService) Issue(user *survey.User, r *IssueRequest) (*IssuedKey, error) {
+	if user == nil {
+		return nil, ErrForbidden
+	}
+	if err := s.validator.Struct(r); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	for _, scope := range r.Scopes {
+		if !isScope(scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidRequest, scope)
+		}
+		if scope == auth.ScopeSurveysWrite && !user.CanCreate() {
+			return nil, ErrForbidden
+		}
+	}
+
+	if err := s.SyncOwner(user); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	roles := userRoles(user)
+
+	k := &Key{
+		ID:        shortid.MustGenerate(),
+		Name:      r.Name,
+		Prefix:    secret[:prefixLength],
+		Hash:      hashSecret(secret),
+		OwnerID:   user.ID,
+		Roles:     roles,
+		Scopes:    r.Scopes,
+		CreatedAt: time.Now().UTC().Unix(),
+	}
+	if err := s.repository.Insert(k); err != nil {
+		return nil, err
+	}
+
+	return &IssuedKey{Key: k, Secret: secret}, nil
+}
+
+// Load loads the API keys issued by the user
+func (s *keyService) Load(user *survey.User) ([]*Key, error) {
+	if user == nil {
+		return nil, ErrForbidden
+	}
+	return s.repository.LoadByOwner(user.ID)
+}
+
+// Revoke revokes an API key owned by the user
+// Revoking a key that is already revoked has no effect
+func (s *keyService) Revoke(user *survey.User, id string) error {
+	if user == nil {
+		return ErrForbidden
+	}
+
+	k, err := s.repository.LoadByID(id)
+	if err != nil {
+		return err
+	}
+	if k.OwnerID != user.ID && !user.IsAdmin() {
+		return ErrForbidden
+	}
+	if k.IsRevoked() {
+		return nil
+	}
+
+	k.RevokedAt = time.Now().UTC().Unix()
+	return s.repository.Update(k)
+}
+
+// Verify looks up an API key by its hash and checks it has not been revoked
+// The key acts with the roles it was issued with that its owner still has, none if the owner's roles are unknown
+func (s *keyService) Verify(secret string) (*Key, error) {
+	if secret == "" {
+		return nil, ErrInvalidKey
+	}
+
+	k, err := s.repository.LoadByHash(hashSecret(secret))
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if k.IsRevoked() {
+		return nil, ErrInvalidKey
+	}
+
+	var current []string
+	o, err := s.repository.LoadOwner(k.OwnerID)
+	if err == nil {
+		current = o.Roles
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	resolved := *k
+	resolved.Roles = intersect(k.Roles, current)
+	return &resolved, nil
+}
+
+// SyncOwner records the current roles of a user
+// Roles are only written when they differ from the ones last recorded by this instance
+func (s *keyService) SyncOwner(user *survey.User) error {
+	if user == nil {
+		return ErrForbidden
+	}
+
+	roles := userRoles(user)
+	sort.Strings(roles)
+	seen := strings.Join(roles, ",")
+
+	s.mutex.Lock()
+	last, ok := s.owners[user.ID]
+	s.mutex.Unlock()
+	if ok && last == seen {
+		return nil
+	}
+
+	o := &Owner{ID: user.ID, Roles: roles, UpdatedAt: time.Now().UTC().Unix()}
+	if err := s.repository.SaveOwner(o); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.owners[user.ID] = seen
+	s.mutex.Unlock()
+	return nil
+}
+
+// generateSecret generates a new random API key
+func generateSecret() (string, error) {
+	b := make([]byte, keyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashSecret hashes an API key for storage
+// Keys are long random strings, so an unsalted hash is enough to protect them at rest
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// userRoles returns the roles of a user as strings
+func userRoles(user *survey.User) []string {
+	roles := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		roles = append(roles, string(role))
+	}
+	return roles
+}
+
+// intersect returns the roles of a that are also in b
+func intersect(a, b []string) []string {
+	roles := make([]string, 0, len(a))
+	for _, role := range a {
+		for _, other := range b {
+			if role == other {
+				roles = append(roles, role)
+				break
+			}
+		}
+	}
+	return roles
+}
+
+// isScope checks if a scope can be granted to API keys
+func isScope(scope string) bool {
+	for _, s := range auth.Scopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package apikey
+
+// Key describes an API key issued to a machine client
+// This struct represents a key acting on behalf of its owner, limited to the scopes it was issued with
+type Key struct {
+	ID        string   `json:"id" bson:"id"`                         // Unique identifier for the key
+	Name      string   `json:"name" bson:"name"`                     // Name describing the client using the key
+	Prefix    string   `json:"prefix" bson:"prefix"`                 // First characters of the key, used to recognize it
+	Hash      string   `json:"-" bson:"hash"`                        // SHA-256 hash of the key, the key itself is never stored
+	OwnerID   string   `json:"ownerId" bson:"ownerId"`               // ID of the user who issued the key
+	Roles     []string `json:"roles" bson:"roles"`                   // Roles of the owner when the key was issued, keys act with the ones the owner still has
+	Scopes    []string `json:"scopes" bson:"scopes"`                 // Scopes the key can access
+	CreatedAt int64    `json:"createdAt" bson:"createdAt"`           // Timestamp of when the key was issued
+	RevokedAt int64    `json:"revokedAt,omitempty" bson:"revokedAt"` // Timestamp of when the key was revoked, zero while valid
+}
+
+// Owner describes the roles a user issuing API keys was last seen with
+// Roles are recorded from the bearer tokens of the user, API keys never act with roles their owner has lost
+type Owner struct {
+	ID        string   `json:"id" bson:"id"`               // ID of the user
+	Roles     []string `json:"roles" bson:"roles"`         // Roles of the latest bearer token of the user
+	UpdatedAt int64    `json:"updatedAt" bson:"updatedAt"` // Timestamp of when the roles were recorded
+}
+
+// IssuedKey is a newly issued API key
+// The key itself is only returned once, when it is issued
+type IssuedKey struct {
+	*Key
+	Secret string `json:"key"` // The API key to send in the X-API-Key header
+}
+
+// IssueRequest describes a request to issue an API key
+type IssueRequest struct {
+	Name   string   `json:"name" validate:"required"`         // Name describing the client using the key
+	Scopes []string `json:"scopes" validate:"required,min=1"` // Scopes the key can access
+}
+
+// ErrorResponse provides a structure for error responses
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// IsRevoked checks if the key has been revoked
+func (k *Key) IsRevoked() bool {
+	return k.RevokedAt != 0
+}
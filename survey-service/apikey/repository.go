@@ -0,0 +1,33 @@
+package apikey
+
+// Repository contains functions to store and fetch API keys from a repository
+// This interface defines the methods required for interacting with an API key repository
+type Repository interface {
+	// Insert stores a new API key
+	// This method saves a new key to the repository
+	Insert(k *Key) error
+
+	// LoadByID loads an API key by ID
+	// This method returns ErrNotFound for unknown keys
+	LoadByID(id string) (*Key, error)
+
+	// LoadByHash loads an API key by the hash of the key
+	// This method returns ErrNotFound for unknown keys
+	LoadByHash(hash string) (*Key, error)
+
+	// LoadByOwner loads the API keys issued by a user
+	// This method retrieves revoked keys too, newest first
+	LoadByOwner(ownerID string) ([]*Key, error)
+
+	// Update updates an existing API key
+	// This method updates a key in the repository
+	Update(k *Key) error
+
+	// SaveOwner stores the roles of a key owner
+	// This method replaces the roles recorded before
+	SaveOwner(o *Owner) error
+
+	// LoadOwner loads the roles of a key owner
+	// This method returns ErrNotFound for users whose roles were never recorded
+	LoadOwner(id string) (*Owner, error)
+}
@@ -0,0 +1,29 @@
+package apikey
+
+// Serializer contains functions to encode and decode API keys
+// This interface defines the methods required for serializing and deserializing API key data
+type Serializer interface {
+	// Encode encodes an API key
+	// This method converts a key into a byte slice (e.g., JSON)
+	Encode(k *Key) ([]byte, error)
+
+	// EncodeIssued encodes a newly issued API key
+	// This method converts an issued key, including the key itself, into a byte slice (e.g., JSON)
+	EncodeIssued(k *IssuedKey) ([]byte, error)
+
+	// EncodeMultiple encodes multiple API keys
+	// This method converts a collection of keys into a byte slice (e.g., JSON)
+	EncodeMultiple(keys []*Key) ([]byte, error)
+
+	// EncodeErrorResponse encodes an error response
+	// This method converts an error response into a byte slice (e.g., JSON)
+	EncodeErrorResponse(er ErrorResponse) ([]byte, error)
+
+	// DecodeIssueRequest decodes a request to issue an API key
+	// This method converts a byte slice into an issue request (e.g., from JSON)
+	DecodeIssueRequest(data []byte) (*IssueRequest, error)
+
+	// GetContentType returns the content-type
+	// This method returns the MIME type of the serialized data (e.g., "application/json")
+	GetContentType() string
+}
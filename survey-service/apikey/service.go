@@ -0,0 +1,28 @@
+package apikey
+
+import "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+
+// Service contains functions to issue, revoke and verify API keys
+// This interface defines the methods required for managing API keys in the application
+// Methods taking a user return ErrForbidden when the user is not allowed to perform them
+type Service interface {
+	// Issue issues a new API key owned by the user
+	// This method returns the key itself, which cannot be retrieved again
+	Issue(user *survey.User, r *IssueRequest) (*IssuedKey, error)
+
+	// Load loads the API keys issued by the user
+	// This method retrieves revoked keys too
+	Load(user *survey.User) ([]*Key, error)
+
+	// Revoke revokes an API key
+	// This method lets owners revoke their keys and admins revoke every key
+	Revoke(user *survey.User, id string) error
+
+	// Verify verifies an API key
+	// This method returns ErrInvalidKey for unknown and revoked keys, and the key with the roles its owner still has
+	Verify(secret string) (*Key, error)
+
+	// SyncOwner records the current roles of a user authenticated with a bearer token
+	// This method limits the roles of the API keys of the user to these roles
+	SyncOwner(user *survey.User) error
+}
@@ -0,0 +1,54 @@
+package apikey
+
+import (
+	"errors"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+)
+
+// keyVerifier implements the auth.KeyVerifier and auth.OwnerRecorder interfaces with the API key service
+type keyVerifier struct {
+	service Service
+}
+
+// NewVerifier creates a verifier authenticating requests with the API keys of the service
+func NewVerifier(service Service) auth.KeyVerifier {
+	return &keyVerifier{service}
+}
+
+// NewOwnerRecorder creates a recorder keeping the roles of the API keys of the service in line with their owners
+func NewOwnerRecorder(service Service) auth.OwnerRecorder {
+	return &keyVerifier{service}
+}
+
+// VerifyAPIKey verifies an API key and returns a principal acting as the owner of the key
+func (v *keyVerifier) VerifyAPIKey(secret string) (*auth.Principal, error) {
+	k, err := v.service.Verify(secret)
+	if errors.Is(err, ErrInvalidKey) {
+		return nil, auth.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Principal(k), nil
+}
+
+// RecordOwner records the roles of a principal authenticated with a bearer token as the roles of its API keys
+func (v *keyVerifier) RecordOwner(p *auth.Principal) error {
+	u := &survey.User{ID: p.Subject}
+	for _, role := range p.Roles {
+		u.Roles = append(u.Roles, survey.Role(role))
+	}
+	return v.service.SyncOwner(u)
+}
+
+// Principal returns the principal authenticated by an API key
+func Principal(k *Key) *auth.Principal {
+	return &auth.Principal{
+		Subject: k.OwnerID,
+		Roles:   k.Roles,
+		Scopes:  k.Scopes,
+		KeyID:   k.ID,
+	}
+}
@@ -0,0 +1,28 @@
+package auth
+
+// Scopes API keys can be issued with
+const (
+	ScopeSurveysRead  = "surveys:read"  // Read surveys
+	ScopeSurveysWrite = "surveys:write" // Create and manage surveys
+	ScopeVotesWrite   = "votes:write"   // Cast votes
+	ScopeResultsRead  = "results:read"  // Read results
+)
+
+// Scopes returns all scopes API keys can be issued with
+func Scopes() []string {
+	return []string{ScopeSurveysRead, ScopeSurveysWrite, ScopeVotesWrite, ScopeResultsRead}
+}
+
+// KeyVerifier contains functions to verify the API keys of machine clients
+type KeyVerifier interface {
+	// VerifyAPIKey verifies an API key and returns the principal it authenticates
+	// This method returns ErrInvalidAPIKey for unknown and revoked keys
+	VerifyAPIKey(key string) (*Principal, error)
+}
+
+// OwnerRecorder contains functions to keep the roles of API keys in line with their owners
+type OwnerRecorder interface {
+	// RecordOwner records the current roles of a principal authenticated with a bearer token
+	// API keys issued by the principal act with these roles at most
+	RecordOwner(p *Principal) error
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrMissingToken indicates that a request did not provide a bearer token
+	ErrMissingToken = errors.New("missing bearer token")
+
+	// ErrInvalidToken indicates that a bearer token could not be verified
+	ErrInvalidToken = errors.New("invalid bearer token")
+
+	// ErrInvalidAPIKey indicates that an API key is unknown or has been revoked
+	ErrInvalidAPIKey = errors.New("invalid API key")
+)
+
+type key int
+
+// principalKey is used as a key to store the principal in the context
+const principalKey key = 0
+
+// Principal describes the authenticated caller of a request
+type Principal struct {
+	Subject string   // Subject of the token, identifies the caller
+	Roles   []string // Roles granted to the caller
+	Scopes  []string // Scopes granted to the caller
+	KeyID   string   // ID of the API key the caller authenticated with, empty for bearer tokens
+}
+
+// HasRole checks if the principal has been granted a role
+func (p *Principal) HasRole(role string) bool {
+	return contains(p.Roles, role)
+}
+
+// HasScope checks if the principal has been granted a scope
+func (p *Principal) HasScope(scope string) bool {
+	return contains(p.Scopes, scope)
+}
+
+// IsAPIKey checks if the principal authenticated with an API key
+func (p *Principal) IsAPIKey() bool {
+	return p.KeyID != ""
+}
+
+// AllowsScope checks if the principal may access endpoints requiring a scope
+// API keys are limited to the scopes they were issued with, bearer tokens act with the full rights of their user
+func (p *Principal) AllowsScope(scope string) bool {
+	return !p.IsAPIKey() || p.HasScope(scope)
+}
+
+// NewContext returns a copy of the context carrying the principal
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// FromContext returns the principal stored in the context, if any
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey).(*Principal)
+	return p, ok
+}
+
+// Config stores the settings used to verify bearer tokens
+// Services read these settings from their own configuration, so every service verifies tokens alike
+type Config struct {
+	KeySource string        // Source of token verification keys, hmac or jwks
+	Secret    string        // Shared secret used to verify HMAC signed tokens
+	JWKSFile  string        // Path of a JWKS file with the public keys used to verify tokens
+	Issuer    string        // Required token issuer, not checked when empty
+	Audience  string        // Required token audience, not checked when empty
+	Leeway    time.Duration // Allowed clock skew when validating token times
+}
+
+// claims describes the claims read from a token
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"` // Roles granted to the subject
+	Scope string   `json:"scope,omitempty"` // Space separated scopes granted to the subject
+}
+
+// Authenticator verifies bearer tokens
+type Authenticator struct {
+	keys   KeySource
+	parser *jwt.Parser
+}
+
+// NewAuthenticator creates a new authenticator
+// This function initializes an authenticator that verifies tokens with keys from the given source
+func NewAuthenticator(keys KeySource, cfg Config) *Authenticator {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(keys.Methods()),
+		jwt.WithLeeway(cfg.Leeway),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Authenticator{
+		keys:   keys,
+		parser: jwt.NewParser(opts...),
+	}
+}
+
+// Authenticate verifies a token and returns the principal it identifies
+func (a *Authenticator) Authenticate(token string) (*Principal, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+
+	var c claims
+	if _, err := a.parser.ParseWithClaims(token, &c, a.keys.Key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return &Principal{
+		Subject: c.Subject,
+		Roles:   c.Roles,
+		Scopes:  strings.Fields(c.Scope),
+	}, nil
+}
+
+// contains reports whether a value is in the given list
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
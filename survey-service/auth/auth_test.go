@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// sign creates a signed token with the given claims
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, c jwt.MapClaims, kid string) string {
+	token := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	assert.NoError(t, err, "Expected no error on signing token")
+	return s
+}
+
+// validClaims returns claims of a token valid for the next hour
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "issuer",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"admin"},
+		"scope": "surveys:read surveys:write",
+	}
+}
+
+// testSecret is a shared secret long enough for HMAC key sources
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// TestAuthenticateHMAC tests verifying tokens signed with a shared secret
+func TestAuthenticateHMAC(t *testing.T) {
+	keys, err := NewHMACKeySource(testSecret)
+	assert.NoError(t, err, "Expected no error on creating HMAC key source")
+	a := NewAuthenticator(keys, Config{Issuer: "issuer"})
+
+	p, err := a.Authenticate(sign(t, jwt.SigningMethodHS256, testSecret, validClaims(), ""))
+	assert.NoError(t, err, "Expected valid token to be accepted")
+	assert.Equal(t, "user-1", p.Subject, "Expected subject of the token")
+	assert.True(t, p.HasRole("admin"), "Expected admin role")
+	assert.True(t, p.HasScope("surveys:write"), "Expected scopes to be split")
+
+	_, err = a.Authenticate("")
+	assert.ErrorIs(t, err, ErrMissingToken, "Expected missing token error")
+
+	_, err = a.Authenticate(sign(t, jwt.SigningMethodHS256, []byte("other"), validClaims(), ""))
+	assert.ErrorIs(t, err, ErrInvalidToken, "Expected token signed with another secret to be rejected")
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	_, err = a.Authenticate(sign(t, jwt.SigningMethodHS256, testSecret, expired, ""))
+	assert.ErrorIs(t, err, ErrInvalidToken, "Expected expired token to be rejected")
+
+	noExp := validClaims()
+	delete(noExp, "exp")
+	_, err = a.Authenticate(sign(t, jwt.SigningMethodHS256, testSecret, noExp, ""))
+	assert.ErrorIs(t, err, ErrInvalidToken, "Expected token without expiration to be rejected")
+
+	otherIssuer := validClaims()
+	otherIssuer["iss"] = "other"
+	_, err = a.Authenticate(sign(t, jwt.SigningMethodHS256, testSecret, otherIssuer, ""))
+	assert.ErrorIs(t, err, ErrInvalidToken, "Expected token from another issuer to be rejected")
+
+	_, err = a.Authenticate(sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, validClaims(), ""))
+	assert.ErrorIs(t, err, ErrInvalidToken, "Expected unsigned token to be rejected")
+
+	_, err = NewHMACKeySource(nil)
+	assert.Error(t, err, "Expected an error for an empty secret")
+
+	_, err = NewHMACKeySource([]byte("change-me"))
+	assert.Error(t, err, "Expected an error for a short secret")
+}
+
+// TestAuthenticateJWKS tests verifying tokens signed with a key from a JWKS file
+func TestAuthenticateJWKS(t *testing.T) {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err, "Expected no error on generating RSA key")
+
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"k1","use":"sig","n":%q,"e":%q},{"kty":"oct","kid":"k2"}]}`,
+		base64.RawURLEncoding.EncodeToString(pk.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pk.E)).Bytes()),
+	)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(path, []byte(jwks), 0600), "Expected no error on writing JWKS file")
+
+	keys, err := NewKeySource(Config{KeySource: "jwks", JWKSFile: path})
+	assert.NoError(t, err, "Expected no error on loading JWKS file")
+	a := NewAuthenticator(keys, Config{})
+
+	p, err := a.Authenticate(sign(t, jwt.SigningMethodRS256, pk, validClaims(), "k1"))
+	assert.NoError(t, err, "Expected token signed with a known key to be accepted")
+	assert.Equal(t, "user-1", p.Subject, "Expected subject of the token")
+
+	_, err = a.Authenticate(sign(t, jwt.SigningMethodRS256, pk, validClaims(), "unknown"))
+	assert.ErrorIs(t, err, ErrInvalidToken, "Expected token signed with an unknown key to be rejected")
+
+	// An HMAC token signed with the public key must not pass as an RSA token
+	_, err = a.Authenticate(sign(t, jwt.SigningMethodHS256, pk.N.Bytes(), validClaims(), "k1"))
+	assert.ErrorIs(t, err, ErrInvalidToken, "Expected HMAC token to be rejected by the JWKS key source")
+}
+
+// TestNewKeySource tests the selection of the key source from configuration
+func TestNewKeySource(t *testing.T) {
+	keys, err := NewKeySource(Config{KeySource: "hmac", Secret: string(testSecret)})
+	assert.NoError(t, err, "Expected no error for the HMAC key source")
+	assert.NotNil(t, keys, "Expected an HMAC key source")
+
+	_, err = NewKeySource(Config{KeySource: "jwks", JWKSFile: "missing.json"})
+	assert.Error(t, err, "Expected an error for a missing JWKS file")
+
+	_, err = NewKeySource(Config{KeySource: "unknown"})
+	assert.Error(t, err, "Expected an error for an unknown key source")
+}
+
+// TestPrincipalContext tests storing a principal in a context
+func TestPrincipalContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok, "Expected no principal in an empty context")
+
+	p := &Principal{Subject: "user-1"}
+	got, ok := FromContext(NewContext(context.Background(), p))
+	assert.True(t, ok, "Expected a principal in the context")
+	assert.Equal(t, p, got, "Expected the stored principal")
+}
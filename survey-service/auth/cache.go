@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+)
+
+// cachedKeyVerifier implements the KeyVerifier interface by caching the results of another verifier
+// Keys are cached by their hash, so the keys themselves are never held in memory
+type cachedKeyVerifier struct {
+	next    KeyVerifier
+	ttl     time.Duration
+	size    int
+	entries map[[sha256.Size]byte]cachedKey
+	mutex   sync.Mutex
+}
+
+// cachedKey is the cached result of verifying an API key
+type cachedKey struct {
+	principal *Principal // Principal authenticated by the key, nil for invalid keys
+	expiresAt time.Time  // Time after which the key is verified again
+}
+
+// NewCachedKeyVerifier creates a verifier caching the results of another verifier for a time to live
+// Invalid keys are cached too, so repeated guesses do not reach the verifier, and revocations take effect after at most one time to live
+// At most size keys are cached, and caching is disabled when the time to live or the size is not positive
+func NewCachedKeyVerifier(next KeyVerifier, ttl time.Duration, size int) KeyVerifier {
+	if ttl <= 0 || size <= 0 {
+		return next
+	}
+	return &cachedKeyVerifier{
+		next:    next,
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[[sha256.Size]byte]cachedKey),
+	}
+}
+
+// VerifyAPIKey returns the cached result for a key, verifying it with the next verifier when it is not cached
+// Errors other than ErrInvalidAPIKey are not cached
+func (v *cachedKeyVerifier) VerifyAPIKey(key string) (*Principal, error) {
+	hash := sha256.Sum256([]byte(key))
+	now := time.Now()
+
+	v.mutex.Lock()
+	entry, ok := v.entries[hash]
+	v.mutex.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		if entry.principal == nil {
+			return nil, ErrInvalidAPIKey
+		}
+		return entry.principal, nil
+	}
+
+	p, err := v.next.VerifyAPIKey(key)
+	if err != nil && !errors.Is(err, ErrInvalidAPIKey) {
+		return nil, err
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if len(v.entries) >= v.size {
+		v.sweep(now)
+	}
+	if len(v.entries) < v.size {
+		v.entries[hash] = cachedKey{principal: p, expiresAt: now.Add(v.ttl)}
+	}
+	return p, err
+}
+
+// sweep removes the expired entries of the cache
+// This method must be called with the mutex held
+func (v *cachedKeyVerifier) sweep(now time.Time) {
+	for hash, entry := range v.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(v.entries, hash)
+		}
+	}
+}
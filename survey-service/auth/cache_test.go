@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingVerifier is a key verifier counting its calls
+type countingVerifier struct {
+	calls int
+	err   error
+}
+
+// VerifyAPIKey accepts the key "valid" and rejects every other key
+func (v *countingVerifier) VerifyAPIKey(key string) (*Principal, error) {
+	v.calls++
+	if v.err != nil {
+		return nil, v.err
+	}
+	if key != "valid" {
+		return nil, ErrInvalidAPIKey
+	}
+	return &Principal{Subject: "user-1", KeyID: "key-1"}, nil
+}
+
+// TestCachedKeyVerifier tests that valid and invalid keys are cached until they expire
+func TestCachedKeyVerifier(t *testing.T) {
+	next := &countingVerifier{}
+	v := NewCachedKeyVerifier(next, 50*time.Millisecond, 10)
+
+	for i := 0; i < 3; i++ {
+		p, err := v.VerifyAPIKey("valid")
+		assert.NoError(t, err, "Expected the valid key to verify")
+		assert.Equal(t, "key-1", p.KeyID, "Expected the principal of the key")
+		_, err = v.VerifyAPIKey("guess")
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, "Expected the invalid key to be rejected")
+	}
+	assert.Equal(t, 2, next.calls, "Expected each key to be verified once")
+
+	time.Sleep(60 * time.Millisecond)
+	_, err := v.VerifyAPIKey("valid")
+	assert.NoError(t, err, "Expected the valid key to verify again")
+	assert.Equal(t, 3, next.calls, "Expected expired keys to be verified again")
+}
+
+// TestCachedKeyVerifierErrors tests that failures of the verifier are not cached
+func TestCachedKeyVerifierErrors(t *testing.T) {
+	next := &countingVerifier{err: errors.New("unavailable")}
+	v := NewCachedKeyVerifier(next, time.Minute, 10)
+
+	_, err := v.VerifyAPIKey("valid")
+	assert.Error(t, err, "Expected the failure to be returned")
+	next.err = nil
+	_, err = v.VerifyAPIKey("valid")
+	assert.NoError(t, err, "Expected the key to verify once the verifier recovers")
+	assert.Equal(t, 2, next.calls, "Expected failures not to be cached")
+}
+
+// TestCachedKeyVerifierSize tests that the cache does not grow beyond its size
+func TestCachedKeyVerifierSize(t *testing.T) {
+	next := &countingVerifier{}
+	v := NewCachedKeyVerifier(next, time.Minute, 1)
+
+	v.VerifyAPIKey("valid")
+	v.VerifyAPIKey("guess")
+	v.VerifyAPIKey("guess")
+	v.VerifyAPIKey("valid")
+	assert.Equal(t, 3, next.calls, "Expected keys beyond the size not to be cached")
+	assert.Len(t, v.(*cachedKeyVerifier).entries, 1, "Expected at most one cached key")
+
+	assert.Equal(t, KeyVerifier(next), NewCachedKeyVerifier(next, 0, 10), "Expected caching to be disabled without a time to live")
+}
@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeySource provides the keys used to verify token signatures
+type KeySource interface {
+	// Key returns the key to verify the signature of a token
+	// This method is used as the key function when parsing tokens
+	Key(token *jwt.Token) (interface{}, error)
+
+	// Methods returns the signing methods accepted by the key source
+	// Tokens signed with any other method are rejected before their key is looked up
+	Methods() []string
+}
+
+// NewKeySource creates a new key source based on the authentication configuration
+// This function selects the appropriate key source type based on the configuration
+func NewKeySource(cfg Config) (KeySource, error) {
+	switch cfg.KeySource {
+	case "hmac":
+		// Verify tokens with a shared secret
+		return NewHMACKeySource([]byte(cfg.Secret))
+	case "jwks":
+		// Verify tokens with public keys read from a JWKS file
+		return NewJWKSFileKeySource(cfg.JWKSFile)
+	default:
+		// Return an error if no valid key source type is specified
+		return nil, errors.New("no key source available")
+	}
+}
+
+// hmacKeySource verifies tokens signed with a static shared secret
+type hmacKeySource struct {
+	secret []byte
+}
+
+// MinHMACSecretLength is the minimum length in bytes of the shared secret of HMAC signed tokens
+// Shorter secrets can be guessed, which lets anyone sign tokens with any role
+const MinHMACSecretLength = 32
+
+// NewHMACKeySource creates a new key source for a static HMAC secret
+// This function rejects secrets shorter than MinHMACSecretLength
+func NewHMACKeySource(secret []byte) (KeySource, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("HMAC secret is required")
+	}
+	if len(secret) < MinHMACSecretLength {
+		return nil, fmt.Errorf("HMAC secret must be at least %d bytes", MinHMACSecretLength)
+	}
+	return &hmacKeySource{secret}, nil
+}
+
+// Key returns the shared secret
+func (s *hmacKeySource) Key(token *jwt.Token) (interface{}, error) {
+	return s.secret, nil
+}
+
+// Methods returns the HMAC signing methods
+func (s *hmacKeySource) Methods() []string {
+	return []string{"HS256", "HS384", "HS512"}
+}
+
+// jwk describes a single JSON web key
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwksKeySource verifies tokens signed with the public keys of a JWKS document
+type jwksKeySource struct {
+	keys map[string]interface{}
+}
+
+// NewJWKSFileKeySource creates a new key source from a JWKS file
+// RSA and EC signing keys are supported, other keys in the file are ignored
+func NewJWKSFileKeySource(path string) (KeySource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read JWKS file: %w", err)
+	}
+	return newJWKSKeySource(data)
+}
+
+// newJWKSKeySource creates a new key source from a JWKS document
+func newJWKSKeySource(data []byte) (KeySource, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("unable to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{})
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		var (
+			key interface{}
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = k.rsaKey()
+		case "EC":
+			key, err = k.ecKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS contains no signing keys")
+	}
+	return &jwksKeySource{keys}, nil
+}
+
+// Key returns the public key identified by the kid header of the token
+// Tokens without a kid are accepted when the document contains a single key
+func (s *jwksKeySource) Key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, nil
+		}
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	return key, nil
+}
+
+// Methods returns the RSA and EC signing methods
+func (s *jwksKeySource) Methods() []string {
+	return []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+}
+
+// rsaKey decodes an RSA public key
+func (k jwk) rsaKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() {
+		return nil, errors.New("invalid RSA exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// ecKey decodes an EC public key
+func (k jwk) ecKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, err
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
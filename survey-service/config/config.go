@@ -3,6 +3,7 @@ package config
 import (
 	"time"
 
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
 	"github.com/joeshaw/envdecode"
 )
 
@@ -11,18 +12,26 @@ type Config struct {
 	HTTP       HTTPConfig
 	Grpc       GrpcConfig
 	Mongo      MongoConfig
+	Rabbit     RabbitConfig
+	Archive    ArchiveConfig
+	Auth       AuthConfig
+	Respondent RespondentConfig
+	RateLimit  RateLimitConfig
 	Repository string `env:"REPOSITORY,default=mongo"`
+	Publisher  string `env:"EVENT_PUBLISHER,default=rabbit"`
 }
 
 // HTTPConfig stores HTTP configuration
 // Code Smell: Duplicated Code
 // Refactoring: Using composition to reduce duplication
 type HTTPConfig struct {
-	Hostname     string        `env:"HTTP_HOSTNAME"`					// Hostname for the HTTP server
-	Port         uint16        `env:"HTTP_PORT,default=8081"`			// Port for the HTTP server
-	ReadTimeout  time.Duration `env:"HTTP_READ_TIMEOUT,default=5s"`		// Read timeout for the HTTP server
-	WriteTimeout time.Duration `env:"HTTP_WRITE_TIMEOUT,default=10s"`	// Write timeout for the HTTP server
-	IdleTimeout  time.Duration `env:"HTTP_IDLE_TIMEOUT,default=2m"`		// Idle timeout for the HTTP server
+	Hostname       string        `env:"HTTP_HOSTNAME"`					// Hostname for the HTTP server
+	Port           uint16        `env:"HTTP_PORT,default=8081"`			// Port for the HTTP server
+	ReadTimeout    time.Duration `env:"HTTP_READ_TIMEOUT,default=5s"`		// Read timeout for the HTTP server
+	WriteTimeout   time.Duration `env:"HTTP_WRITE_TIMEOUT,default=10s"`	// Write timeout for the HTTP server
+	IdleTimeout    time.Duration `env:"HTTP_IDLE_TIMEOUT,default=2m"`		// Idle timeout for the HTTP server
+	ClientIPHeader string        `env:"HTTP_CLIENT_IP_HEADER"`			// Header trusted proxies carry the client address in, such as X-Forwarded-For, the remote address is used when empty
+	TrustedProxies []string      `env:"HTTP_TRUSTED_PROXIES"`			// Addresses or CIDR ranges of the trusted proxies, separated by semicolons
 }
 
 // GrpcConfig stores gRPC configuration
@@ -39,6 +48,61 @@ type MongoConfig struct {
 	Timeout time.Duration `env:"MONGO_TIMEOUT,default=5s"`
 }
 
+// RabbitConfig stores RabbitMQ configuration used to publish survey events
+type RabbitConfig struct {
+	Hostname string `env:"RABBITMQ_HOSTNAME,default=localhost"`
+	Port     uint16 `env:"RABBITMQ_PORT,default=5672"`
+	Username string `env:"RABBITMQ_USER,default=guest"`
+	Password string `env:"RABBITMQ_PASSWORD,default=guest"`
+	Exchange string `env:"RABBITMQ_SURVEY_EXCHANGE,default=surveys"`
+	Buffer   int    `env:"RABBITMQ_EVENT_BUFFER,default=100"`
+}
+
+// ArchiveConfig stores configuration of archived surveys
+type ArchiveConfig struct {
+	Retention     time.Duration `env:"ARCHIVE_RETENTION,default=720h"`     // How long archived surveys are kept before being purged
+	PurgeInterval time.Duration `env:"ARCHIVE_PURGE_INTERVAL,default=1h"` // How often archived surveys are purged
+}
+
+// AuthConfig stores configuration of the JWT bearer authentication
+type AuthConfig struct {
+	KeySource    string        `env:"AUTH_KEY_SOURCE,default=hmac"`     // Source of token verification keys, hmac or jwks
+	Secret       string        `env:"AUTH_HMAC_SECRET"`                 // Shared secret used to verify HMAC signed tokens
+	JWKSFile     string        `env:"AUTH_JWKS_FILE"`                   // Path of a JWKS file with the public keys used to verify tokens
+	Issuer       string        `env:"AUTH_ISSUER"`                      // Required token issuer, not checked when empty
+	Audience     string        `env:"AUTH_AUDIENCE"`                    // Required token audience, not checked when empty
+	Leeway       time.Duration `env:"AUTH_LEEWAY,default=30s"`          // Allowed clock skew when validating token times
+	PublicReads  bool          `env:"AUTH_PUBLIC_READS,default=true"`   // Whether surveys can be read without a token
+	KeyCacheTTL  time.Duration `env:"API_KEY_CACHE_TTL,default=30s"`    // How long API key verifications are cached, revocations take effect after at most this long
+	KeyCacheSize int           `env:"API_KEY_CACHE_SIZE,default=10000"` // Maximum number of cached API key verifications
+}
+
+// Tokens returns the settings used to verify bearer tokens
+func (c AuthConfig) Tokens() auth.Config {
+	return auth.Config{
+		KeySource: c.KeySource,
+		Secret:    c.Secret,
+		JWKSFile:  c.JWKSFile,
+		Issuer:    c.Issuer,
+		Audience:  c.Audience,
+		Leeway:    c.Leeway,
+	}
+}
+
+// RespondentConfig stores configuration of the respondent tokens issued with surveys
+type RespondentConfig struct {
+	Secret string        `env:"RESPONDENT_TOKEN_SECRET"`           // Secret shared with the vote service to sign tokens, no tokens are issued when empty
+	TTL    time.Duration `env:"RESPONDENT_TOKEN_TTL,default=24h"`  // Time a respondent token can be used to vote
+}
+
+// RateLimitConfig stores configuration of the token bucket rate limits of the HTTP API
+// Clients are identified by user, API key or IP address, a zero rate disables the limit
+type RateLimitConfig struct {
+	Store        string  `env:"RATE_LIMIT_STORE,default=memory"`     // Store of the token buckets
+	SurveysRate  float64 `env:"RATE_LIMIT_SURVEYS_RATE,default=10"`  // Requests per second allowed on /surveys per client
+	SurveysBurst int     `env:"RATE_LIMIT_SURVEYS_BURST,default=20"` // Requests a client can burst on /surveys
+}
+
 // GetConfig loads and returns application configuration
 // Code Smell: Long Method
 // Refactoring: Break method into smaller, more manageable functions
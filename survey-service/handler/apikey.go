@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/apikey"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+)
+
+// APIKeyHTTPHandler handles HTTP requests for API keys
+type APIKeyHTTPHandler struct {
+	service    apikey.Service
+	serializer apikey.Serializer
+	log        *zerolog.Logger
+}
+
+// NewAPIKeyHTTPHandler creates a new API key HTTP handler
+func NewAPIKeyHTTPHandler(service apikey.Service, serializer apikey.Serializer, log *zerolog.Logger) *APIKeyHTTPHandler {
+	return &APIKeyHTTPHandler{
+		service,
+		serializer,
+		log,
+	}
+}
+
+// Collection handles get requests to return the API keys of the user
+func (h *APIKeyHTTPHandler) Collection(w http.ResponseWriter, r *http.Request) {
+	h.log.Info().Msg("API key COLLECTION request received")
+
+	keys, err := h.service.Load(currentUser(r))
+	if err != nil {
+		h.handleError(w, "", err)
+		return
+	}
+
+	res, err := h.serializer.EncodeMultiple(keys)
+	if err != nil {
+		h.log.Error().Err(err).Msg("Unable to encode API keys")
+		h.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, res, http.StatusOK)
+}
+
+// Post handles post requests to issue an API key
+// The key itself is only part of this response and cannot be retrieved again
+func (h *APIKeyHTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
+	h.log.Info().Msg("API key POST request received")
+
+	// Read the request body
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.log.Error().Err(err).Msg("Unable to read API key POST body")
+		h.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Decode the request
+	req, err := h.serializer.DecodeIssueRequest(requestBody)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Unable to decode API key POST body")
+		h.Error(w, apikey.ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Issue the key
+	k, err := h.service.Issue(currentUser(r), req)
+	if err != nil {
+		h.handleError(w, "", err)
+		return
+	}
+
+	h.log.Info().Str("id", k.ID).Str("owner", k.OwnerID).Strs("scopes", k.Scopes).Msg("API key issued")
+
+	res, err := h.serializer.EncodeIssued(k)
+	if err != nil {
+		h.log.Error().Str("id", k.ID).Err(err).Msg("Unable to encode API key")
+		h.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, res, http.StatusCreated)
+}
+
+// Delete handles delete requests to revoke an API key
+func (h *APIKeyHTTPHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("API key DELETE request received")
+
+	if err := h.service.Revoke(currentUser(r), id); err != nil {
+		h.handleError(w, id, err)
+		return
+	}
+
+	h.log.Info().Str("id", id).Msg("API key revoked")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleError handles errors of API key requests
+func (h *APIKeyHTTPHandler) handleError(w http.ResponseWriter, id string, err error) {
+	if errors.Is(err, apikey.ErrInvalidRequest) {
+		h.log.Debug().Err(err).Msg("Invalid API key request")
+		h.Error(w, err.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, apikey.ErrNotFound) {
+		h.log.Debug().Str("id", id).Msg("API key not found")
+		h.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	} else if errors.Is(err, apikey.ErrForbidden) {
+		h.log.Debug().Str("id", id).Msg("API key access forbidden")
+		h.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	} else {
+		h.log.Error().Err(err).Str("id", id).Msg("Unable to manage API key")
+		h.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// Response sends an HTTP response
+func (h *APIKeyHTTPHandler) Response(w http.ResponseWriter, output []byte, code int) {
+	w.Header().Set("Content-Type", h.serializer.GetContentType())
+	w.WriteHeader(code)
+	w.Write(output)
+}
+
+// Error sends an HTTP error response
+func (h *APIKeyHTTPHandler) Error(w http.ResponseWriter, message string, code int) {
+	output, err := h.serializer.EncodeErrorResponse(apikey.ErrorResponse{Error: message})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, output, code)
+}
@@ -4,57 +4,299 @@ import (
 	"context"
 	"errors"
 
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/apikey"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/invitation"
 	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
 	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // SurveyGrpcHandler handles gRPC requests for surveys
 type SurveyGrpcHandler struct {
-	service survey.Service
-	log     *zerolog.Logger
+	service     survey.Service
+	keys        apikey.Service
+	invitations invitation.Service
+	log         *zerolog.Logger
 }
 
 // NewSurveyGrpcHandler creates a new survey gRPC handler
-func NewSurveyGrpcHandler(service survey.Service, log *zerolog.Logger) *SurveyGrpcHandler {
-	return &SurveyGrpcHandler{service, log}
+func NewSurveyGrpcHandler(service survey.Service, keys apikey.Service, invitations invitation.Service, log *zerolog.Logger) *SurveyGrpcHandler {
+	return &SurveyGrpcHandler{service, keys, invitations, log}
 }
 
 // GetSurvey loads and returns a requested survey
+// Other services are trusted, so the survey is loaded as the system user
 func (g *SurveyGrpcHandler) GetSurvey(ctx context.Context, r *protos.SurveyRequest) (*protos.SurveyResponse, error) {
 	id := r.GetId()
 	g.log.Info().Str("id", id).Msg("GetSurvey request received")
 
 	// Load survey by ID
-	s, err := g.service.LoadByID(id)
+	s, err := g.service.LoadByID(survey.SystemUser(), id)
 	if err != nil {
 		return handleSurveyError(g.log, id, err)
 	}
 
-	// Build survey response
+	// Archived surveys no longer accept votes
+	if s.IsArchived() {
+		return handleSurveyError(g.log, id, survey.ErrNotFound)
+	}
+
+	return toSurveyResponse(s), nil
+}
+
+// GetActiveSurveys loads and returns all active surveys
+func (g *SurveyGrpcHandler) GetActiveSurveys(ctx context.Context, r *protos.ActiveSurveysRequest) (*protos.SurveysResponse, error) {
+	g.log.Info().Msg("GetActiveSurveys request received")
+
+	surveys, err := g.service.LoadActive()
+	if err != nil {
+		g.log.Error().Err(err).Msg("Unable to load active surveys")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toSurveysResponse(surveys), nil
+}
+
+// GetSurveys loads and returns all surveys that are not archived
+// Other services are trusted, so surveys are loaded as the system user
+func (g *SurveyGrpcHandler) GetSurveys(ctx context.Context, r *protos.SurveysRequest) (*protos.SurveysResponse, error) {
+	g.log.Info().Msg("GetSurveys request received")
+
+	surveys, err := g.service.Load(survey.SystemUser())
+	if err != nil {
+		g.log.Error().Err(err).Msg("Unable to load surveys")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return toSurveysResponse(surveys), nil
+}
+
+// ValidateSurvey checks that a survey accepts votes and has the requested question
+func (g *SurveyGrpcHandler) ValidateSurvey(ctx context.Context, r *protos.SurveyValidationRequest) (*protos.SurveyValidationResponse, error) {
+	id := r.GetSurveyId()
+	g.log.Info().Str("id", id).Int32("question", r.GetQuestionId()).Msg("ValidateSurvey request received")
+
+	s, err := g.service.LoadByID(survey.SystemUser(), id)
+	if errors.Is(err, survey.ErrNotFound) {
+		return &protos.SurveyValidationResponse{Message: err.Error()}, nil
+	}
+	if err != nil {
+		g.log.Error().Err(err).Str("id", id).Msg("Unable to load survey")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	switch s.Status {
+	case survey.SurveyStatusArchived:
+		return &protos.SurveyValidationResponse{Message: survey.ErrNotFound.Error()}, nil
+	case survey.SurveyStatusExpired:
+		return &protos.SurveyValidationResponse{Message: survey.ErrSurveyExpired.Error()}, nil
+	case survey.SurveyStatusInactive:
+		return &protos.SurveyValidationResponse{Message: survey.ErrSurveyInactive.Error()}, nil
+	}
+
+	for _, q := range s.Questions {
+		if int32(q.ID) == r.GetQuestionId() {
+			return &protos.SurveyValidationResponse{Valid: true, QuestionType: string(q.Type)}, nil
+		}
+	}
+	return &protos.SurveyValidationResponse{Message: "question not found"}, nil
+}
+
+// VerifyAPIKey verifies an API key for other services
+// Unknown and revoked keys are reported as unauthenticated
+func (g *SurveyGrpcHandler) VerifyAPIKey(ctx context.Context, r *protos.APIKeyRequest) (*protos.APIKeyResponse, error) {
+	k, err := g.keys.Verify(r.GetKey())
+	if errors.Is(err, apikey.ErrInvalidKey) {
+		g.log.Debug().Msg("Invalid API key verification requested")
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		g.log.Error().Err(err).Msg("Unable to verify API key")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &protos.APIKeyResponse{
+		Id:      k.ID,
+		OwnerId: k.OwnerID,
+		Roles:   k.Roles,
+		Scopes:  k.Scopes,
+	}, nil
+}
+
+// UseInvitation consumes an invitation token for the vote service
+// Unknown tokens are reported as permission denied and questions already answered as already existing
+func (g *SurveyGrpcHandler) UseInvitation(ctx context.Context, r *protos.InvitationRequest) (*protos.InvitationResponse, error) {
+	id := r.GetSurveyId()
+	err := g.invitations.Use(id, r.GetToken(), int(r.GetQuestionId()))
+	if errors.Is(err, invitation.ErrInvalidToken) {
+		g.log.Debug().Str("id", id).Msg("Invalid invitation token used")
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, invitation.ErrAlreadyUsed) {
+		g.log.Debug().Str("id", id).Int32("question", r.GetQuestionId()).Msg("Invitation already used for question")
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		g.log.Error().Err(err).Str("id", id).Msg("Unable to use invitation")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &protos.InvitationResponse{}, nil
+}
+
+// ReleaseInvitation releases an invitation token for the vote service when a vote could not be stored
+// Unknown tokens are reported as permission denied
+func (g *SurveyGrpcHandler) ReleaseInvitation(ctx context.Context, r *protos.InvitationRequest) (*protos.InvitationResponse, error) {
+	id := r.GetSurveyId()
+	err := g.invitations.Release(id, r.GetToken(), int(r.GetQuestionId()))
+	if errors.Is(err, invitation.ErrInvalidToken) {
+		g.log.Debug().Str("id", id).Msg("Invalid invitation token released")
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		g.log.Error().Err(err).Str("id", id).Msg("Unable to release invitation")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &protos.InvitationResponse{}, nil
+}
+
+// toSurveysResponse converts surveys to a gRPC surveys response
+func toSurveysResponse(surveys []survey.SurveyWithStatus) *protos.SurveysResponse {
+	res := &protos.SurveysResponse{}
+	for i := range surveys {
+		res.Surveys = append(res.Surveys, toSurveyResponse(&surveys[i]))
+	}
+	return res
+}
+
+// toSurveyResponse converts a survey to a gRPC survey response
+func toSurveyResponse(s *survey.SurveyWithStatus) *protos.SurveyResponse {
 	res := &protos.SurveyResponse{
-		Id:        s.ID,
-		Name:      s.Name,
-		CreatedAt: s.CreatedAt,
+		Id:              s.ID,
+		Name:            s.Name,
+		Description:     s.Description,
+		CreatedAt:       s.CreatedAt,
+		ExpiresAt:       s.ExpiresAt,
+		Active:          s.Active,
+		Status:          string(s.Status),
+		AllowAnonymous:  s.AllowAnonymous,
+		AllowEdits:      s.AllowEdits,
+		ThankYouMessage: s.ThankYouMessage,
+		OwnerId:         s.OwnerID,
+		SharedWith:      s.SharedWith,
+		InviteOnly:      s.InviteOnly,
 	}
 
 	// Add questions to the response
 	for _, q := range s.Questions {
-		res.Questions = append(res.Questions, &protos.QuestionResponse{
-			Id:   int32(q.ID),
-			Text: q.Text,
-		})
+		res.Questions = append(res.Questions, toQuestionResponse(q))
+	}
+
+	return res
+}
+
+// toQuestionResponse converts a question to a gRPC question response
+func toQuestionResponse(q survey.Question) *protos.QuestionResponse {
+	res := &protos.QuestionResponse{
+		Id:          int32(q.ID),
+		Text:        q.Text,
+		Type:        string(q.Type),
+		Required:    q.Required,
+		Placeholder: q.Placeholder,
+		HelpText:    q.HelpText,
+		Tags:        q.Tags,
 	}
 
-	return res, nil
+	res.Options = toOptionResponses(q.Options)
+	res.Rows = toOptionResponses(q.Rows)
+	res.Columns = toOptionResponses(q.Columns)
+	if q.Media != nil {
+		res.Media = &protos.MediaResponse{
+			Type:    string(q.Media.Type),
+			Url:     q.Media.URL,
+			Caption: q.Media.Caption,
+		}
+	}
+	if q.MinValue != nil {
+		v := int32(*q.MinValue)
+		res.MinValue = &v
+	}
+	if q.MaxValue != nil {
+		v := int32(*q.MaxValue)
+		res.MaxValue = &v
+	}
+	if q.MinSelections != nil {
+		v := int32(*q.MinSelections)
+		res.MinSelections = &v
+	}
+	if q.MaxSelections != nil {
+		v := int32(*q.MaxSelections)
+		res.MaxSelections = &v
+	}
+	if q.DateConstraints != nil {
+		res.DateConstraints = &protos.DateConstraintsResponse{
+			Mode:    string(q.DateConstraints.Mode),
+			MinDate: q.DateConstraints.MinDate,
+			MaxDate: q.DateConstraints.MaxDate,
+		}
+	}
+	if q.FileConstraints != nil {
+		res.FileConstraints = &protos.FileConstraintsResponse{
+			AllowedTypes: q.FileConstraints.AllowedTypes,
+			MaxSize:      q.FileConstraints.MaxSize,
+		}
+	}
+	if q.TextConstraints != nil {
+		res.TextConstraints = &protos.TextConstraintsResponse{
+			Pattern: q.TextConstraints.Pattern,
+			Format:  string(q.TextConstraints.Format),
+		}
+		if q.TextConstraints.MinLength != nil {
+			v := int32(*q.TextConstraints.MinLength)
+			res.TextConstraints.MinLength = &v
+		}
+		if q.TextConstraints.MaxLength != nil {
+			v := int32(*q.TextConstraints.MaxLength)
+			res.TextConstraints.MaxLength = &v
+		}
+	}
+	if q.ConditionalLogic != nil {
+		res.ConditionalLogic = &protos.ConditionalLogicResponse{
+			Type:             string(q.ConditionalLogic.Type),
+			SourceQuestionId: int32(q.ConditionalLogic.SourceQuestionID),
+			SourceOptionId:   int32(q.ConditionalLogic.SourceOptionID),
+			SourceValue:      q.ConditionalLogic.SourceValue,
+			Operator:         q.ConditionalLogic.Operator,
+		}
+	}
+
+	return res
+}
+
+// toOptionResponses converts options to gRPC option responses
+func toOptionResponses(options []survey.Option) []*protos.OptionResponse {
+	var res []*protos.OptionResponse
+	for _, o := range options {
+		res = append(res, &protos.OptionResponse{
+			Id:    int32(o.ID),
+			Text:  o.Text,
+			Image: o.Image,
+			Other: o.Other,
+		})
+	}
+	return res
 }
 
 // handleSurveyError handles errors during survey loading
+// Errors are converted to gRPC status errors so clients can tell a missing survey from a failure
 func handleSurveyError(log *zerolog.Logger, id string, err error) (*protos.SurveyResponse, error) {
 	if errors.Is(err, survey.ErrNotFound) {
 		log.Debug().Str("id", id).Msg("Survey not found")
-	} else {
-		log.Error().Err(err).Str("id", id).Msg("Unable to load survey")
+		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	return nil, err
+	log.Error().Err(err).Str("id", id).Msg("Unable to load survey")
+	return nil, status.Error(codes.Internal, err.Error())
 }
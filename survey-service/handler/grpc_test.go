@@ -10,6 +10,9 @@ import (
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestGetSurvey(t *testing.T) {
@@ -17,19 +20,19 @@ func TestGetSurvey(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockService := new(MockSurveyService)
-		handler := NewSurveyGrpcHandler(mockService, &log)
+		handler := NewSurveyGrpcHandler(mockService, nil, nil, &log)
 
 		surveyID := "123"
-		expectedSurvey := &survey.Survey{
+		expectedSurvey := &survey.SurveyWithStatus{Survey: &survey.Survey{
 			ID:        surveyID,
 			Name:      "Test Survey",
 			CreatedAt: time.Now().Unix(), // CreatedAt має бути int64
 			Questions: []survey.Question{
 				{ID: 1, Text: "Question 1"},
 			},
-		}
+		}}
 
-		mockService.On("LoadByID", surveyID).Return(expectedSurvey, nil)
+		mockService.On("LoadByID", mock.Anything, surveyID).Return(expectedSurvey, nil)
 
 		req := &protos.SurveyRequest{Id: surveyID}
 		res, err := handler.GetSurvey(context.Background(), req)
@@ -47,34 +50,35 @@ func TestGetSurvey(t *testing.T) {
 
 	t.Run("not found", func(t *testing.T) {
 		mockService := new(MockSurveyService)
-		handler := NewSurveyGrpcHandler(mockService, &log)
+		handler := NewSurveyGrpcHandler(mockService, nil, nil, &log)
 
 		surveyID := "123"
-		mockService.On("LoadByID", surveyID).Return(nil, survey.ErrNotFound)
+		mockService.On("LoadByID", mock.Anything, surveyID).Return(nil, survey.ErrNotFound)
 
 		req := &protos.SurveyRequest{Id: surveyID}
 		res, err := handler.GetSurvey(context.Background(), req)
 
 		assert.Error(t, err)
 		assert.Nil(t, res)
-		assert.True(t, errors.Is(err, survey.ErrNotFound))
+		assert.Equal(t, codes.NotFound, status.Code(err))
 
 		mockService.AssertExpectations(t)
 	})
 
 	t.Run("internal error", func(t *testing.T) {
 		mockService := new(MockSurveyService)
-		handler := NewSurveyGrpcHandler(mockService, &log)
+		handler := NewSurveyGrpcHandler(mockService, nil, nil, &log)
 
 		surveyID := "123"
-		mockService.On("LoadByID", surveyID).Return(nil, errors.New("internal error"))
+		mockService.On("LoadByID", mock.Anything, surveyID).Return(nil, errors.New("internal error"))
 
 		req := &protos.SurveyRequest{Id: surveyID}
 		res, err := handler.GetSurvey(context.Background(), req)
 
 		assert.Error(t, err)
 		assert.Nil(t, res)
-		assert.Equal(t, "internal error", err.Error())
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, "internal error", status.Convert(err).Message())
 
 		mockService.AssertExpectations(t)
 	})
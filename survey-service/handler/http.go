@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/invitation"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/middleware"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
 	"github.com/go-chi/chi"
@@ -14,14 +16,17 @@ import (
 
 // SurveyHTTPHandler handles HTTP requests for surveys
 type SurveyHTTPHandler struct {
-	service survey.Service
-	log     *zerolog.Logger
+	service     survey.Service
+	invitations invitation.Service
+	log         *zerolog.Logger
 }
 
 // NewSurveyHTTPHandler creates a new survey HTTP handler
-func NewSurveyHTTPHandler(service survey.Service, log *zerolog.Logger) *SurveyHTTPHandler {
+// Invitation tokens are verified with the invitation service, so invitees can read invite-only surveys
+func NewSurveyHTTPHandler(service survey.Service, invitations invitation.Service, log *zerolog.Logger) *SurveyHTTPHandler {
 	return &SurveyHTTPHandler{
 		service,
+		invitations,
 		log,
 	}
 }
@@ -31,8 +36,13 @@ func (h *SurveyHTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	h.log.Info().Str("id", id).Msg("GET request received")
 
-	// Load the requested survey
-	surveyWithStatus, err := h.service.LoadByID(id)
+	// Load the requested survey for the user, or the invitee presenting an invitation token
+	user, err := h.reader(r, id)
+	if err != nil {
+		handleGetError(h, w, r, id, err)
+		return
+	}
+	surveyWithStatus, err := h.service.LoadByID(user, id)
 	if err != nil {
 		handleGetError(h, w, r, id, err)
 		return
@@ -57,6 +67,9 @@ func handleGetError(h *SurveyHTTPHandler, w http.ResponseWriter, r *http.Request
 	if errors.Is(err, survey.ErrNotFound) {
 		h.log.Debug().Str("id", id).Msg("Survey not found")
 		h.Error(w, r, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	} else if errors.Is(err, survey.ErrForbidden) || errors.Is(err, invitation.ErrInvalidToken) {
+		h.log.Debug().Str("id", id).Msg("Reading survey forbidden")
+		h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
 	} else {
 		h.log.Error().Err(err).Str("id", id).Msg("Unable to load survey")
 		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -68,7 +81,12 @@ func (h *SurveyHTTPHandler) Collection(w http.ResponseWriter, r *http.Request) {
 	h.log.Info().Msg("COLLECTION request received")
 
 	// Load all surveys
-	surveysWithStatus, err := h.service.Load()
+	surveysWithStatus, err := h.service.Load(currentUser(r))
+	if errors.Is(err, survey.ErrForbidden) {
+		h.log.Debug().Msg("Listing surveys forbidden")
+		h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		h.log.Error().Err(err).Msg("Unable to load surveys")
 		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -111,6 +129,35 @@ func (h *SurveyHTTPHandler) ActiveCollection(w http.ResponseWriter, r *http.Requ
 	h.Response(w, r, json, http.StatusOK)
 }
 
+// ArchivedCollection handles get requests to return archived surveys
+func (h *SurveyHTTPHandler) ArchivedCollection(w http.ResponseWriter, r *http.Request) {
+	h.log.Info().Msg("ARCHIVED COLLECTION request received")
+
+	// Load archived surveys
+	archivedSurveys, err := h.service.LoadArchived(currentUser(r))
+	if errors.Is(err, survey.ErrForbidden) {
+		h.log.Debug().Msg("Listing archived surveys forbidden")
+		h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		h.log.Error().Err(err).Msg("Unable to load archived surveys")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Encode the surveys to be returned
+	serializer := h.GetSerializer(r)
+	json, err := serializer.EncodeMultiple(archivedSurveys)
+	if err != nil {
+		h.log.Error().Err(err).Msg("Unable to encode archived surveys")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, r, json, http.StatusOK)
+}
+
 // Post handles post requests to create a survey
 func (h *SurveyHTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
 	h.log.Info().Msg("POST request received")
@@ -133,7 +180,7 @@ func (h *SurveyHTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Save the survey
-	err = h.service.Insert(s)
+	err = h.service.Insert(currentUser(r), s)
 	if err != nil {
 		handlePostError(h, w, r, err)
 		return
@@ -142,7 +189,8 @@ func (h *SurveyHTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
 	h.log.Info().Str("id", s.ID).Msg("Survey created")
 
 	// Get survey with status
-	surveyWithStatus, err := h.service.LoadByID(s.ID)
+	// The user just stored the survey, so it is loaded as the system user
+	surveyWithStatus, err := h.service.LoadByID(survey.SystemUser(), s.ID)
 	if err != nil {
 		h.log.Error().Str("id", s.ID).Err(err).Msg("Unable to load created survey")
 		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -183,7 +231,7 @@ func (h *SurveyHTTPHandler) Put(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Update the survey
-	err = h.service.Update(id, s)
+	err = h.service.Update(currentUser(r), id, s)
 	if err != nil {
 		handleUpdateError(h, w, r, id, err)
 		return
@@ -192,7 +240,8 @@ func (h *SurveyHTTPHandler) Put(w http.ResponseWriter, r *http.Request) {
 	h.log.Info().Str("id", id).Msg("Survey updated")
 
 	// Get updated survey with status
-	updatedSurvey, err := h.service.LoadByID(id)
+	// The user was allowed to update the survey, so it is loaded as the system user, even when its owner changed
+	updatedSurvey, err := h.service.LoadByID(survey.SystemUser(), id)
 	if err != nil {
 		h.log.Error().Str("id", id).Err(err).Msg("Unable to load updated survey")
 		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -216,11 +265,14 @@ func (h *SurveyHTTPHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	h.log.Info().Str("id", id).Msg("DELETE request received")
 
 	// Delete the survey
-	err := h.service.DeleteSurvey(id)
+	err := h.service.DeleteSurvey(currentUser(r), id)
 	if err != nil {
 		if errors.Is(err, survey.ErrNotFound) {
 			h.log.Debug().Str("id", id).Msg("Survey not found for deletion")
 			h.Error(w, r, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		} else if errors.Is(err, survey.ErrForbidden) {
+			h.log.Debug().Str("id", id).Msg("Survey deletion forbidden")
+			h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
 		} else {
 			h.log.Error().Err(err).Str("id", id).Msg("Unable to delete survey")
 			h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -238,11 +290,17 @@ func (h *SurveyHTTPHandler) Activate(w http.ResponseWriter, r *http.Request) {
 	h.log.Info().Str("id", id).Msg("ACTIVATE request received")
 
 	// Activate the survey
-	err := h.service.ActivateSurvey(id)
+	err := h.service.ActivateSurvey(currentUser(r), id)
 	if err != nil {
 		if errors.Is(err, survey.ErrNotFound) {
 			h.log.Debug().Str("id", id).Msg("Survey not found for activation")
 			h.Error(w, r, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		} else if errors.Is(err, survey.ErrForbidden) {
+			h.log.Debug().Str("id", id).Msg("Survey activation forbidden")
+			h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		} else if errors.Is(err, survey.ErrSurveyArchived) {
+			h.log.Debug().Str("id", id).Msg("Archived survey cannot be changed")
+			h.Error(w, r, err.Error(), http.StatusConflict)
 		} else {
 			h.log.Error().Err(err).Str("id", id).Msg("Unable to activate survey")
 			h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -260,11 +318,17 @@ func (h *SurveyHTTPHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
 	h.log.Info().Str("id", id).Msg("DEACTIVATE request received")
 
 	// Deactivate the survey
-	err := h.service.DeactivateSurvey(id)
+	err := h.service.DeactivateSurvey(currentUser(r), id)
 	if err != nil {
 		if errors.Is(err, survey.ErrNotFound) {
 			h.log.Debug().Str("id", id).Msg("Survey not found for deactivation")
 			h.Error(w, r, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		} else if errors.Is(err, survey.ErrForbidden) {
+			h.log.Debug().Str("id", id).Msg("Survey deactivation forbidden")
+			h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		} else if errors.Is(err, survey.ErrSurveyArchived) {
+			h.log.Debug().Str("id", id).Msg("Archived survey cannot be changed")
+			h.Error(w, r, err.Error(), http.StatusConflict)
 		} else {
 			h.log.Error().Err(err).Str("id", id).Msg("Unable to deactivate survey")
 			h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
@@ -276,6 +340,62 @@ func (h *SurveyHTTPHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
 	h.Response(w, r, []byte(`{"message":"Survey deactivated successfully"}`), http.StatusOK)
 }
 
+// Archive handles requests to archive a survey
+func (h *SurveyHTTPHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("ARCHIVE request received")
+
+	// Archive the survey
+	err := h.service.ArchiveSurvey(currentUser(r), id)
+	if err != nil {
+		if errors.Is(err, survey.ErrNotFound) {
+			h.log.Debug().Str("id", id).Msg("Survey not found for archiving")
+			h.Error(w, r, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		} else if errors.Is(err, survey.ErrForbidden) {
+			h.log.Debug().Str("id", id).Msg("Survey archiving forbidden")
+			h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		} else if errors.Is(err, survey.ErrSurveyArchived) {
+			h.log.Debug().Str("id", id).Msg("Survey already archived")
+			h.Error(w, r, err.Error(), http.StatusConflict)
+		} else {
+			h.log.Error().Err(err).Str("id", id).Msg("Unable to archive survey")
+			h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.log.Info().Str("id", id).Msg("Survey archived")
+	h.Response(w, r, []byte(`{"message":"Survey archived successfully"}`), http.StatusOK)
+}
+
+// Restore handles requests to restore an archived survey
+func (h *SurveyHTTPHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("RESTORE request received")
+
+	// Restore the survey
+	err := h.service.RestoreSurvey(currentUser(r), id)
+	if err != nil {
+		if errors.Is(err, survey.ErrNotFound) {
+			h.log.Debug().Str("id", id).Msg("Survey not found for restoring")
+			h.Error(w, r, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		} else if errors.Is(err, survey.ErrForbidden) {
+			h.log.Debug().Str("id", id).Msg("Survey restoring forbidden")
+			h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		} else if errors.Is(err, survey.ErrSurveyNotArchived) {
+			h.log.Debug().Str("id", id).Msg("Survey is not archived")
+			h.Error(w, r, err.Error(), http.StatusConflict)
+		} else {
+			h.log.Error().Err(err).Str("id", id).Msg("Unable to restore survey")
+			h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.log.Info().Str("id", id).Msg("Survey restored")
+	h.Response(w, r, []byte(`{"message":"Survey restored successfully"}`), http.StatusOK)
+}
+
 // SetExpiration handles requests to set an expiration date for a survey
 func (h *SurveyHTTPHandler) SetExpiration(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -297,11 +417,17 @@ func (h *SurveyHTTPHandler) SetExpiration(w http.ResponseWriter, r *http.Request
 	}
 
 	// Set the expiration date
-	err = h.service.SetExpirationDate(id, expiresAt)
+	err = h.service.SetExpirationDate(currentUser(r), id, expiresAt)
 	if err != nil {
 		if errors.Is(err, survey.ErrNotFound) {
 			h.log.Debug().Str("id", id).Msg("Survey not found for setting expiration")
 			h.Error(w, r, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		} else if errors.Is(err, survey.ErrForbidden) {
+			h.log.Debug().Str("id", id).Msg("Survey setting expiration forbidden")
+			h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		} else if errors.Is(err, survey.ErrSurveyArchived) {
+			h.log.Debug().Str("id", id).Msg("Archived survey cannot be changed")
+			h.Error(w, r, err.Error(), http.StatusConflict)
 		} else if errors.Is(err, survey.ErrInvalidRequest) {
 			h.log.Debug().Str("id", id).Int64("timestamp", expiresAt).Msg("Invalid expiration timestamp")
 			h.Error(w, r, err.Error(), http.StatusBadRequest)
@@ -320,7 +446,10 @@ func (h *SurveyHTTPHandler) SetExpiration(w http.ResponseWriter, r *http.Request
 // Code Smell: Long Method
 // Refactoring: Extract Method to handle error responses for Post
 func handlePostError(h *SurveyHTTPHandler, w http.ResponseWriter, r *http.Request, err error) {
-	if errors.Is(err, survey.ErrInvalidRequest) {
+	if errors.Is(err, survey.ErrForbidden) {
+		h.log.Debug().Msg("Survey creation forbidden")
+		h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	} else if errors.Is(err, survey.ErrInvalidRequest) {
 		h.log.Debug().Err(err).Msg("Invalid survey data in POST")
 		h.Error(w, r, err.Error(), http.StatusUnprocessableEntity)
 	} else if errors.Is(err, survey.ErrQuestionConditionalLogic) {
@@ -337,6 +466,12 @@ func handleUpdateError(h *SurveyHTTPHandler, w http.ResponseWriter, r *http.Requ
 	if errors.Is(err, survey.ErrNotFound) {
 		h.log.Debug().Str("id", id).Msg("Survey not found for update")
 		h.Error(w, r, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	} else if errors.Is(err, survey.ErrForbidden) {
+		h.log.Debug().Str("id", id).Msg("Survey update forbidden")
+		h.Error(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	} else if errors.Is(err, survey.ErrSurveyArchived) {
+		h.log.Debug().Str("id", id).Msg("Archived survey cannot be updated")
+		h.Error(w, r, err.Error(), http.StatusConflict)
 	} else if errors.Is(err, survey.ErrInvalidRequest) {
 		h.log.Debug().Err(err).Str("id", id).Msg("Invalid survey data in PUT")
 		h.Error(w, r, err.Error(), http.StatusUnprocessableEntity)
@@ -349,6 +484,40 @@ func handleUpdateError(h *SurveyHTTPHandler, w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// currentUser returns the survey user of the authenticated principal of a request
+// This function returns nil for anonymous requests
+// reader returns the user reading a survey, marked as invited to it when the request carries a valid invitation token
+// Requests with an invalid token fail with invitation.ErrInvalidToken
+func (h *SurveyHTTPHandler) reader(r *http.Request, surveyID string) (*survey.User, error) {
+	user := currentUser(r)
+	token := r.Header.Get(middleware.InvitationTokenHeader)
+	if token == "" {
+		return user, nil
+	}
+
+	if err := h.invitations.Verify(surveyID, token); err != nil {
+		return nil, err
+	}
+	if user == nil {
+		user = &survey.User{}
+	}
+	user.InvitedTo = surveyID
+	return user, nil
+}
+
+func currentUser(r *http.Request) *survey.User {
+	p, ok := auth.FromContext(r.Context())
+	if !ok {
+		return nil
+	}
+
+	u := &survey.User{ID: p.Subject}
+	for _, role := range p.Roles {
+		u.Roles = append(u.Roles, survey.Role(role))
+	}
+	return u
+}
+
 // GetSerializer gets a serializer from the request, which is added via middleware
 func (h *SurveyHTTPHandler) GetSerializer(r *http.Request) survey.Serializer {
 	return r.Context().Value(middleware.SerializerKey).(survey.Serializer)
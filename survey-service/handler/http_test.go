@@ -20,20 +20,20 @@ import (
 func TestSurveyHTTPHandler_Get(t *testing.T) {
 	log := zerolog.New(nil)
 	mockService := new(MockSurveyService)
-	handler := NewSurveyHTTPHandler(mockService, &log)
+	handler := NewSurveyHTTPHandler(mockService, nil, &log)
 
 	t.Run("success", func(t *testing.T) {
 		surveyID := "123"
-		expectedSurvey := &survey.Survey{
+		expectedSurvey := &survey.SurveyWithStatus{Survey: &survey.Survey{
 			ID:        surveyID,
 			Name:      "Test Survey",
 			CreatedAt: time.Now().Unix(),
 			Questions: []survey.Question{
 				{ID: 1, Text: "Question 1"},
 			},
-		}
+		}}
 
-		mockService.On("LoadByID", surveyID).Return(expectedSurvey, nil)
+		mockService.On("LoadByID", mock.Anything, surveyID).Return(expectedSurvey, nil)
 
 		mockSerializer := new(MockSerializer)
 		expectedJSON := []byte(`{"id":"123","name":"Test Survey","created_at":1234567890,"questions":[{"id":1,"text":"Question 1"}]}`)
@@ -58,12 +58,32 @@ func TestSurveyHTTPHandler_Get(t *testing.T) {
 		mockService.AssertExpectations(t)
 		mockSerializer.AssertExpectations(t)
 	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		mockService.On("LoadByID", mock.Anything, "456").Return(nil, survey.ErrForbidden)
+
+		mockSerializer := new(MockSerializer)
+		mockSerializer.On("EncodeErrorResponse", mock.Anything).Return([]byte(`{"error":"Forbidden"}`), nil)
+
+		req := httptest.NewRequest("GET", "/surveys/456", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.SerializerKey, mockSerializer))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "456")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		w := httptest.NewRecorder()
+
+		handler.Get(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockService.AssertExpectations(t)
+	})
 }
 
 func TestSurveyHTTPHandler_Post(t *testing.T) {
 	log := zerolog.New(nil)
 	mockService := new(MockSurveyService)
-	handler := NewSurveyHTTPHandler(mockService, &log)
+	handler := NewSurveyHTTPHandler(mockService, nil, &log)
 
 	t.Run("success", func(t *testing.T) {
 		newSurvey := &survey.Survey{
@@ -78,9 +98,11 @@ func TestSurveyHTTPHandler_Post(t *testing.T) {
 		requestBody := []byte(`{"id":"123","name":"New Survey","created_at":1234567890,"questions":[{"id":1,"text":"Question 1"}]}`)
 		mockSerializer := new(MockSerializer)
 		mockSerializer.On("Decode", mock.Anything).Return(newSurvey, nil)
-		mockSerializer.On("Encode", newSurvey).Return(requestBody, nil)
+		createdSurvey := &survey.SurveyWithStatus{Survey: newSurvey, Status: survey.SurveyStatusActive}
+		mockSerializer.On("Encode", createdSurvey).Return(requestBody, nil)
 
-		mockService.On("Insert", newSurvey).Return(nil)
+		mockService.On("Insert", mock.Anything, newSurvey).Return(nil)
+		mockService.On("LoadByID", mock.Anything, "123").Return(createdSurvey, nil)
 
 		req := httptest.NewRequest("POST", "/surveys", bytes.NewReader(requestBody))
 		ctx := context.WithValue(req.Context(), middleware.SerializerKey, mockSerializer)
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/invitation"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+)
+
+// InvitationHTTPHandler handles HTTP requests for survey invitations
+type InvitationHTTPHandler struct {
+	service    invitation.Service
+	serializer invitation.Serializer
+	log        *zerolog.Logger
+}
+
+// NewInvitationHTTPHandler creates a new invitation HTTP handler
+func NewInvitationHTTPHandler(service invitation.Service, serializer invitation.Serializer, log *zerolog.Logger) *InvitationHTTPHandler {
+	return &InvitationHTTPHandler{
+		service,
+		serializer,
+		log,
+	}
+}
+
+// Post handles post requests to invite recipients to a survey
+// Recipients are read from a JSON request, or from CSV data when sent as text/csv
+func (h *InvitationHTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("Invitations POST request received")
+
+	// Read the request body
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.log.Error().Err(err).Msg("Unable to read invitations POST body")
+		h.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// Decode the recipients
+	var recipients []invitation.Recipient
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+		recipients, err = invitation.ParseCSV(bytes.NewReader(requestBody))
+	} else {
+		var req *invitation.CreateRequest
+		if req, err = h.serializer.DecodeCreateRequest(requestBody); err == nil {
+			recipients = req.Recipients
+		}
+	}
+	if err != nil {
+		h.log.Debug().Err(err).Str("id", id).Msg("Unable to decode invitations POST body")
+		h.Error(w, invitation.ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Create the invitations
+	issued, err := h.service.Create(currentUser(r), id, recipients)
+	if err != nil {
+		h.handleError(w, id, err)
+		return
+	}
+
+	h.log.Info().Str("id", id).Int("count", len(issued)).Msg("Invitations created")
+
+	res, err := h.serializer.EncodeIssued(issued)
+	if err != nil {
+		h.log.Error().Str("id", id).Err(err).Msg("Unable to encode invitations")
+		h.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, res, http.StatusCreated)
+}
+
+// Report handles get requests to report which invitations of a survey have been responded to
+func (h *InvitationHTTPHandler) Report(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("Invitations GET request received")
+
+	report, err := h.service.Report(currentUser(r), id)
+	if err != nil {
+		h.handleError(w, id, err)
+		return
+	}
+
+	res, err := h.serializer.EncodeReport(report)
+	if err != nil {
+		h.log.Error().Str("id", id).Err(err).Msg("Unable to encode invitation report")
+		h.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, res, http.StatusOK)
+}
+
+// handleError handles errors of invitation requests
+func (h *InvitationHTTPHandler) handleError(w http.ResponseWriter, id string, err error) {
+	if errors.Is(err, invitation.ErrInvalidRequest) {
+		h.log.Debug().Err(err).Str("id", id).Msg("Invalid invitation request")
+		h.Error(w, err.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, survey.ErrNotFound) {
+		h.log.Debug().Str("id", id).Msg("Survey not found")
+		h.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	} else if errors.Is(err, invitation.ErrForbidden) {
+		h.log.Debug().Str("id", id).Msg("Invitations access forbidden")
+		h.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	} else if errors.Is(err, survey.ErrSurveyArchived) {
+		h.log.Debug().Str("id", id).Msg("Invitations for archived survey")
+		h.Error(w, err.Error(), http.StatusConflict)
+	} else {
+		h.log.Error().Err(err).Str("id", id).Msg("Unable to manage invitations")
+		h.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// Response sends an HTTP response
+func (h *InvitationHTTPHandler) Response(w http.ResponseWriter, output []byte, code int) {
+	w.Header().Set("Content-Type", h.serializer.GetContentType())
+	w.WriteHeader(code)
+	w.Write(output)
+}
+
+// Error sends an HTTP error response
+func (h *InvitationHTTPHandler) Error(w http.ResponseWriter, message string, code int) {
+	output, err := h.serializer.EncodeErrorResponse(invitation.ErrorResponse{Error: message})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, output, code)
+}
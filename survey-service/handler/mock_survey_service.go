@@ -10,52 +10,123 @@ type MockSurveyService struct {
 	mock.Mock
 }
 
-func (m *MockSurveyService) LoadByID(id string) (*survey.Survey, error) {
-	args := m.Called(id)
+func (m *MockSurveyService) LoadByID(user *survey.User, id string) (*survey.SurveyWithStatus, error) {
+	args := m.Called(user, id)
 	if args.Get(0) != nil {
-		return args.Get(0).(*survey.Survey), args.Error(1)
+		return args.Get(0).(*survey.SurveyWithStatus), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockSurveyService) Insert(s *survey.Survey) error {
-	args := m.Called(s)
+func (m *MockSurveyService) Insert(user *survey.User, s *survey.Survey) error {
+	args := m.Called(user, s)
 	return args.Error(0)
 }
 
-func (m *MockSurveyService) Update(s *survey.Survey) error {
-	args := m.Called(s)
+func (m *MockSurveyService) Update(user *survey.User, id string, s *survey.Survey) error {
+	args := m.Called(user, id, s)
 	return args.Error(0)
 }
 
-func (m *MockSurveyService) Delete(id string) error {
-	args := m.Called(id)
-	return args.Error(0)
+func (m *MockSurveyService) Load(user *survey.User) ([]survey.SurveyWithStatus, error) {
+	args := m.Called(user)
+	if args.Get(0) != nil {
+		return args.Get(0).([]survey.SurveyWithStatus), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSurveyService) LoadArchived(user *survey.User) ([]survey.SurveyWithStatus, error) {
+	args := m.Called(user)
+	if args.Get(0) != nil {
+		return args.Get(0).([]survey.SurveyWithStatus), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
-func (m *MockSurveyService) Load() (*survey.Surveys, error) {
+func (m *MockSurveyService) LoadActive() ([]survey.SurveyWithStatus, error) {
 	args := m.Called()
 	if args.Get(0) != nil {
-		return args.Get(0).(*survey.Surveys), args.Error(1)
+		return args.Get(0).([]survey.SurveyWithStatus), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
+func (m *MockSurveyService) ActivateSurvey(user *survey.User, id string) error {
+	args := m.Called(user, id)
+	return args.Error(0)
+}
+
+func (m *MockSurveyService) DeactivateSurvey(user *survey.User, id string) error {
+	args := m.Called(user, id)
+	return args.Error(0)
+}
+
+func (m *MockSurveyService) SetExpirationDate(user *survey.User, id string, expiresAt int64) error {
+	args := m.Called(user, id, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockSurveyService) DeleteSurvey(user *survey.User, id string) error {
+	args := m.Called(user, id)
+	return args.Error(0)
+}
+
+func (m *MockSurveyService) ArchiveSurvey(user *survey.User, id string) error {
+	args := m.Called(user, id)
+	return args.Error(0)
+}
+
+func (m *MockSurveyService) RestoreSurvey(user *survey.User, id string) error {
+	args := m.Called(user, id)
+	return args.Error(0)
+}
+
+func (m *MockSurveyService) PurgeArchived(before int64) (int, error) {
+	args := m.Called(before)
+	return args.Int(0), args.Error(1)
+}
+
 // MockSerializer is a mock implementation of the survey.Serializer interface
 type MockSerializer struct {
 	mock.Mock
 }
 
-func (m *MockSerializer) Encode(s *survey.Survey) ([]byte, error) {
+func (m *MockSerializer) Encode(s *survey.SurveyWithStatus) ([]byte, error) {
 	args := m.Called(s)
 	return args.Get(0).([]byte), args.Error(1)
 }
 
-func (m *MockSerializer) EncodeMultiple(surveys *survey.Surveys) ([]byte, error) {
+func (m *MockSerializer) EncodeMultiple(surveys []survey.SurveyWithStatus) ([]byte, error) {
 	args := m.Called(surveys)
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockSerializer) EncodeSurvey(s *survey.Survey) ([]byte, error) {
+	args := m.Called(s)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSerializer) EncodeErrorResponse(err survey.ErrorResponse) ([]byte, error) {
+	args := m.Called(err)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSerializer) EncodeSurveyResponse(sr survey.SurveyResponse) ([]byte, error) {
+	args := m.Called(sr)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSerializer) EncodeSurveysResponse(sr survey.SurveysResponse) ([]byte, error) {
+	args := m.Called(sr)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSerializer) EncodeEvent(e *survey.Event) ([]byte, error) {
+	args := m.Called(e)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *MockSerializer) Decode(data []byte) (*survey.Survey, error) {
 	args := m.Called(data)
 	return args.Get(0).(*survey.Survey), args.Error(1)
@@ -66,11 +137,6 @@ func (m *MockSerializer) DecodeMultiple(data []byte) (*survey.Surveys, error) {
 	return args.Get(0).(*survey.Surveys), args.Error(1)
 }
 
-func (m *MockSerializer) EncodeErrorResponse(err survey.ErrorResponse) ([]byte, error) {
-	args := m.Called(err)
-	return args.Get(0).([]byte), args.Error(1)
-}
-
 func (m *MockSerializer) GetContentType() string {
 	return "application/json"
 }
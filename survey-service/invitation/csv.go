@@ -0,0 +1,65 @@
+package invitation
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseCSV reads recipients from CSV data
+// The first row is a header with an email column and an optional name column, in any order
+func ParseCSV(r io.Reader) ([]Recipient, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read CSV header: %v", ErrInvalidRequest, err)
+	}
+
+	email, name := -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "email":
+			email = i
+		case "name":
+			name = i
+		}
+	}
+	if email < 0 {
+		return nil, fmt.Errorf("%w: CSV header has no email column", ErrInvalidRequest)
+	}
+
+	recipients := make([]Recipient, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidRequest, line, err)
+		}
+
+		r := Recipient{Email: field(record, email)}
+		if r.Email == "" {
+			continue
+		}
+		if name >= 0 {
+			r.Name = field(record, name)
+		}
+		recipients = append(recipients, r)
+	}
+
+	return recipients, nil
+}
+
+// field returns a trimmed field of a CSV record, empty when the record is too short
+func field(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
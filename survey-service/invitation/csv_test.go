@@ -0,0 +1,21 @@
+package invitation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseCSV tests reading recipients from CSV data
+func TestParseCSV(t *testing.T) {
+	recipients, err := ParseCSV(strings.NewReader("Name,Email\nAnn,ann@example.com\n,\nBob , bob@example.com\n"))
+	assert.NoError(t, err, "Expected no error on parse")
+	assert.Equal(t, []Recipient{
+		{Email: "ann@example.com", Name: "Ann"},
+		{Email: "bob@example.com", Name: "Bob"},
+	}, recipients, "Expected rows without an email to be skipped")
+
+	_, err = ParseCSV(strings.NewReader("name\nAnn\n"))
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected an error without an email column")
+}
@@ -0,0 +1,170 @@
+package invitation
+
+import (
+	"testing"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// testEditor owns the test survey
+	testEditor = &survey.User{ID: "editor-1", Roles: []survey.Role{survey.RoleEditor}}
+
+	// testOtherEditor is an editor the test survey is not shared with
+	testOtherEditor = &survey.User{ID: "editor-2", Roles: []survey.Role{survey.RoleEditor}}
+)
+
+// testSurveys is a survey repository serving a single survey, its other methods are not used by the invitation service
+type testSurveys struct {
+	survey.Repository
+	survey *survey.Survey
+}
+
+// LoadByID returns the survey
+func (r *testSurveys) LoadByID(id string) (*survey.Survey, error) {
+	if id != r.survey.ID {
+		return nil, survey.ErrNotFound
+	}
+	return r.survey, nil
+}
+
+// testRepository is an invitation repository keeping invitations in memory
+type testRepository struct {
+	invitations []*Invitation
+}
+
+// Insert stores the invitations
+func (r *testRepository) Insert(invitations []*Invitation) error {
+	r.invitations = append(r.invitations, invitations...)
+	return nil
+}
+
+// LoadBySurvey returns the invitations of the survey
+func (r *testRepository) LoadBySurvey(surveyID string) ([]*Invitation, error) {
+	var invitations []*Invitation
+	for _, i := range r.invitations {
+		if i.Survey == surveyID {
+			invitations = append(invitations, i)
+		}
+	}
+	return invitations, nil
+}
+
+// find returns the invitation of the survey matching the token hash
+func (r *testRepository) find(surveyID, hash string) *Invitation {
+	for _, i := range r.invitations {
+		if i.Survey == surveyID && i.Hash == hash {
+			return i
+		}
+	}
+	return nil
+}
+
+// Exists checks if the invitation is stored
+func (r *testRepository) Exists(surveyID, hash string) (bool, error) {
+	return r.find(surveyID, hash) != nil, nil
+}
+
+// Use marks the question as answered with the invitation
+func (r *testRepository) Use(surveyID, hash string, question int, at int64) error {
+	i := r.find(surveyID, hash)
+	if i == nil {
+		return ErrNotFound
+	}
+	for _, q := range i.Answered {
+		if q == question {
+			return ErrAlreadyUsed
+		}
+	}
+	i.Answered = append(i.Answered, question)
+	i.RespondedAt = at
+	return nil
+}
+
+// Release marks the question as not answered with the invitation
+func (r *testRepository) Release(surveyID, hash string, question int) error {
+	i := r.find(surveyID, hash)
+	if i == nil {
+		return ErrNotFound
+	}
+	i.Answered, i.RespondedAt = nil, 0
+	return nil
+}
+
+// newTestService creates an invitation service for an invite-only survey owned by the test editor
+func newTestService() (Service, *testRepository) {
+	surveys := &testSurveys{survey: &survey.Survey{ID: "survey-1", OwnerID: testEditor.ID, InviteOnly: true}}
+	repo := &testRepository{}
+	return NewService(repo, surveys), repo
+}
+
+// TestCreate tests that recipients are invited once, by editors of the survey
+func TestCreate(t *testing.T) {
+	service, repo := newTestService()
+
+	recipients := []Recipient{{Email: "ann@example.com"}, {Email: "ANN@example.com"}, {Email: "bob@example.com"}}
+	_, err := service.Create(testOtherEditor, "survey-1", recipients)
+	assert.ErrorIs(t, err, ErrForbidden, "Expected other editors not to invite recipients")
+
+	issued, err := service.Create(testEditor, "survey-1", recipients)
+	assert.NoError(t, err, "Expected no error on create")
+	assert.Len(t, issued, 2, "Expected duplicate recipients to be invited once")
+	assert.Equal(t, hashToken(issued[0].Token), repo.invitations[0].Hash, "Expected the hash of the token to be stored")
+
+	again, _ := service.Create(testEditor, "survey-1", recipients[:1])
+	assert.Empty(t, again, "Expected invited recipients to be skipped")
+
+	_, err = service.Create(testEditor, "survey-1", []Recipient{{Email: "not an email"}})
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected invalid emails to be rejected")
+	_, err = service.Create(testEditor, "survey-2", recipients)
+	assert.ErrorIs(t, err, survey.ErrNotFound, "Expected unknown surveys to be reported")
+}
+
+// TestUse tests that invitation tokens answer each question once
+func TestUse(t *testing.T) {
+	service, _ := newTestService()
+	issued, _ := service.Create(testEditor, "survey-1", []Recipient{{Email: "ann@example.com"}, {Email: "bob@example.com"}})
+	token := issued[0].Token
+
+	assert.ErrorIs(t, service.Use("survey-1", "", 1), ErrInvalidToken, "Expected missing tokens to be rejected")
+	assert.ErrorIs(t, service.Use("survey-1", "inv_unknown", 1), ErrInvalidToken, "Expected unknown tokens to be rejected")
+	assert.ErrorIs(t, service.Use("other", token, 1), ErrInvalidToken, "Expected tokens to be bound to their survey")
+	assert.NoError(t, service.Use("survey-1", token, 1), "Expected the token to answer the question")
+	assert.ErrorIs(t, service.Use("survey-1", token, 1), ErrAlreadyUsed, "Expected the token to answer a question once")
+	assert.NoError(t, service.Use("survey-1", token, 2), "Expected the token to answer other questions")
+
+	report, err := service.Report(testEditor, "survey-1")
+	assert.NoError(t, err, "Expected no error on report")
+	assert.Equal(t, 2, report.Total, "Expected every invitation to be reported")
+	assert.Equal(t, 1, report.Responded, "Expected one invitation to be responded to")
+
+	_, err = service.Report(testOtherEditor, "survey-1")
+	assert.ErrorIs(t, err, ErrForbidden, "Expected other editors not to view the report")
+}
+
+// TestVerify tests that tokens are verified without being consumed
+func TestVerify(t *testing.T) {
+	service, _ := newTestService()
+	issued, _ := service.Create(testEditor, "survey-1", []Recipient{{Email: "ann@example.com"}})
+	token := issued[0].Token
+
+	assert.NoError(t, service.Verify("survey-1", token), "Expected the token to be verified")
+	assert.ErrorIs(t, service.Verify("survey-1", ""), ErrInvalidToken, "Expected missing tokens to be rejected")
+	assert.ErrorIs(t, service.Verify("survey-1", "inv_unknown"), ErrInvalidToken, "Expected unknown tokens to be rejected")
+	assert.ErrorIs(t, service.Verify("other", token), ErrInvalidToken, "Expected tokens to be bound to their survey")
+	assert.NoError(t, service.Use("survey-1", token, 1), "Expected verified tokens to stay unused")
+}
+
+// TestRelease tests that released tokens can answer the question again
+func TestRelease(t *testing.T) {
+	service, _ := newTestService()
+	issued, _ := service.Create(testEditor, "survey-1", []Recipient{{Email: "ann@example.com"}})
+	token := issued[0].Token
+
+	assert.NoError(t, service.Use("survey-1", token, 1), "Expected the token to answer the question")
+	assert.NoError(t, service.Release("survey-1", token, 1), "Expected the token to be released")
+	assert.NoError(t, service.Use("survey-1", token, 1), "Expected the released token to answer the question again")
+	assert.ErrorIs(t, service.Release("survey-1", "inv_unknown", 1), ErrInvalidToken, "Expected unknown tokens to be rejected")
+	assert.ErrorIs(t, service.Release("survey-1", "", 1), ErrInvalidToken, "Expected missing tokens to be rejected")
+}
@@ -0,0 +1,199 @@
+package invitation
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/go-playground/validator"
+	"github.com/teris-io/shortid"
+)
+
+var (
+	// ErrNotFound indicates that a requested invitation was not found
+	ErrNotFound = errors.New("Invitation not found")
+
+	// ErrInvalidRequest indicates that an invalid invitation request was provided
+	ErrInvalidRequest = errors.New("invalid invitation input")
+
+	// ErrForbidden indicates that the user is not allowed to manage the invitations of a survey
+	ErrForbidden = errors.New("access to invitations forbidden")
+
+	// ErrInvalidToken indicates that an invitation token is unknown for the survey
+	ErrInvalidToken = errors.New("invalid invitation token")
+
+	// ErrAlreadyUsed indicates that the question was already answered with the invitation
+	ErrAlreadyUsed = errors.New("invitation already used for this question")
+)
+
+// Format of invitation tokens
+const (
+	tokenPrefix = "inv_" // Prefix marking invitation tokens
+	tokenBytes  = 24     // Random bytes of a token
+)
+
+// invitationService implements the Service interface for managing invitations
+type invitationService struct {
+	repository Repository
+	surveys    survey.Repository
+	validator  *validator.Validate
+}
+
+// NewService creates a new invitation service
+// This function initializes a new invitationService with the invitation and survey repositories
+func NewService(repository Repository, surveys survey.Repository) Service {
+	return &invitationService{
+		repository: repository,
+		surveys:    surveys,
+		validator:  validator.New(),
+	}
+}
+
+// Create validates the recipients and creates their invitations
+// Recipients who are already invited, or listed twice, are skipped so their tokens stay valid
+func (s *invitationService) Create(user *survey.User, surveyID string, recipients []Recipient) ([]*IssuedInvitation, error) {
+	surv, err := s.surveys.LoadByID(surveyID)
+	if err != nil {
+		return nil, err
+	}
+	if !surv.CanEdit(user) {
+		return nil, ErrForbidden
+	}
+	if surv.IsArchived() {
+		return nil, survey.ErrSurveyArchived
+	}
+
+	if err := s.validator.Struct(&CreateRequest{Recipients: recipients}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	existing, err := s.repository.LoadBySurvey(surveyID)
+	if err != nil {
+		return nil, err
+	}
+	invited := make(map[string]bool, len(existing))
+	for _, i := range existing {
+		invited[strings.ToLower(i.Email)] = true
+	}
+
+	now := time.Now().UTC().Unix()
+	issued := make([]*IssuedInvitation, 0, len(recipients))
+	invitations := make([]*Invitation, 0, len(recipients))
+	for _, r := range recipients {
+		email := strings.ToLower(r.Email)
+		if invited[email] {
+			continue
+		}
+		invited[email] = true
+
+		token, err := generateToken()
+		if err != nil {
+			return nil, err
+		}
+		i := &Invitation{
+			ID:        shortid.MustGenerate(),
+			Survey:    surveyID,
+			Email:     email,
+			Name:      r.Name,
+			Hash:      hashToken(token),
+			CreatedAt: now,
+		}
+		invitations = append(invitations, i)
+		issued = append(issued, &IssuedInvitation{Invitation: i, Token: token})
+	}
+
+	if len(invitations) > 0 {
+		if err := s.repository.Insert(invitations); err != nil {
+			return nil, err
+		}
+	}
+
+	return issued, nil
+}
+
+// Report loads the invitations of a survey and counts those responded to
+func (s *invitationService) Report(user *survey.User, surveyID string) (*Report, error) {
+	surv, err := s.surveys.LoadByID(surveyID)
+	if err != nil {
+		return nil, err
+	}
+	if !surv.CanView(user) {
+		return nil, ErrForbidden
+	}
+
+	invitations, err := s.repository.LoadBySurvey(surveyID)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{Survey: surveyID, Total: len(invitations), Invitations: invitations}
+	for _, i := range invitations {
+		if i.HasResponded() {
+			r.Responded++
+		}
+	}
+	return r, nil
+}
+
+// Verify checks an invitation token of a survey without consuming it
+func (s *invitationService) Verify(surveyID, token string) error {
+	if token == "" {
+		return ErrInvalidToken
+	}
+
+	ok, err := s.repository.Exists(surveyID, hashToken(token))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// Use consumes an invitation token to answer a question of a survey
+func (s *invitationService) Use(surveyID, token string, question int) error {
+	if token == "" {
+		return ErrInvalidToken
+	}
+
+	err := s.repository.Use(surveyID, hashToken(token), question, time.Now().UTC().Unix())
+	if errors.Is(err, ErrNotFound) {
+		return ErrInvalidToken
+	}
+	return err
+}
+
+// Release releases an invitation token used to answer a question of a survey
+func (s *invitationService) Release(surveyID, token string, question int) error {
+	if token == "" {
+		return ErrInvalidToken
+	}
+
+	err := s.repository.Release(surveyID, hashToken(token), question)
+	if errors.Is(err, ErrNotFound) {
+		return ErrInvalidToken
+	}
+	return err
+}
+
+// generateToken generates a new random invitation token
+func generateToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken hashes an invitation token for storage
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
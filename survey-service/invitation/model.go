@@ -0,0 +1,50 @@
+package invitation
+
+// Recipient describes a person invited to respond to a survey
+type Recipient struct {
+	Email string `json:"email" validate:"required,email"` // Email address of the recipient, unique per survey
+	Name  string `json:"name,omitempty"`                  // Optional name of the recipient
+}
+
+// Invitation describes an invitation to respond to an invite-only survey
+// This struct tracks whether the recipient responded, never which answers they gave
+type Invitation struct {
+	ID          string `json:"id" bson:"id"`                                       // Unique identifier for the invitation
+	Survey      string `json:"survey" bson:"survey"`                               // Survey ID the recipient is invited to
+	Email       string `json:"email" bson:"email"`                                 // Email address of the recipient
+	Name        string `json:"name,omitempty" bson:"name,omitempty"`               // Optional name of the recipient
+	Hash        string `json:"-" bson:"hash"`                                      // SHA-256 hash of the token, the token itself is never stored
+	CreatedAt   int64  `json:"createdAt" bson:"createdAt"`                         // Timestamp of when the invitation was created
+	RespondedAt int64  `json:"respondedAt,omitempty" bson:"respondedAt,omitempty"` // Timestamp of the first answer given with the invitation
+	Answered    []int  `json:"-" bson:"answered,omitempty"`                        // IDs of the questions answered with the invitation
+}
+
+// IssuedInvitation is a newly created invitation
+// The token is only returned once, when the invitation is created, to be sent to the recipient
+type IssuedInvitation struct {
+	*Invitation
+	Token string `json:"token"` // The token the recipient sends with their answers
+}
+
+// CreateRequest describes a request to invite recipients to a survey
+type CreateRequest struct {
+	Recipients []Recipient `json:"recipients" validate:"required,min=1,dive"` // Recipients to invite
+}
+
+// Report describes which invitations of a survey have been responded to
+type Report struct {
+	Survey      string        `json:"survey"`      // Survey ID of the invitations
+	Total       int           `json:"total"`       // Number of invitations
+	Responded   int           `json:"responded"`   // Number of invitations used to answer at least one question
+	Invitations []*Invitation `json:"invitations"` // Invitations of the survey
+}
+
+// ErrorResponse provides a structure for error responses
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// HasResponded checks if the invitation was used to answer the survey
+func (i *Invitation) HasResponded() bool {
+	return i.RespondedAt != 0
+}
@@ -0,0 +1,26 @@
+package invitation
+
+// Repository contains functions to store and fetch invitations from a repository
+// This interface defines the methods required for interacting with an invitation repository
+type Repository interface {
+	// Insert stores new invitations
+	// This method saves the invitations to the repository
+	Insert(invitations []*Invitation) error
+
+	// LoadBySurvey loads the invitations of a survey
+	// This method retrieves the invitations in the order they were created
+	LoadBySurvey(surveyID string) ([]*Invitation, error)
+
+	// Exists checks if the survey has an invitation matching a token hash
+	// This method never marks the invitation as used
+	Exists(surveyID, hash string) (bool, error)
+
+	// Use marks a question as answered with the invitation of a survey matching a token hash
+	// This method returns ErrNotFound for unknown invitations and ErrAlreadyUsed when the
+	// question was already answered, checking and marking the question atomically
+	Use(surveyID, hash string, question int, at int64) error
+
+	// Release marks a question as not answered with the invitation of a survey matching a token hash
+	// This method returns ErrNotFound for unknown invitations, releasing a question not answered has no effect
+	Release(surveyID, hash string, question int) error
+}
@@ -0,0 +1,25 @@
+package invitation
+
+// Serializer contains functions to encode and decode invitations
+// This interface defines the methods required for serializing and deserializing invitation data
+type Serializer interface {
+	// EncodeIssued encodes newly created invitations
+	// This method converts issued invitations, including their tokens, into a byte slice (e.g., JSON)
+	EncodeIssued(invitations []*IssuedInvitation) ([]byte, error)
+
+	// EncodeReport encodes an invitation report
+	// This method converts a report into a byte slice (e.g., JSON)
+	EncodeReport(r *Report) ([]byte, error)
+
+	// EncodeErrorResponse encodes an error response
+	// This method converts an error response into a byte slice (e.g., JSON)
+	EncodeErrorResponse(er ErrorResponse) ([]byte, error)
+
+	// DecodeCreateRequest decodes a request to invite recipients
+	// This method converts a byte slice into a create request (e.g., from JSON)
+	DecodeCreateRequest(data []byte) (*CreateRequest, error)
+
+	// GetContentType returns the content-type
+	// This method returns the MIME type of the serialized data (e.g., "application/json")
+	GetContentType() string
+}
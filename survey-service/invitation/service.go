@@ -0,0 +1,29 @@
+package invitation
+
+import "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+
+// Service contains functions to invite recipients to surveys and track their responses
+// This interface defines the methods required for managing invitations in the application
+// Methods taking a user return ErrForbidden when the user is not allowed to perform them
+type Service interface {
+	// Create invites recipients to a survey the user can edit
+	// This method returns the tokens of the new invitations, which cannot be retrieved again
+	Create(user *survey.User, surveyID string, recipients []Recipient) ([]*IssuedInvitation, error)
+
+	// Report reports which invitations of a survey the user can view have been responded to
+	// This method never reveals which answers a recipient gave
+	Report(user *survey.User, surveyID string) (*Report, error)
+
+	// Verify checks an invitation token of a survey without consuming it
+	// This method returns ErrInvalidToken for unknown tokens
+	Verify(surveyID, token string) error
+
+	// Use consumes an invitation token to answer a question of a survey
+	// This method returns ErrInvalidToken for unknown tokens and ErrAlreadyUsed when the
+	// question was already answered with the token
+	Use(surveyID, token string, question int) error
+
+	// Release releases an invitation token used to answer a question of a survey
+	// This method lets the token answer the question again when the answer could not be stored
+	Release(surveyID, token string, question int) error
+}
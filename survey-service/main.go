@@ -6,13 +6,20 @@ import (
 	"os/signal"
 	"time"
 
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/apikey"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/config"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/handler"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/invitation"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/logger"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/ratelimit"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/repository"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/respondent"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/router"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/serializer"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/server"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/rs/zerolog"
 )
 
 func main() {
@@ -33,12 +40,72 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize the survey service with the repository
-	service := survey.NewService(repo)
+	// Initialize the publisher of survey change events
+	publisher, err := repository.NewEventPublisher(cfg, &log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot connect to event publisher")
+		os.Exit(1)
+	}
+
+	// Initialize the survey service with the repository and publisher
+	service := survey.NewService(repo, publisher)
+
+	// Initialize the API key service of machine clients
+	keyRepo, err := repository.NewAPIKeyRepository(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load API key repository")
+		os.Exit(1)
+	}
+	keyService := apikey.NewService(keyRepo)
+
+	// Initialize the invitation service of invite-only surveys
+	invitationRepo, err := repository.NewInvitationRepository(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load invitation repository")
+		os.Exit(1)
+	}
+	invitationService := invitation.NewService(invitationRepo, repo)
+
+	// Initialize the authenticator of survey administration requests
+	keys, err := auth.NewKeySource(cfg.Auth.Tokens())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load authentication keys")
+		os.Exit(1)
+	}
+	authenticator := auth.NewAuthenticator(keys, cfg.Auth.Tokens())
+
+	// Issue respondent tokens with fetched surveys when a secret is shared with the vote service
+	var issuer *respondent.TokenIssuer
+	if cfg.Respondent.Secret != "" {
+		issuer, err = respondent.NewTokenIssuer(cfg.Respondent)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Cannot load respondent token issuer")
+			os.Exit(1)
+		}
+	}
+
+	// Load the store of the HTTP rate limits
+	limits, err := ratelimit.NewStore(cfg.RateLimit.Store)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load rate limit store")
+		os.Exit(1)
+	}
+
+	// Resolve client addresses from the header set by the trusted proxies
+	clients, err := ratelimit.NewClientResolver(cfg.HTTP.ClientIPHeader, cfg.HTTP.TrustedProxies)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load trusted proxies")
+		os.Exit(1)
+	}
+
+	// Verify API keys through a cache, so repeated requests do not reach the key store
+	keyVerifier := auth.NewCachedKeyVerifier(apikey.NewVerifier(keyService), cfg.Auth.KeyCacheTTL, cfg.Auth.KeyCacheSize)
 
 	// Set up the HTTP server dependencies
-	httpHandler := handler.NewSurveyHTTPHandler(service, &log)
-	httpRouter := router.NewRouter(*httpHandler)
+	httpHandler := handler.NewSurveyHTTPHandler(service, invitationService, &log)
+	keyHandler := handler.NewAPIKeyHTTPHandler(keyService, serializer.NewAPIKeyJSONSerializer(), &log)
+	invitationHandler := handler.NewInvitationHTTPHandler(invitationService, serializer.NewInvitationJSONSerializer(), &log)
+	httpRouter := router.NewRouter(*httpHandler, keyHandler, invitationHandler, authenticator, keyVerifier, apikey.NewOwnerRecorder(keyService), cfg.Auth, issuer, limits, clients, cfg.RateLimit)
 	httpServer := server.NewHTTPServer(httpRouter, cfg.HTTP)
 
 	// Start the HTTP server in a new goroutine
@@ -52,11 +119,15 @@ func main() {
 	}()
 
 	// Set up the gRPC server dependencies
-	grpcHandler := handler.NewSurveyGrpcHandler(service, &log)
+	grpcHandler := handler.NewSurveyGrpcHandler(service, keyService, invitationService, &log)
 
 	// Start the gRPC server
 	server.StartGrpcServer(grpcHandler, cfg.Grpc, &log)
 
+	// Purge archived surveys once their retention period has passed
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	go purgeArchived(purgeCtx, service, cfg.Archive, &log)
+
 	// Set up signal handling for graceful shutdown
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, os.Kill)
@@ -64,9 +135,33 @@ func main() {
 	// Block until a signal is received
 	sig := <-c
 	log.Warn().Msgf("Signal received: %v", sig)
+	stopPurge()
 
 	// Gracefully shutdown the HTTP server, allowing up to 30 seconds
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	httpServer.Shutdown(ctx)
 }
+
+// purgeArchived periodically deletes surveys archived longer than the retention period
+// This function runs until the context is cancelled
+func purgeArchived(ctx context.Context, service survey.Service, cfg config.ArchiveConfig, log *zerolog.Logger) {
+	ticker := time.NewTicker(cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := service.PurgeArchived(time.Now().UTC().Add(-cfg.Retention).Unix())
+			if err != nil {
+				log.Error().Err(err).Msg("Unable to purge archived surveys")
+				continue
+			}
+			if purged > 0 {
+				log.Info().Int("surveys", purged).Msg("Archived surveys purged")
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+)
+
+// Authenticate verifies the bearer token of a request and adds its principal to the request context
+// Requests without a token are passed on anonymously, requests with an invalid token are rejected
+func Authenticate(a *auth.Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := bearerToken(r)
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			p, err := a.Authenticate(token)
+			if err != nil {
+				unauthorized(w, r, auth.ErrInvalidToken)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), p)))
+		})
+	}
+}
+
+// AuthenticateAPIKey verifies the API key of a request and adds its principal to the request context
+// Requests already authenticated with a bearer token or without a key are passed on unchanged
+func AuthenticateAPIKey(v auth.KeyVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(APIKeyHeader)
+			if _, ok := auth.FromContext(r.Context()); ok || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			p, err := v.VerifyAPIKey(key)
+			if errors.Is(err, auth.ErrInvalidAPIKey) {
+				unauthorized(w, r, auth.ErrInvalidAPIKey)
+				return
+			}
+			if err != nil {
+				errorResponse(w, r, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), p)))
+		})
+	}
+}
+
+// RecordKeyOwner records the roles of requests authenticated with a bearer token as the roles of their API keys
+// This middleware must be used after Authenticate, requests authenticated with an API key or anonymous are passed on
+func RecordKeyOwner(o auth.OwnerRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if p, ok := auth.FromContext(r.Context()); ok && !p.IsAPIKey() {
+				if err := o.RecordOwner(p); err != nil {
+					errorResponse(w, r, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScope rejects requests authenticated with an API key lacking a scope
+// Anonymous requests and requests authenticated with a bearer token are passed on
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if p, ok := auth.FromContext(r.Context()); ok && !p.AllowsScope(scope) {
+				errorResponse(w, r, "API key lacks scope "+scope, http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireAuth rejects requests that were not authenticated
+// This middleware must be used after Authenticate
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := auth.FromContext(r.Context()); !ok {
+			unauthorized(w, r, auth.ErrMissingToken)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireBearer rejects requests that were not authenticated with a bearer token
+// This middleware keeps API keys from managing API keys and must be used after Authenticate
+func RequireBearer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := auth.FromContext(r.Context())
+		if !ok {
+			unauthorized(w, r, auth.ErrMissingToken)
+			return
+		}
+		if p.IsAPIKey() {
+			errorResponse(w, r, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bearerToken returns the bearer token of the Authorization header of a request
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	if len(h) < 7 || !strings.EqualFold(h[:7], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(h[7:])
+}
+
+// unauthorized sends an HTTP 401 error response
+func unauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer`)
+	errorResponse(w, r, err.Error(), http.StatusUnauthorized)
+}
+
+// errorResponse sends an HTTP error response encoded with the serializer of the request
+func errorResponse(w http.ResponseWriter, r *http.Request, message string, code int) {
+	serializer := getSerializer(r.Header.Get("Content-Type"))
+	output, err := serializer.EncodeErrorResponse(survey.ErrorResponse{Error: message})
+	if err != nil {
+		http.Error(w, http.StatusText(code), code)
+		return
+	}
+
+	w.Header().Set("Content-Type", serializer.GetContentType())
+	w.WriteHeader(code)
+	w.Write(output)
+}
@@ -14,6 +14,9 @@ type key int
 // SerializerKey is used as a key to store the serializer in the context
 const SerializerKey key = 0
 
+// InvitationTokenHeader is the request header carrying the invitation token of invite-only surveys
+const InvitationTokenHeader = "X-Invitation-Token"
+
 var (
 	// serializers stores instances of different serializers
 	serializers      = make(map[string]survey.Serializer)
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/ratelimit"
+)
+
+// APIKeyHeader is the request header carrying API keys
+const APIKeyHeader = "X-API-Key"
+
+// RateLimit throttles the requests of each client to a route group
+// This middleware must be used after Authenticate so authenticated clients are limited per user
+func RateLimit(s ratelimit.Store, group string, l ratelimit.Limit) func(http.Handler) http.Handler {
+	return ratelimit.Middleware(s, group, l, tooManyRequests)
+}
+
+// tooManyRequests sends an HTTP 429 error response
+func tooManyRequests(w http.ResponseWriter, r *http.Request) {
+	errorResponse(w, r, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+}
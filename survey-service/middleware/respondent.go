@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/respondent"
+	"github.com/go-chi/chi"
+)
+
+// RespondentTokenHeader is the response header carrying respondent tokens
+const RespondentTokenHeader = "X-Respondent-Token"
+
+// IssueRespondentToken adds a respondent token for the requested survey to the response
+// The token must be sent back to the vote service with every vote on the survey
+func IssueRespondentToken(i *respondent.TokenIssuer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := chi.URLParam(r, "id"); id != "" {
+				if token, err := i.Issue(id); err == nil {
+					w.Header().Set(RespondentTokenHeader, token)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
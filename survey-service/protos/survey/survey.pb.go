@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.25.0
+// 	protoc-gen-go v1.34.1
 // 	protoc        (unknown)
 // source: survey.proto
 
@@ -8,7 +8,6 @@ package survey
 
 import (
 	context "context"
-	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -25,18 +24,12 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// This is a compile-time assertion that a sufficiently up-to-date version
-// of the legacy proto package is being used.
-const _ = proto.ProtoPackageIsVersion4
-
-// SurveyRequest defines the request for a survey
 type SurveyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Id is the survey ID
-	Id string `protobuf:"bytes,1,opt,name=Id,json=id,proto3" json:"Id,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
 }
 
 func (x *SurveyRequest) Reset() {
@@ -78,26 +71,225 @@ func (x *SurveyRequest) GetId() string {
 	return ""
 }
 
-// SurveyResponse contains the requested survey
+type ActiveSurveysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ActiveSurveysRequest) Reset() {
+	*x = ActiveSurveysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActiveSurveysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveSurveysRequest) ProtoMessage() {}
+
+func (x *ActiveSurveysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveSurveysRequest.ProtoReflect.Descriptor instead.
+func (*ActiveSurveysRequest) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{1}
+}
+
+type SurveysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SurveysRequest) Reset() {
+	*x = SurveysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurveysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurveysRequest) ProtoMessage() {}
+
+func (x *SurveysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurveysRequest.ProtoReflect.Descriptor instead.
+func (*SurveysRequest) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{2}
+}
+
+type SurveyValidationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SurveyId   string `protobuf:"bytes,1,opt,name=SurveyId,proto3" json:"SurveyId,omitempty"`
+	QuestionId int32  `protobuf:"varint,2,opt,name=QuestionId,proto3" json:"QuestionId,omitempty"`
+}
+
+func (x *SurveyValidationRequest) Reset() {
+	*x = SurveyValidationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurveyValidationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurveyValidationRequest) ProtoMessage() {}
+
+func (x *SurveyValidationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurveyValidationRequest.ProtoReflect.Descriptor instead.
+func (*SurveyValidationRequest) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SurveyValidationRequest) GetSurveyId() string {
+	if x != nil {
+		return x.SurveyId
+	}
+	return ""
+}
+
+func (x *SurveyValidationRequest) GetQuestionId() int32 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+type SurveyValidationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Valid        bool   `protobuf:"varint,1,opt,name=Valid,proto3" json:"Valid,omitempty"`
+	Message      string `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
+	QuestionType string `protobuf:"bytes,3,opt,name=QuestionType,proto3" json:"QuestionType,omitempty"`
+}
+
+func (x *SurveyValidationResponse) Reset() {
+	*x = SurveyValidationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurveyValidationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurveyValidationResponse) ProtoMessage() {}
+
+func (x *SurveyValidationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurveyValidationResponse.ProtoReflect.Descriptor instead.
+func (*SurveyValidationResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SurveyValidationResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *SurveyValidationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SurveyValidationResponse) GetQuestionType() string {
+	if x != nil {
+		return x.QuestionType
+	}
+	return ""
+}
+
 type SurveyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Id is the survey ID
-	Id string `protobuf:"bytes,1,opt,name=Id,json=id,proto3" json:"Id,omitempty"`
-	// Name is the survey name
-	Name string `protobuf:"bytes,2,opt,name=Name,json=name,proto3" json:"Name,omitempty"`
-	// Questions is a list of questions
-	Questions []*QuestionResponse `protobuf:"bytes,3,rep,name=Questions,json=questions,proto3" json:"Questions,omitempty"`
-	// CreatedAt is the timestamp of when the survey was created
-	CreatedAt int64 `protobuf:"varint,4,opt,name=CreatedAt,json=createdAt,proto3" json:"CreatedAt,omitempty"`
+	Id              string              `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Name            string              `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Description     string              `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
+	Questions       []*QuestionResponse `protobuf:"bytes,4,rep,name=Questions,proto3" json:"Questions,omitempty"`
+	CreatedAt       int64               `protobuf:"varint,5,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	ExpiresAt       int64               `protobuf:"varint,6,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+	Active          bool                `protobuf:"varint,7,opt,name=Active,proto3" json:"Active,omitempty"`
+	Status          string              `protobuf:"bytes,8,opt,name=Status,proto3" json:"Status,omitempty"`
+	AllowAnonymous  bool                `protobuf:"varint,9,opt,name=AllowAnonymous,proto3" json:"AllowAnonymous,omitempty"`
+	ThankYouMessage string              `protobuf:"bytes,10,opt,name=ThankYouMessage,proto3" json:"ThankYouMessage,omitempty"`
+	OwnerId         string              `protobuf:"bytes,11,opt,name=OwnerId,proto3" json:"OwnerId,omitempty"`
+	SharedWith      []string            `protobuf:"bytes,12,rep,name=SharedWith,proto3" json:"SharedWith,omitempty"`
+	AllowEdits      bool                `protobuf:"varint,13,opt,name=AllowEdits,proto3" json:"AllowEdits,omitempty"`
+	InviteOnly      bool                `protobuf:"varint,14,opt,name=InviteOnly,proto3" json:"InviteOnly,omitempty"`
 }
 
 func (x *SurveyResponse) Reset() {
 	*x = SurveyResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_survey_proto_msgTypes[1]
+		mi := &file_survey_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -110,7 +302,7 @@ func (x *SurveyResponse) String() string {
 func (*SurveyResponse) ProtoMessage() {}
 
 func (x *SurveyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_survey_proto_msgTypes[1]
+	mi := &file_survey_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -123,7 +315,7 @@ func (x *SurveyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyResponse.ProtoReflect.Descriptor instead.
 func (*SurveyResponse) Descriptor() ([]byte, []int) {
-	return file_survey_proto_rawDescGZIP(), []int{1}
+	return file_survey_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SurveyResponse) GetId() string {
@@ -140,6 +332,13 @@ func (x *SurveyResponse) GetName() string {
 	return ""
 }
 
+func (x *SurveyResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
 func (x *SurveyResponse) GetQuestions() []*QuestionResponse {
 	if x != nil {
 		return x.Questions
@@ -154,22 +353,146 @@ func (x *SurveyResponse) GetCreatedAt() int64 {
 	return 0
 }
 
-// QuestionResponse contains a question from a given survey
+func (x *SurveyResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *SurveyResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *SurveyResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SurveyResponse) GetAllowAnonymous() bool {
+	if x != nil {
+		return x.AllowAnonymous
+	}
+	return false
+}
+
+func (x *SurveyResponse) GetThankYouMessage() string {
+	if x != nil {
+		return x.ThankYouMessage
+	}
+	return ""
+}
+
+func (x *SurveyResponse) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *SurveyResponse) GetSharedWith() []string {
+	if x != nil {
+		return x.SharedWith
+	}
+	return nil
+}
+
+func (x *SurveyResponse) GetAllowEdits() bool {
+	if x != nil {
+		return x.AllowEdits
+	}
+	return false
+}
+
+func (x *SurveyResponse) GetInviteOnly() bool {
+	if x != nil {
+		return x.InviteOnly
+	}
+	return false
+}
+
+type SurveysResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Surveys []*SurveyResponse `protobuf:"bytes,1,rep,name=Surveys,proto3" json:"Surveys,omitempty"`
+}
+
+func (x *SurveysResponse) Reset() {
+	*x = SurveysResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurveysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurveysResponse) ProtoMessage() {}
+
+func (x *SurveysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurveysResponse.ProtoReflect.Descriptor instead.
+func (*SurveysResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SurveysResponse) GetSurveys() []*SurveyResponse {
+	if x != nil {
+		return x.Surveys
+	}
+	return nil
+}
+
 type QuestionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Id is the question ID
-	Id int32 `protobuf:"varint,1,opt,name=Id,json=id,proto3" json:"Id,omitempty"`
-	// Text is the question text
-	Text string `protobuf:"bytes,2,opt,name=Text,json=text,proto3" json:"Text,omitempty"`
+	Id               int32                     `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Text             string                    `protobuf:"bytes,2,opt,name=Text,proto3" json:"Text,omitempty"`
+	Type             string                    `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
+	Required         bool                      `protobuf:"varint,4,opt,name=Required,proto3" json:"Required,omitempty"`
+	Options          []*OptionResponse         `protobuf:"bytes,5,rep,name=Options,proto3" json:"Options,omitempty"`
+	Media            *MediaResponse            `protobuf:"bytes,6,opt,name=Media,proto3" json:"Media,omitempty"`
+	MinValue         *int32                    `protobuf:"varint,7,opt,name=MinValue,proto3,oneof" json:"MinValue,omitempty"`
+	MaxValue         *int32                    `protobuf:"varint,8,opt,name=MaxValue,proto3,oneof" json:"MaxValue,omitempty"`
+	ConditionalLogic *ConditionalLogicResponse `protobuf:"bytes,9,opt,name=ConditionalLogic,proto3" json:"ConditionalLogic,omitempty"`
+	Placeholder      string                    `protobuf:"bytes,10,opt,name=Placeholder,proto3" json:"Placeholder,omitempty"`
+	HelpText         string                    `protobuf:"bytes,11,opt,name=HelpText,proto3" json:"HelpText,omitempty"`
+	Tags             []string                  `protobuf:"bytes,12,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	Rows             []*OptionResponse         `protobuf:"bytes,13,rep,name=Rows,proto3" json:"Rows,omitempty"`
+	Columns          []*OptionResponse         `protobuf:"bytes,14,rep,name=Columns,proto3" json:"Columns,omitempty"`
+	TextConstraints  *TextConstraintsResponse  `protobuf:"bytes,15,opt,name=TextConstraints,proto3" json:"TextConstraints,omitempty"`
+	MinSelections    *int32                    `protobuf:"varint,16,opt,name=MinSelections,proto3,oneof" json:"MinSelections,omitempty"`
+	MaxSelections    *int32                    `protobuf:"varint,17,opt,name=MaxSelections,proto3,oneof" json:"MaxSelections,omitempty"`
+	FileConstraints  *FileConstraintsResponse  `protobuf:"bytes,18,opt,name=FileConstraints,proto3" json:"FileConstraints,omitempty"`
+	DateConstraints  *DateConstraintsResponse  `protobuf:"bytes,19,opt,name=DateConstraints,proto3" json:"DateConstraints,omitempty"`
 }
 
 func (x *QuestionResponse) Reset() {
 	*x = QuestionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_survey_proto_msgTypes[2]
+		mi := &file_survey_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -182,7 +505,7 @@ func (x *QuestionResponse) String() string {
 func (*QuestionResponse) ProtoMessage() {}
 
 func (x *QuestionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_survey_proto_msgTypes[2]
+	mi := &file_survey_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,7 +518,7 @@ func (x *QuestionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuestionResponse.ProtoReflect.Descriptor instead.
 func (*QuestionResponse) Descriptor() ([]byte, []int) {
-	return file_survey_proto_rawDescGZIP(), []int{2}
+	return file_survey_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *QuestionResponse) GetId() int32 {
@@ -212,60 +535,1011 @@ func (x *QuestionResponse) GetText() string {
 	return ""
 }
 
-var File_survey_proto protoreflect.FileDescriptor
-
-var file_survey_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1f,
-	0x0a, 0x0d, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0x83, 0x01, 0x0a, 0x0e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x51, 0x75, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x09, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x36, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x65, 0x78,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x32, 0x36, 0x0a,
-	0x06, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x75,
-	0x72, 0x76, 0x65, 0x79, 0x12, 0x0e, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *QuestionResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-var (
-	file_survey_proto_rawDescOnce sync.Once
-	file_survey_proto_rawDescData = file_survey_proto_rawDesc
-)
-
-func file_survey_proto_rawDescGZIP() []byte {
-	file_survey_proto_rawDescOnce.Do(func() {
-		file_survey_proto_rawDescData = protoimpl.X.CompressGZIP(file_survey_proto_rawDescData)
-	})
-	return file_survey_proto_rawDescData
+func (x *QuestionResponse) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
 }
 
-var file_survey_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_survey_proto_goTypes = []interface{}{
-	(*SurveyRequest)(nil),    // 0: SurveyRequest
-	(*SurveyResponse)(nil),   // 1: SurveyResponse
-	(*QuestionResponse)(nil), // 2: QuestionResponse
+func (x *QuestionResponse) GetOptions() []*OptionResponse {
+	if x != nil {
+		return x.Options
+	}
+	return nil
 }
-var file_survey_proto_depIdxs = []int32{
-	2, // 0: SurveyResponse.Questions:type_name -> QuestionResponse
-	0, // 1: Survey.GetSurvey:input_type -> SurveyRequest
-	1, // 2: Survey.GetSurvey:output_type -> SurveyResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+func (x *QuestionResponse) GetMedia() *MediaResponse {
+	if x != nil {
+		return x.Media
+	}
+	return nil
 }
 
-func init() { file_survey_proto_init() }
+func (x *QuestionResponse) GetMinValue() int32 {
+	if x != nil && x.MinValue != nil {
+		return *x.MinValue
+	}
+	return 0
+}
+
+func (x *QuestionResponse) GetMaxValue() int32 {
+	if x != nil && x.MaxValue != nil {
+		return *x.MaxValue
+	}
+	return 0
+}
+
+func (x *QuestionResponse) GetConditionalLogic() *ConditionalLogicResponse {
+	if x != nil {
+		return x.ConditionalLogic
+	}
+	return nil
+}
+
+func (x *QuestionResponse) GetPlaceholder() string {
+	if x != nil {
+		return x.Placeholder
+	}
+	return ""
+}
+
+func (x *QuestionResponse) GetHelpText() string {
+	if x != nil {
+		return x.HelpText
+	}
+	return ""
+}
+
+func (x *QuestionResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *QuestionResponse) GetRows() []*OptionResponse {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *QuestionResponse) GetColumns() []*OptionResponse {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *QuestionResponse) GetTextConstraints() *TextConstraintsResponse {
+	if x != nil {
+		return x.TextConstraints
+	}
+	return nil
+}
+
+func (x *QuestionResponse) GetMinSelections() int32 {
+	if x != nil && x.MinSelections != nil {
+		return *x.MinSelections
+	}
+	return 0
+}
+
+func (x *QuestionResponse) GetMaxSelections() int32 {
+	if x != nil && x.MaxSelections != nil {
+		return *x.MaxSelections
+	}
+	return 0
+}
+
+func (x *QuestionResponse) GetFileConstraints() *FileConstraintsResponse {
+	if x != nil {
+		return x.FileConstraints
+	}
+	return nil
+}
+
+func (x *QuestionResponse) GetDateConstraints() *DateConstraintsResponse {
+	if x != nil {
+		return x.DateConstraints
+	}
+	return nil
+}
+
+type DateConstraintsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode    string `protobuf:"bytes,1,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	MinDate *int64 `protobuf:"varint,2,opt,name=MinDate,proto3,oneof" json:"MinDate,omitempty"`
+	MaxDate *int64 `protobuf:"varint,3,opt,name=MaxDate,proto3,oneof" json:"MaxDate,omitempty"`
+}
+
+func (x *DateConstraintsResponse) Reset() {
+	*x = DateConstraintsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DateConstraintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateConstraintsResponse) ProtoMessage() {}
+
+func (x *DateConstraintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateConstraintsResponse.ProtoReflect.Descriptor instead.
+func (*DateConstraintsResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DateConstraintsResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *DateConstraintsResponse) GetMinDate() int64 {
+	if x != nil && x.MinDate != nil {
+		return *x.MinDate
+	}
+	return 0
+}
+
+func (x *DateConstraintsResponse) GetMaxDate() int64 {
+	if x != nil && x.MaxDate != nil {
+		return *x.MaxDate
+	}
+	return 0
+}
+
+type FileConstraintsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AllowedTypes []string `protobuf:"bytes,1,rep,name=AllowedTypes,proto3" json:"AllowedTypes,omitempty"`
+	MaxSize      int64    `protobuf:"varint,2,opt,name=MaxSize,proto3" json:"MaxSize,omitempty"`
+}
+
+func (x *FileConstraintsResponse) Reset() {
+	*x = FileConstraintsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileConstraintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileConstraintsResponse) ProtoMessage() {}
+
+func (x *FileConstraintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileConstraintsResponse.ProtoReflect.Descriptor instead.
+func (*FileConstraintsResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FileConstraintsResponse) GetAllowedTypes() []string {
+	if x != nil {
+		return x.AllowedTypes
+	}
+	return nil
+}
+
+func (x *FileConstraintsResponse) GetMaxSize() int64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+type TextConstraintsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinLength *int32 `protobuf:"varint,1,opt,name=MinLength,proto3,oneof" json:"MinLength,omitempty"`
+	MaxLength *int32 `protobuf:"varint,2,opt,name=MaxLength,proto3,oneof" json:"MaxLength,omitempty"`
+	Pattern   string `protobuf:"bytes,3,opt,name=Pattern,proto3" json:"Pattern,omitempty"`
+	Format    string `protobuf:"bytes,4,opt,name=Format,proto3" json:"Format,omitempty"`
+}
+
+func (x *TextConstraintsResponse) Reset() {
+	*x = TextConstraintsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TextConstraintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextConstraintsResponse) ProtoMessage() {}
+
+func (x *TextConstraintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextConstraintsResponse.ProtoReflect.Descriptor instead.
+func (*TextConstraintsResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TextConstraintsResponse) GetMinLength() int32 {
+	if x != nil && x.MinLength != nil {
+		return *x.MinLength
+	}
+	return 0
+}
+
+func (x *TextConstraintsResponse) GetMaxLength() int32 {
+	if x != nil && x.MaxLength != nil {
+		return *x.MaxLength
+	}
+	return 0
+}
+
+func (x *TextConstraintsResponse) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *TextConstraintsResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type OptionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int32  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Text  string `protobuf:"bytes,2,opt,name=Text,proto3" json:"Text,omitempty"`
+	Image string `protobuf:"bytes,3,opt,name=Image,proto3" json:"Image,omitempty"`
+	Other bool   `protobuf:"varint,4,opt,name=Other,proto3" json:"Other,omitempty"`
+}
+
+func (x *OptionResponse) Reset() {
+	*x = OptionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionResponse) ProtoMessage() {}
+
+func (x *OptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionResponse.ProtoReflect.Descriptor instead.
+func (*OptionResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OptionResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OptionResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *OptionResponse) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *OptionResponse) GetOther() bool {
+	if x != nil {
+		return x.Other
+	}
+	return false
+}
+
+type MediaResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    string `protobuf:"bytes,1,opt,name=Type,proto3" json:"Type,omitempty"`
+	Url     string `protobuf:"bytes,2,opt,name=Url,proto3" json:"Url,omitempty"`
+	Caption string `protobuf:"bytes,3,opt,name=Caption,proto3" json:"Caption,omitempty"`
+}
+
+func (x *MediaResponse) Reset() {
+	*x = MediaResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaResponse) ProtoMessage() {}
+
+func (x *MediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaResponse.ProtoReflect.Descriptor instead.
+func (*MediaResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MediaResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MediaResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *MediaResponse) GetCaption() string {
+	if x != nil {
+		return x.Caption
+	}
+	return ""
+}
+
+type ConditionalLogicResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type             string `protobuf:"bytes,1,opt,name=Type,proto3" json:"Type,omitempty"`
+	SourceQuestionId int32  `protobuf:"varint,2,opt,name=SourceQuestionId,proto3" json:"SourceQuestionId,omitempty"`
+	SourceOptionId   int32  `protobuf:"varint,3,opt,name=SourceOptionId,proto3" json:"SourceOptionId,omitempty"`
+	SourceValue      string `protobuf:"bytes,4,opt,name=SourceValue,proto3" json:"SourceValue,omitempty"`
+	Operator         string `protobuf:"bytes,5,opt,name=Operator,proto3" json:"Operator,omitempty"`
+}
+
+func (x *ConditionalLogicResponse) Reset() {
+	*x = ConditionalLogicResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConditionalLogicResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionalLogicResponse) ProtoMessage() {}
+
+func (x *ConditionalLogicResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionalLogicResponse.ProtoReflect.Descriptor instead.
+func (*ConditionalLogicResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ConditionalLogicResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ConditionalLogicResponse) GetSourceQuestionId() int32 {
+	if x != nil {
+		return x.SourceQuestionId
+	}
+	return 0
+}
+
+func (x *ConditionalLogicResponse) GetSourceOptionId() int32 {
+	if x != nil {
+		return x.SourceOptionId
+	}
+	return 0
+}
+
+func (x *ConditionalLogicResponse) GetSourceValue() string {
+	if x != nil {
+		return x.SourceValue
+	}
+	return ""
+}
+
+func (x *ConditionalLogicResponse) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+type APIKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+}
+
+func (x *APIKeyRequest) Reset() {
+	*x = APIKeyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *APIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeyRequest) ProtoMessage() {}
+
+func (x *APIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeyRequest.ProtoReflect.Descriptor instead.
+func (*APIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *APIKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type APIKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string   `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	OwnerId string   `protobuf:"bytes,2,opt,name=OwnerId,proto3" json:"OwnerId,omitempty"`
+	Roles   []string `protobuf:"bytes,3,rep,name=Roles,proto3" json:"Roles,omitempty"`
+	Scopes  []string `protobuf:"bytes,4,rep,name=Scopes,proto3" json:"Scopes,omitempty"`
+}
+
+func (x *APIKeyResponse) Reset() {
+	*x = APIKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *APIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeyResponse) ProtoMessage() {}
+
+func (x *APIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeyResponse.ProtoReflect.Descriptor instead.
+func (*APIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *APIKeyResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKeyResponse) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *APIKeyResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *APIKeyResponse) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type InvitationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SurveyId   string `protobuf:"bytes,1,opt,name=SurveyId,proto3" json:"SurveyId,omitempty"`
+	Token      string `protobuf:"bytes,2,opt,name=Token,proto3" json:"Token,omitempty"`
+	QuestionId int32  `protobuf:"varint,3,opt,name=QuestionId,proto3" json:"QuestionId,omitempty"`
+}
+
+func (x *InvitationRequest) Reset() {
+	*x = InvitationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvitationRequest) ProtoMessage() {}
+
+func (x *InvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvitationRequest.ProtoReflect.Descriptor instead.
+func (*InvitationRequest) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *InvitationRequest) GetSurveyId() string {
+	if x != nil {
+		return x.SurveyId
+	}
+	return ""
+}
+
+func (x *InvitationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *InvitationRequest) GetQuestionId() int32 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+type InvitationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *InvitationResponse) Reset() {
+	*x = InvitationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_survey_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvitationResponse) ProtoMessage() {}
+
+func (x *InvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_survey_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvitationResponse.ProtoReflect.Descriptor instead.
+func (*InvitationResponse) Descriptor() ([]byte, []int) {
+	return file_survey_proto_rawDescGZIP(), []int{17}
+}
+
+var File_survey_proto protoreflect.FileDescriptor
+
+var file_survey_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1f,
+	0x0a, 0x0d, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x22,
+	0x16, 0x0a, 0x14, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x10, 0x0a, 0x0e, 0x53, 0x75, 0x72, 0x76, 0x65,
+	0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x55, 0x0a, 0x17, 0x53, 0x75, 0x72,
+	0x76, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x64,
+	0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0x6e, 0x0a, 0x18, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
+	0x22, 0xbf, 0x03, 0x0a, 0x0e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x09, 0x51, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x51,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
+	0x09, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x41,
+	0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x41, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f, 0x75, 0x73, 0x12, 0x28,
+	0x0a, 0x0f, 0x54, 0x68, 0x61, 0x6e, 0x6b, 0x59, 0x6f, 0x75, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x54, 0x68, 0x61, 0x6e, 0x6b, 0x59, 0x6f,
+	0x75, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4f, 0x77, 0x6e, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4f, 0x77, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68,
+	0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x69,
+	0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x45, 0x64, 0x69, 0x74, 0x73,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x45, 0x64, 0x69,
+	0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x79,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x4f, 0x6e,
+	0x6c, 0x79, 0x22, 0x3c, 0x0a, 0x0f, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x73,
+	0x22, 0xc2, 0x06, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x07, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x52, 0x05, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x1f, 0x0a, 0x08, 0x4d, 0x69,
+	0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x08,
+	0x4d, 0x69, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x4d,
+	0x61, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52,
+	0x08, 0x4d, 0x61, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01, 0x12, 0x45, 0x0a, 0x10,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x6f, 0x67, 0x69, 0x63,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x52, 0x10, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x6f,
+	0x67, 0x69, 0x63, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x68,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x65, 0x6c, 0x70, 0x54, 0x65, 0x78,
+	0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x65, 0x6c, 0x70, 0x54, 0x65, 0x78,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x54, 0x61, 0x67, 0x73, 0x12, 0x23, 0x0a, 0x04, 0x52, 0x6f, 0x77, 0x73, 0x18, 0x0d, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x04, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x43, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x43, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x54, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x54, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0f, 0x54, 0x65, 0x78, 0x74, 0x43, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x0d, 0x4d, 0x69, 0x6e,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x02, 0x52, 0x0d, 0x4d, 0x69, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x29, 0x0a, 0x0d, 0x4d, 0x61, 0x78, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52, 0x0d, 0x4d,
+	0x61, 0x78, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12,
+	0x42, 0x0a, 0x0f, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x52, 0x0f, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x44, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x44,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0f, 0x44, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x4d, 0x69, 0x6e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x4d, 0x61, 0x78, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x4d, 0x69, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x4d, 0x61, 0x78, 0x53, 0x65, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x17, 0x44, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x07, 0x4d, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x07, 0x4d, 0x69, 0x6e, 0x44, 0x61, 0x74,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x4d, 0x61, 0x78, 0x44, 0x61, 0x74, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x07, 0x4d, 0x61, 0x78, 0x44, 0x61, 0x74, 0x65,
+	0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x4d, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x42,
+	0x0a, 0x0a, 0x08, 0x5f, 0x4d, 0x61, 0x78, 0x44, 0x61, 0x74, 0x65, 0x22, 0x57, 0x0a, 0x17, 0x46,
+	0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x41, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x61,
+	0x78, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x4d, 0x61, 0x78,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0xad, 0x01, 0x0a, 0x17, 0x54, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e,
+	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x21, 0x0a, 0x09, 0x4d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x09, 0x4d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09, 0x4d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x09, 0x4d, 0x61, 0x78, 0x4c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x4d, 0x69, 0x6e,
+	0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x4d, 0x61, 0x78, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x22, 0x60, 0x0a, 0x0e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x22, 0x4f, 0x0a, 0x0d, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x55,
+	0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x72, 0x6c, 0x12, 0x18, 0x0a,
+	0x07, 0x43, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x43, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xc0, 0x01, 0x0a, 0x18, 0x43, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x10, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x21, 0x0a, 0x0d, 0x41, 0x50,
+	0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x4b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x22, 0x68, 0x0a,
+	0x0e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x6f, 0x6c,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22, 0x65, 0x0a, 0x11, 0x49, 0x6e, 0x76, 0x69, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1e,
+	0x0a, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x14,
+	0x0a, 0x12, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0x94, 0x03, 0x0a, 0x06, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x12,
+	0x2c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x12, 0x0e, 0x2e, 0x53,
+	0x75, 0x72, 0x76, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x53,
+	0x75, 0x72, 0x76, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79,
+	0x73, 0x12, 0x15, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65,
+	0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x0a, 0x47, 0x65,
+	0x74, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x73, 0x12, 0x0f, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65,
+	0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x53, 0x75, 0x72, 0x76,
+	0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x12, 0x18, 0x2e,
+	0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2f, 0x0a, 0x0c, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x50, 0x49, 0x4b,
+	0x65, 0x79, 0x12, 0x0e, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0d, 0x55, 0x73, 0x65, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a,
+	0x11, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_survey_proto_rawDescOnce sync.Once
+	file_survey_proto_rawDescData = file_survey_proto_rawDesc
+)
+
+func file_survey_proto_rawDescGZIP() []byte {
+	file_survey_proto_rawDescOnce.Do(func() {
+		file_survey_proto_rawDescData = protoimpl.X.CompressGZIP(file_survey_proto_rawDescData)
+	})
+	return file_survey_proto_rawDescData
+}
+
+var file_survey_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_survey_proto_goTypes = []interface{}{
+	(*SurveyRequest)(nil),            // 0: SurveyRequest
+	(*ActiveSurveysRequest)(nil),     // 1: ActiveSurveysRequest
+	(*SurveysRequest)(nil),           // 2: SurveysRequest
+	(*SurveyValidationRequest)(nil),  // 3: SurveyValidationRequest
+	(*SurveyValidationResponse)(nil), // 4: SurveyValidationResponse
+	(*SurveyResponse)(nil),           // 5: SurveyResponse
+	(*SurveysResponse)(nil),          // 6: SurveysResponse
+	(*QuestionResponse)(nil),         // 7: QuestionResponse
+	(*DateConstraintsResponse)(nil),  // 8: DateConstraintsResponse
+	(*FileConstraintsResponse)(nil),  // 9: FileConstraintsResponse
+	(*TextConstraintsResponse)(nil),  // 10: TextConstraintsResponse
+	(*OptionResponse)(nil),           // 11: OptionResponse
+	(*MediaResponse)(nil),            // 12: MediaResponse
+	(*ConditionalLogicResponse)(nil), // 13: ConditionalLogicResponse
+	(*APIKeyRequest)(nil),            // 14: APIKeyRequest
+	(*APIKeyResponse)(nil),           // 15: APIKeyResponse
+	(*InvitationRequest)(nil),        // 16: InvitationRequest
+	(*InvitationResponse)(nil),       // 17: InvitationResponse
+}
+var file_survey_proto_depIdxs = []int32{
+	7,  // 0: SurveyResponse.Questions:type_name -> QuestionResponse
+	5,  // 1: SurveysResponse.Surveys:type_name -> SurveyResponse
+	11, // 2: QuestionResponse.Options:type_name -> OptionResponse
+	12, // 3: QuestionResponse.Media:type_name -> MediaResponse
+	13, // 4: QuestionResponse.ConditionalLogic:type_name -> ConditionalLogicResponse
+	11, // 5: QuestionResponse.Rows:type_name -> OptionResponse
+	11, // 6: QuestionResponse.Columns:type_name -> OptionResponse
+	10, // 7: QuestionResponse.TextConstraints:type_name -> TextConstraintsResponse
+	9,  // 8: QuestionResponse.FileConstraints:type_name -> FileConstraintsResponse
+	8,  // 9: QuestionResponse.DateConstraints:type_name -> DateConstraintsResponse
+	0,  // 10: Survey.GetSurvey:input_type -> SurveyRequest
+	1,  // 11: Survey.GetActiveSurveys:input_type -> ActiveSurveysRequest
+	2,  // 12: Survey.GetSurveys:input_type -> SurveysRequest
+	3,  // 13: Survey.ValidateSurvey:input_type -> SurveyValidationRequest
+	14, // 14: Survey.VerifyAPIKey:input_type -> APIKeyRequest
+	16, // 15: Survey.UseInvitation:input_type -> InvitationRequest
+	16, // 16: Survey.ReleaseInvitation:input_type -> InvitationRequest
+	5,  // 17: Survey.GetSurvey:output_type -> SurveyResponse
+	6,  // 18: Survey.GetActiveSurveys:output_type -> SurveysResponse
+	6,  // 19: Survey.GetSurveys:output_type -> SurveysResponse
+	4,  // 20: Survey.ValidateSurvey:output_type -> SurveyValidationResponse
+	15, // 21: Survey.VerifyAPIKey:output_type -> APIKeyResponse
+	17, // 22: Survey.UseInvitation:output_type -> InvitationResponse
+	17, // 23: Survey.ReleaseInvitation:output_type -> InvitationResponse
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_survey_proto_init() }
 func file_survey_proto_init() {
 	if File_survey_proto != nil {
 		return
@@ -283,7 +1557,55 @@ func file_survey_proto_init() {
 				return nil
 			}
 		}
-		file_survey_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_survey_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActiveSurveysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SurveysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SurveyValidationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SurveyValidationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SurveyResponse); i {
 			case 0:
 				return &v.state
@@ -295,7 +1617,19 @@ func file_survey_proto_init() {
 				return nil
 			}
 		}
-		file_survey_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_survey_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SurveysResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*QuestionResponse); i {
 			case 0:
 				return &v.state
@@ -307,14 +1641,137 @@ func file_survey_proto_init() {
 				return nil
 			}
 		}
+		file_survey_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DateConstraintsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileConstraintsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TextConstraintsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OptionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MediaResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConditionalLogicResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*APIKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*APIKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvitationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_survey_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvitationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_survey_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_survey_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	file_survey_proto_msgTypes[10].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_survey_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -340,8 +1797,13 @@ const _ = grpc.SupportPackageIsVersion6
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type SurveyClient interface {
-	// GetSurvey returns the requested survey
 	GetSurvey(ctx context.Context, in *SurveyRequest, opts ...grpc.CallOption) (*SurveyResponse, error)
+	GetActiveSurveys(ctx context.Context, in *ActiveSurveysRequest, opts ...grpc.CallOption) (*SurveysResponse, error)
+	GetSurveys(ctx context.Context, in *SurveysRequest, opts ...grpc.CallOption) (*SurveysResponse, error)
+	ValidateSurvey(ctx context.Context, in *SurveyValidationRequest, opts ...grpc.CallOption) (*SurveyValidationResponse, error)
+	VerifyAPIKey(ctx context.Context, in *APIKeyRequest, opts ...grpc.CallOption) (*APIKeyResponse, error)
+	UseInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*InvitationResponse, error)
+	ReleaseInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*InvitationResponse, error)
 }
 
 type surveyClient struct {
@@ -361,10 +1823,69 @@ func (c *surveyClient) GetSurvey(ctx context.Context, in *SurveyRequest, opts ..
 	return out, nil
 }
 
+func (c *surveyClient) GetActiveSurveys(ctx context.Context, in *ActiveSurveysRequest, opts ...grpc.CallOption) (*SurveysResponse, error) {
+	out := new(SurveysResponse)
+	err := c.cc.Invoke(ctx, "/Survey/GetActiveSurveys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *surveyClient) GetSurveys(ctx context.Context, in *SurveysRequest, opts ...grpc.CallOption) (*SurveysResponse, error) {
+	out := new(SurveysResponse)
+	err := c.cc.Invoke(ctx, "/Survey/GetSurveys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *surveyClient) ValidateSurvey(ctx context.Context, in *SurveyValidationRequest, opts ...grpc.CallOption) (*SurveyValidationResponse, error) {
+	out := new(SurveyValidationResponse)
+	err := c.cc.Invoke(ctx, "/Survey/ValidateSurvey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *surveyClient) VerifyAPIKey(ctx context.Context, in *APIKeyRequest, opts ...grpc.CallOption) (*APIKeyResponse, error) {
+	out := new(APIKeyResponse)
+	err := c.cc.Invoke(ctx, "/Survey/VerifyAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *surveyClient) UseInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*InvitationResponse, error) {
+	out := new(InvitationResponse)
+	err := c.cc.Invoke(ctx, "/Survey/UseInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *surveyClient) ReleaseInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*InvitationResponse, error) {
+	out := new(InvitationResponse)
+	err := c.cc.Invoke(ctx, "/Survey/ReleaseInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SurveyServer is the server API for Survey service.
 type SurveyServer interface {
-	// GetSurvey returns the requested survey
 	GetSurvey(context.Context, *SurveyRequest) (*SurveyResponse, error)
+	GetActiveSurveys(context.Context, *ActiveSurveysRequest) (*SurveysResponse, error)
+	GetSurveys(context.Context, *SurveysRequest) (*SurveysResponse, error)
+	ValidateSurvey(context.Context, *SurveyValidationRequest) (*SurveyValidationResponse, error)
+	VerifyAPIKey(context.Context, *APIKeyRequest) (*APIKeyResponse, error)
+	UseInvitation(context.Context, *InvitationRequest) (*InvitationResponse, error)
+	ReleaseInvitation(context.Context, *InvitationRequest) (*InvitationResponse, error)
 }
 
 // UnimplementedSurveyServer can be embedded to have forward compatible implementations.
@@ -374,6 +1895,24 @@ type UnimplementedSurveyServer struct {
 func (*UnimplementedSurveyServer) GetSurvey(context.Context, *SurveyRequest) (*SurveyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSurvey not implemented")
 }
+func (*UnimplementedSurveyServer) GetActiveSurveys(context.Context, *ActiveSurveysRequest) (*SurveysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveSurveys not implemented")
+}
+func (*UnimplementedSurveyServer) GetSurveys(context.Context, *SurveysRequest) (*SurveysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSurveys not implemented")
+}
+func (*UnimplementedSurveyServer) ValidateSurvey(context.Context, *SurveyValidationRequest) (*SurveyValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateSurvey not implemented")
+}
+func (*UnimplementedSurveyServer) VerifyAPIKey(context.Context, *APIKeyRequest) (*APIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAPIKey not implemented")
+}
+func (*UnimplementedSurveyServer) UseInvitation(context.Context, *InvitationRequest) (*InvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseInvitation not implemented")
+}
+func (*UnimplementedSurveyServer) ReleaseInvitation(context.Context, *InvitationRequest) (*InvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseInvitation not implemented")
+}
 
 func RegisterSurveyServer(s *grpc.Server, srv SurveyServer) {
 	s.RegisterService(&_Survey_serviceDesc, srv)
@@ -397,6 +1936,114 @@ func _Survey_GetSurvey_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Survey_GetActiveSurveys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActiveSurveysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SurveyServer).GetActiveSurveys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Survey/GetActiveSurveys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SurveyServer).GetActiveSurveys(ctx, req.(*ActiveSurveysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Survey_GetSurveys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SurveysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SurveyServer).GetSurveys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Survey/GetSurveys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SurveyServer).GetSurveys(ctx, req.(*SurveysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Survey_ValidateSurvey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SurveyValidationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SurveyServer).ValidateSurvey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Survey/ValidateSurvey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SurveyServer).ValidateSurvey(ctx, req.(*SurveyValidationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Survey_VerifyAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SurveyServer).VerifyAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Survey/VerifyAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SurveyServer).VerifyAPIKey(ctx, req.(*APIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Survey_UseInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SurveyServer).UseInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Survey/UseInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SurveyServer).UseInvitation(ctx, req.(*InvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Survey_ReleaseInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SurveyServer).ReleaseInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Survey/ReleaseInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SurveyServer).ReleaseInvitation(ctx, req.(*InvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Survey_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "Survey",
 	HandlerType: (*SurveyServer)(nil),
@@ -405,6 +2052,30 @@ var _Survey_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSurvey",
 			Handler:    _Survey_GetSurvey_Handler,
 		},
+		{
+			MethodName: "GetActiveSurveys",
+			Handler:    _Survey_GetActiveSurveys_Handler,
+		},
+		{
+			MethodName: "GetSurveys",
+			Handler:    _Survey_GetSurveys_Handler,
+		},
+		{
+			MethodName: "ValidateSurvey",
+			Handler:    _Survey_ValidateSurvey_Handler,
+		},
+		{
+			MethodName: "VerifyAPIKey",
+			Handler:    _Survey_VerifyAPIKey_Handler,
+		},
+		{
+			MethodName: "UseInvitation",
+			Handler:    _Survey_UseInvitation_Handler,
+		},
+		{
+			MethodName: "ReleaseInvitation",
+			Handler:    _Survey_ReleaseInvitation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "survey.proto",
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientResolver resolves the IP address of the client of a request
+// Behind proxies the address is read from a forwarded-for header, which is only trusted on requests coming from a trusted proxy,
+// so clients cannot pick their own address by sending the header themselves
+type ClientResolver struct {
+	header  string       // Header carrying the client address, such as X-Forwarded-For, not read when empty
+	proxies []*net.IPNet // Networks of the trusted proxies
+}
+
+// NewClientResolver creates a new client resolver
+// Proxies are given as IP addresses or CIDR ranges, requests are resolved to their remote address when the header is empty
+func NewClientResolver(header string, proxies []string) (*ClientResolver, error) {
+	c := &ClientResolver{header: http.CanonicalHeaderKey(header)}
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", p, err)
+		}
+		c.proxies = append(c.proxies, n)
+	}
+	return c, nil
+}
+
+// ClientIP returns the IP address of the client of a request
+// Addresses in the header are read from the right, skipping trusted proxies, since only the proxies append to the header
+func (c *ClientResolver) ClientIP(r *http.Request) string {
+	remote := remoteIP(r)
+	if c == nil || c.header == "" || !c.trusted(remote) {
+		return remote
+	}
+
+	var hops []string
+	for _, v := range r.Header.Values(c.header) {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		client = hop
+		if !c.trusted(hop) {
+			break
+		}
+	}
+	return client
+}
+
+// trusted checks if an address belongs to a trusted proxy
+func (c *ClientResolver) trusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range c.proxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP returns the IP address of the peer of a request
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,46 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClientResolver tests that forwarded addresses are only read from trusted proxies
+func TestClientResolver(t *testing.T) {
+	c, err := NewClientResolver("X-Forwarded-For", []string{"10.0.0.0/8", "192.168.1.1"})
+	assert.NoError(t, err, "Expected no error on creating the resolver")
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		want      string
+	}{
+		{"direct request", "203.0.113.1", nil, "203.0.113.1"},
+		{"header from untrusted peer", "203.0.113.1", []string{"198.51.100.1"}, "203.0.113.1"},
+		{"header from trusted proxy", "10.0.0.1", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"spoofed hops before the client", "10.0.0.1", []string{"1.2.3.4, 198.51.100.1"}, "198.51.100.1"},
+		{"chain of trusted proxies", "10.0.0.1", []string{"198.51.100.1, 192.168.1.1", "10.0.0.2"}, "198.51.100.1"},
+		{"only trusted proxies", "10.0.0.1", []string{"10.0.0.2"}, "10.0.0.2"},
+		{"invalid hop", "10.0.0.1", []string{"198.51.100.1, unknown"}, "10.0.0.1"},
+		{"missing header", "10.0.0.1", nil, "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newClientRequest(tt.remote, nil)
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			assert.Equal(t, tt.want, c.ClientIP(r), "Expected the client address")
+		})
+	}
+
+	direct, _ := NewClientResolver("", []string{"10.0.0.0/8"})
+	r := newClientRequest("10.0.0.1", nil)
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+	assert.Equal(t, "10.0.0.1", direct.ClientIP(r), "Expected the header to be ignored when not configured")
+
+	_, err = NewClientResolver("X-Forwarded-For", []string{"proxy"})
+	assert.Error(t, err, "Expected invalid proxies to be rejected")
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+)
+
+// clientIPKey is the context key of the resolved client address
+type clientIPKey struct{}
+
+// ResolveClient adds the IP address of the client of each request to the request context
+// This middleware must run before the rate limits and handlers reading the client address
+func ResolveClient(c *ClientResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), clientIPKey{}, c.ClientIP(r))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ClientIP returns the IP address of the client of a request
+// Requests not passed through ResolveClient are resolved to their remote address
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return remoteIP(r)
+}
+
+// ClientKey identifies the client of a request by API key, user or IP address
+// Unauthenticated requests are limited per IP address, whatever credentials they carry
+func ClientKey(r *http.Request) string {
+	if p, ok := auth.FromContext(r.Context()); ok {
+		if p.IsAPIKey() {
+			return "key:" + p.KeyID
+		}
+		return "user:" + p.Subject
+	}
+	return "ip:" + ClientIP(r)
+}
+
+// Middleware throttles the requests of each client to a route group
+// This middleware must be used after authentication so authenticated clients are limited per user
+// Throttled requests get a Retry-After header and are answered by reject, requests are let through when the store fails,
+// so an unavailable shared store does not take the API down
+func Middleware(s Store, group string, l Limit, reject http.HandlerFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !l.Enabled() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ok, wait, err := s.Take(group+"|"+ClientKey(r), l)
+			if err == nil && !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				reject(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+// newClientRequest creates a request from an IP address, authenticated as the principal when not nil
+func newClientRequest(ip string, p *auth.Principal) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/results/1", nil)
+	r.RemoteAddr = ip + ":1234"
+	if p != nil {
+		r = r.WithContext(auth.NewContext(r.Context(), p))
+	}
+	return r
+}
+
+// TestClientKey tests that clients are identified by API key, user or IP address
+func TestClientKey(t *testing.T) {
+	assert.Equal(t, "ip:10.0.0.1", ClientKey(newClientRequest("10.0.0.1", nil)), "Expected anonymous requests to be keyed by IP")
+	assert.Equal(t, "user:user-1", ClientKey(newClientRequest("10.0.0.1", &auth.Principal{Subject: "user-1"})), "Expected users to be keyed by subject")
+	assert.Equal(t, "key:key-1", ClientKey(newClientRequest("10.0.0.1", &auth.Principal{Subject: "user-1", KeyID: "key-1"})), "Expected API keys to be keyed by key ID")
+}
+
+// TestMiddleware tests that throttled requests are rejected with a Retry-After header, per resolved client
+func TestMiddleware(t *testing.T) {
+	clients, err := NewClientResolver("X-Forwarded-For", []string{"10.0.0.0/8"})
+	assert.NoError(t, err, "Expected no error on creating the resolver")
+	limit := Middleware(NewMemoryStore(), "results", Limit{Rate: 1, Burst: 1}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	h := ResolveClient(clients)(limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	forwarded := func(client string) *httptest.ResponseRecorder {
+		r := newClientRequest("10.0.0.1", nil)
+		r.Header.Set("X-Forwarded-For", client)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, forwarded("203.0.113.1").Code, "Expected the first request of a client to pass")
+	w := forwarded("203.0.113.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code, "Expected requests beyond the burst to be throttled")
+	assert.Equal(t, "1", w.Header().Get("Retry-After"), "Expected to wait a second for the next token")
+	assert.Equal(t, http.StatusOK, forwarded("203.0.113.2").Code, "Expected clients behind the same proxy to have their own bucket")
+}
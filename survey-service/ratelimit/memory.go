@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is the interval between removals of full buckets
+const sweepInterval = time.Minute
+
+// bucket is the token bucket of a key
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time // Time at which the bucket is refilled completely
+}
+
+// memoryStore implements the Store interface in memory
+type memoryStore struct {
+	buckets   map[string]*bucket
+	nextSweep time.Time
+	mutex     sync.Mutex
+}
+
+// NewMemoryStore creates a new rate limit store that keeps buckets in memory
+// Buckets are local to the service instance and dropped once they are full again
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take takes a token from the bucket of a key
+func (s *memoryStore) Take(key string, limit Limit) (bool, time.Duration, error) {
+	if !limit.Enabled() {
+		return true, 0, nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	// Refill the bucket for the time elapsed since it was last used
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+		return false, wait, nil
+	}
+
+	b.tokens--
+	b.full = now.Add(time.Duration((float64(limit.Burst) - b.tokens) / limit.Rate * float64(time.Second)))
+	return true, 0, nil
+}
+
+// sweep removes the buckets that are full again
+// This method must be called with the mutex held
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for k, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, k)
+		}
+	}
+	s.nextSweep = now.Add(sweepInterval)
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMemoryStoreTake tests that a bucket allows its burst and then throttles
+func TestMemoryStoreTake(t *testing.T) {
+	s := NewMemoryStore()
+	limit := Limit{Rate: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		ok, _, err := s.Take("client", limit)
+		assert.NoError(t, err, "Expected no error on take")
+		assert.True(t, ok, "Expected requests within the burst to be allowed")
+	}
+
+	ok, wait, _ := s.Take("client", limit)
+	assert.False(t, ok, "Expected requests beyond the burst to be throttled")
+	assert.InDelta(t, time.Second, wait, float64(100*time.Millisecond), "Expected to wait for the next token")
+
+	ok, _, _ = s.Take("other", limit)
+	assert.True(t, ok, "Expected other clients to have their own bucket")
+}
+
+// TestMemoryStoreRefill tests that buckets refill over time and are swept once full
+func TestMemoryStoreRefill(t *testing.T) {
+	s := NewMemoryStore().(*memoryStore)
+	limit := Limit{Rate: 1, Burst: 2}
+
+	s.Take("client", limit)
+	s.Take("client", limit)
+	ok, _, _ := s.Take("client", limit)
+	assert.False(t, ok, "Expected the empty bucket to throttle")
+
+	// A second later the bucket holds one token again
+	s.buckets["client"].updated = s.buckets["client"].updated.Add(-time.Second)
+	ok, _, _ = s.Take("client", limit)
+	assert.True(t, ok, "Expected the refilled token to be taken")
+	ok, _, _ = s.Take("client", limit)
+	assert.False(t, ok, "Expected a single token to be refilled")
+
+	// Full buckets are dropped at the next sweep
+	s.buckets["client"].full = time.Now().Add(-time.Second)
+	s.nextSweep = time.Time{}
+	s.Take("other", limit)
+	assert.NotContains(t, s.buckets, "client", "Expected full buckets to be swept")
+	assert.Contains(t, s.buckets, "other", "Expected buckets in use to be kept")
+}
+
+// TestMemoryStoreDisabled tests that a disabled limit never throttles
+func TestMemoryStoreDisabled(t *testing.T) {
+	s := NewMemoryStore()
+	for i := 0; i < 100; i++ {
+		ok, _, _ := s.Take("client", Limit{})
+		assert.True(t, ok, "Expected a disabled limit to allow every request")
+	}
+}
+
+// TestNewStore tests creating stores from the configuration
+func TestNewStore(t *testing.T) {
+	s, err := NewStore("memory")
+	assert.NoError(t, err, "Expected no error for the memory store")
+	assert.NotNil(t, s, "Expected a memory store")
+
+	_, err = NewStore("unknown")
+	assert.ErrorIs(t, err, ErrUnknownStore, "Expected an error for unknown stores")
+}
@@ -0,0 +1,40 @@
+package ratelimit
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrUnknownStore indicates that the configured rate limit store is not supported
+var ErrUnknownStore = errors.New("Unknown rate limit store")
+
+// Limit describes a token bucket
+// Buckets hold up to Burst tokens and are refilled with Rate tokens per second
+type Limit struct {
+	Rate  float64 // Tokens added to the bucket per second, zero disables the limit
+	Burst int     // Maximum number of tokens in the bucket
+}
+
+// Enabled checks if the limit throttles requests
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Store contains functions to keep the token buckets of rate limited clients
+// This interface lets several service instances enforce a common limit through a shared store
+type Store interface {
+	// Take takes a token from the bucket of a key
+	// This method returns whether a token was available and otherwise the time until the next one is
+	Take(key string, limit Limit) (bool, time.Duration, error)
+}
+
+// NewStore creates a new rate limit store based on the configuration
+// This function returns the store selected by the configuration
+func NewStore(store string) (Store, error) {
+	switch store {
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, ErrUnknownStore
+	}
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// testEditor is an editor used to create and manage surveys in tests
+	testEditor = &survey.User{ID: "editor-1", Roles: []survey.Role{survey.RoleEditor}}
+
+	// testOtherEditor is an editor who owns none of the test surveys
+	testOtherEditor = &survey.User{ID: "editor-2", Roles: []survey.Role{survey.RoleEditor}}
+
+	// testViewer is a viewer who cannot change surveys
+	testViewer = &survey.User{ID: "viewer-1", Roles: []survey.Role{survey.RoleViewer}}
+
+	// testAdmin is an admin who can access every survey
+	testAdmin = &survey.User{ID: "admin-1", Roles: []survey.Role{survey.RoleAdmin}}
+)
+
+// TestServiceOwnership tests that surveys are owned by their creator and hidden from other users
+func TestServiceOwnership(t *testing.T) {
+	repo, _ := NewSurveyMemoryRepository()
+	service := survey.NewService(repo, NewMemoryEventPublisher())
+
+	s := &survey.Survey{
+		Name:    "Test Survey",
+		OwnerID: "someone-else",
+		Questions: []survey.Question{
+			{Text: "What is your name?", Type: survey.QuestionTypeText},
+		},
+	}
+	assert.ErrorIs(t, service.Insert(testViewer, s), survey.ErrForbidden, "Expected viewers not to create surveys")
+	assert.ErrorIs(t, service.Insert(nil, s), survey.ErrForbidden, "Expected anonymous users not to create surveys")
+	assert.NoError(t, service.Insert(testEditor, s), "Expected no error on insert")
+	assert.Equal(t, testEditor.ID, s.OwnerID, "Expected the creator to own the survey")
+
+	mine, _ := service.Load(testEditor)
+	assert.Len(t, mine, 1, "Expected the owner to list the survey")
+	others, _ := service.Load(testOtherEditor)
+	assert.Empty(t, others, "Expected other users not to list the survey")
+	all, _ := service.Load(testAdmin)
+	assert.Len(t, all, 1, "Expected admins to list every survey")
+	_, err := service.Load(nil)
+	assert.ErrorIs(t, err, survey.ErrForbidden, "Expected anonymous users not to list surveys")
+
+	assert.ErrorIs(t, service.DeactivateSurvey(testOtherEditor, s.ID), survey.ErrForbidden, "Expected other editors not to change the survey")
+	assert.ErrorIs(t, service.DeleteSurvey(testOtherEditor, s.ID), survey.ErrForbidden, "Expected other editors not to delete the survey")
+	assert.NoError(t, service.DeactivateSurvey(testAdmin, s.ID), "Expected admins to change every survey")
+}
+
+// TestServiceSharing tests that shared users can access a survey but not change its sharing
+func TestServiceSharing(t *testing.T) {
+	repo, _ := NewSurveyMemoryRepository()
+	service := survey.NewService(repo, NewMemoryEventPublisher())
+
+	s := &survey.Survey{
+		Name:       "Test Survey",
+		SharedWith: []string{testOtherEditor.ID, testViewer.ID},
+		Questions: []survey.Question{
+			{Text: "What is your name?", Type: survey.QuestionTypeText},
+		},
+	}
+	assert.NoError(t, service.Insert(testEditor, s), "Expected no error on insert")
+
+	shared, _ := service.Load(testOtherEditor)
+	assert.Len(t, shared, 1, "Expected shared editors to list the survey")
+	shared, _ = service.Load(testViewer)
+	assert.Len(t, shared, 1, "Expected shared viewers to list the survey")
+
+	assert.ErrorIs(t, service.DeactivateSurvey(testViewer, s.ID), survey.ErrForbidden, "Expected viewers not to change the survey")
+	assert.NoError(t, service.DeactivateSurvey(testOtherEditor, s.ID), "Expected shared editors to change the survey")
+
+	// Shared editors cannot take over or reshare the survey
+	update := &survey.Survey{
+		Name:       "Renamed",
+		OwnerID:    testOtherEditor.ID,
+		SharedWith: []string{"stranger"},
+		Questions:  s.Questions,
+	}
+	assert.NoError(t, service.Update(testOtherEditor, s.ID, update), "Expected shared editors to update the survey")
+
+	loaded, _ := service.LoadByID(testEditor, s.ID)
+	assert.Equal(t, "Renamed", loaded.Name, "Expected the update to be stored")
+	assert.Equal(t, testEditor.ID, loaded.OwnerID, "Expected the owner to be preserved")
+	assert.Equal(t, []string{testOtherEditor.ID, testViewer.ID}, loaded.SharedWith, "Expected the sharing to be preserved")
+}
+
+// TestMemoryRepositoryLoadAccessible tests loading the surveys owned by or shared with a user
+func TestMemoryRepositoryLoadAccessible(t *testing.T) {
+	repo, _ := NewSurveyMemoryRepository()
+	owned := &survey.Survey{ID: "1", OwnerID: "user-1"}
+	shared := &survey.Survey{ID: "2", OwnerID: "user-2", SharedWith: []string{"user-1"}}
+	other := &survey.Survey{ID: "3", OwnerID: "user-2"}
+	repo.Insert(owned)
+	repo.Insert(shared)
+	repo.Insert(other)
+
+	surveys, err := repo.LoadAccessible("user-1", false)
+	assert.NoError(t, err, "Expected no error on loading accessible surveys")
+	assert.ElementsMatch(t, survey.Surveys{owned, shared}, *surveys, "Expected owned and shared surveys only")
+
+	surveys, _ = repo.LoadAccessible("user-1", true)
+	assert.Empty(t, *surveys, "Expected no archived surveys")
+}
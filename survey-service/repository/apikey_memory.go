@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/apikey"
+)
+
+// memoryAPIKeyRepository implements the apikey.Repository interface using an in-memory store
+type memoryAPIKeyRepository struct {
+	keys   map[string]*apikey.Key
+	owners map[string]*apikey.Owner
+	mu     sync.RWMutex
+}
+
+// NewAPIKeyMemoryRepository creates a new in-memory API key repository
+func NewAPIKeyMemoryRepository() (apikey.Repository, error) {
+	return &memoryAPIKeyRepository{
+		keys:   make(map[string]*apikey.Key),
+		owners: make(map[string]*apikey.Owner),
+	}, nil
+}
+
+// Insert adds a new API key to the in-memory store
+func (r *memoryAPIKeyRepository) Insert(k *apikey.Key) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[k.ID] = k
+	return nil
+}
+
+// LoadByID retrieves an API key by its ID from the in-memory store
+func (r *memoryAPIKeyRepository) LoadByID(id string) (*apikey.Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if k, ok := r.keys[id]; ok {
+		return k, nil
+	}
+	return nil, apikey.ErrNotFound
+}
+
+// LoadByHash retrieves an API key by the hash of the key from the in-memory store
+func (r *memoryAPIKeyRepository) LoadByHash(hash string) (*apikey.Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, k := range r.keys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+	return nil, apikey.ErrNotFound
+}
+
+// LoadByOwner retrieves the API keys issued by a user from the in-memory store, newest first
+func (r *memoryAPIKeyRepository) LoadByOwner(ownerID string) ([]*apikey.Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]*apikey.Key, 0)
+	for _, k := range r.keys {
+		if k.OwnerID == ownerID {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt > keys[j].CreatedAt
+	})
+	return keys, nil
+}
+
+// Update updates an existing API key in the in-memory store
+func (r *memoryAPIKeyRepository) Update(k *apikey.Key) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[k.ID]; !ok {
+		return apikey.ErrNotFound
+	}
+	r.keys[k.ID] = k
+	return nil
+}
+
+// SaveOwner stores the roles of a key owner in the in-memory store
+func (r *memoryAPIKeyRepository) SaveOwner(o *apikey.Owner) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.owners[o.ID] = o
+	return nil
+}
+
+// LoadOwner retrieves the roles of a key owner from the in-memory store
+func (r *memoryAPIKeyRepository) LoadOwner(id string) (*apikey.Owner, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if o, ok := r.owners[id]; ok {
+		return o, nil
+	}
+	return nil, apikey.ErrNotFound
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/apikey"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoAPIKeyRepository implements the apikey.Repository interface using MongoDB
+type mongoAPIKeyRepository struct {
+	client *mongo.Client
+	config config.MongoConfig
+}
+
+// NewAPIKeyMongoRepository creates a new MongoDB API key repository
+func NewAPIKeyMongoRepository(cfg config.MongoConfig) (apikey.Repository, error) {
+	client, err := connectToMongo(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &mongoAPIKeyRepository{client: client, config: cfg}, nil
+}
+
+// contextWithTimeout creates a context with a timeout based on the repository configuration
+func (r *mongoAPIKeyRepository) contextWithTimeout() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), r.config.Timeout)
+}
+
+// getCollection returns the MongoDB collection for API keys
+func (r *mongoAPIKeyRepository) getCollection() *mongo.Collection {
+	return r.client.Database(r.config.DB).Collection("apikeys")
+}
+
+// getOwnerCollection returns the MongoDB collection for the roles of key owners
+func (r *mongoAPIKeyRepository) getOwnerCollection() *mongo.Collection {
+	return r.client.Database(r.config.DB).Collection("apikey_owners")
+}
+
+// Insert adds a new API key to the MongoDB collection
+func (r *mongoAPIKeyRepository) Insert(k *apikey.Key) error {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	_, err := r.getCollection().InsertOne(ctx, k)
+	return err
+}
+
+// LoadByID retrieves an API key by its ID from the MongoDB collection
+func (r *mongoAPIKeyRepository) LoadByID(id string) (*apikey.Key, error) {
+	return r.findOne(bson.M{"id": id})
+}
+
+// LoadByHash retrieves an API key by the hash of the key from the MongoDB collection
+func (r *mongoAPIKeyRepository) LoadByHash(hash string) (*apikey.Key, error) {
+	return r.findOne(bson.M{"hash": hash})
+}
+
+// LoadByOwner retrieves the API keys issued by a user from the MongoDB collection, newest first
+func (r *mongoAPIKeyRepository) LoadByOwner(ownerID string) ([]*apikey.Key, error) {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.getCollection().Find(ctx, bson.M{"ownerId": ownerID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := make([]*apikey.Key, 0)
+	err = cursor.All(ctx, &keys)
+	return keys, err
+}
+
+// Update updates an existing API key in the MongoDB collection
+func (r *mongoAPIKeyRepository) Update(k *apikey.Key) error {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	result, err := r.getCollection().UpdateOne(ctx, bson.M{"id": k.ID}, bson.M{"$set": k})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return apikey.ErrNotFound
+	}
+
+	return nil
+}
+
+// SaveOwner upserts the roles of a key owner in the MongoDB collection
+func (r *mongoAPIKeyRepository) SaveOwner(o *apikey.Owner) error {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.getOwnerCollection().ReplaceOne(ctx, bson.M{"id": o.ID}, o, opts)
+	return err
+}
+
+// LoadOwner retrieves the roles of a key owner from the MongoDB collection
+func (r *mongoAPIKeyRepository) LoadOwner(id string) (*apikey.Owner, error) {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	o := &apikey.Owner{}
+	err := r.getOwnerCollection().FindOne(ctx, bson.M{"id": id}).Decode(o)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apikey.ErrNotFound
+		}
+		return nil, err
+	}
+	return o, nil
+}
+
+// findOne retrieves the API key matching a filter
+func (r *mongoAPIKeyRepository) findOne(filter bson.M) (*apikey.Key, error) {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	k := &apikey.Key{}
+	err := r.getCollection().FindOne(ctx, filter).Decode(k)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apikey.ErrNotFound
+		}
+		return nil, err
+	}
+	return k, nil
+}
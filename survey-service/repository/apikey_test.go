@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/apikey"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAPIKeyMemoryRepository tests storing API keys and the roles of their owners
+func TestAPIKeyMemoryRepository(t *testing.T) {
+	repo, err := NewAPIKeyMemoryRepository()
+	assert.NoError(t, err, "Expected no error on creating memory repository")
+
+	older := &apikey.Key{ID: "1", Hash: "hash-1", OwnerID: "editor-1", CreatedAt: 100}
+	newer := &apikey.Key{ID: "2", Hash: "hash-2", OwnerID: "editor-1", CreatedAt: 200}
+	other := &apikey.Key{ID: "3", Hash: "hash-3", OwnerID: "editor-2", CreatedAt: 300}
+	for _, k := range []*apikey.Key{older, newer, other} {
+		assert.NoError(t, repo.Insert(k), "Expected no error on insert")
+	}
+
+	k, err := repo.LoadByHash("hash-2")
+	assert.NoError(t, err, "Expected no error on loading by hash")
+	assert.Equal(t, newer, k, "Expected the key with the hash")
+	_, err = repo.LoadByHash("hash-unknown")
+	assert.ErrorIs(t, err, apikey.ErrNotFound, "Expected unknown hashes to be reported")
+
+	keys, err := repo.LoadByOwner("editor-1")
+	assert.NoError(t, err, "Expected no error on loading by owner")
+	assert.Equal(t, []*apikey.Key{newer, older}, keys, "Expected the keys of the owner, newest first")
+
+	revoked := &apikey.Key{ID: "1", Hash: "hash-1", OwnerID: "editor-1", CreatedAt: 100, RevokedAt: 150}
+	assert.NoError(t, repo.Update(revoked), "Expected no error on update")
+	k, _ = repo.LoadByID("1")
+	assert.Equal(t, int64(150), k.RevokedAt, "Expected the update to be stored")
+	assert.ErrorIs(t, repo.Update(&apikey.Key{ID: "unknown"}), apikey.ErrNotFound, "Expected unknown keys not to be updated")
+
+	_, err = repo.LoadOwner("editor-1")
+	assert.ErrorIs(t, err, apikey.ErrNotFound, "Expected owners without recorded roles to be reported")
+	assert.NoError(t, repo.SaveOwner(&apikey.Owner{ID: "editor-1", Roles: []string{"editor"}}), "Expected no error on saving the owner")
+	o, err := repo.LoadOwner("editor-1")
+	assert.NoError(t, err, "Expected no error on loading the owner")
+	assert.Equal(t, []string{"editor"}, o.Roles, "Expected the recorded roles")
+}
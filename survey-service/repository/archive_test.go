@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMemoryRepositoryArchived tests that archived surveys are only loaded on request
+func TestMemoryRepositoryArchived(t *testing.T) {
+	repo, _ := NewSurveyMemoryRepository()
+	live := &survey.Survey{ID: "1", Name: "Live"}
+	archived := &survey.Survey{ID: "2", Name: "Archived", ArchivedAt: time.Now().UTC().Unix()}
+	repo.Insert(live)
+	repo.Insert(archived)
+
+	surveys, err := repo.Load()
+	assert.NoError(t, err, "Expected no error on loading surveys")
+	assert.Equal(t, survey.Surveys{live}, *surveys, "Expected only the live survey")
+
+	surveys, err = repo.LoadArchived()
+	assert.NoError(t, err, "Expected no error on loading archived surveys")
+	assert.Equal(t, survey.Surveys{archived}, *surveys, "Expected only the archived survey")
+}
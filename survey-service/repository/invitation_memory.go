@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/invitation"
+)
+
+// memoryInvitationRepository implements the invitation.Repository interface using an in-memory store
+type memoryInvitationRepository struct {
+	invitations []*invitation.Invitation
+	mu          sync.RWMutex
+}
+
+// NewInvitationMemoryRepository creates a new in-memory invitation repository
+func NewInvitationMemoryRepository() (invitation.Repository, error) {
+	return &memoryInvitationRepository{}, nil
+}
+
+// Insert adds new invitations to the in-memory store
+func (r *memoryInvitationRepository) Insert(invitations []*invitation.Invitation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.invitations = append(r.invitations, invitations...)
+	return nil
+}
+
+// LoadBySurvey retrieves the invitations of a survey from the in-memory store
+func (r *memoryInvitationRepository) LoadBySurvey(surveyID string) ([]*invitation.Invitation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invitations := make([]*invitation.Invitation, 0)
+	for _, i := range r.invitations {
+		if i.Survey == surveyID {
+			invitations = append(invitations, i)
+		}
+	}
+	return invitations, nil
+}
+
+// Exists checks if an invitation is in the in-memory store
+func (r *memoryInvitationRepository) Exists(surveyID, hash string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, i := range r.invitations {
+		if i.Survey == surveyID && i.Hash == hash {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Use marks a question as answered with an invitation in the in-memory store
+func (r *memoryInvitationRepository) Use(surveyID, hash string, question int, at int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, i := range r.invitations {
+		if i.Survey != surveyID || i.Hash != hash {
+			continue
+		}
+		for _, q := range i.Answered {
+			if q == question {
+				return invitation.ErrAlreadyUsed
+			}
+		}
+		i.Answered = append(i.Answered, question)
+		if i.RespondedAt == 0 {
+			i.RespondedAt = at
+		}
+		return nil
+	}
+	return invitation.ErrNotFound
+}
+
+// Release marks a question as not answered with an invitation in the in-memory store
+// Invitations without answered questions are no longer reported as responded to
+func (r *memoryInvitationRepository) Release(surveyID, hash string, question int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, i := range r.invitations {
+		if i.Survey != surveyID || i.Hash != hash {
+			continue
+		}
+		answered := make([]int, 0, len(i.Answered))
+		for _, q := range i.Answered {
+			if q != question {
+				answered = append(answered, q)
+			}
+		}
+		i.Answered = answered
+		if len(i.Answered) == 0 {
+			i.RespondedAt = 0
+		}
+		return nil
+	}
+	return invitation.ErrNotFound
+}
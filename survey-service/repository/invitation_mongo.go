@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/invitation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoInvitationRepository implements the invitation.Repository interface using MongoDB
+type mongoInvitationRepository struct {
+	client *mongo.Client
+	config config.MongoConfig
+}
+
+// NewInvitationMongoRepository creates a new MongoDB invitation repository
+func NewInvitationMongoRepository(cfg config.MongoConfig) (invitation.Repository, error) {
+	client, err := connectToMongo(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &mongoInvitationRepository{client: client, config: cfg}, nil
+}
+
+// contextWithTimeout creates a context with a timeout based on the repository configuration
+func (r *mongoInvitationRepository) contextWithTimeout() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), r.config.Timeout)
+}
+
+// getCollection returns the MongoDB collection for invitations
+func (r *mongoInvitationRepository) getCollection() *mongo.Collection {
+	return r.client.Database(r.config.DB).Collection("invitations")
+}
+
+// Insert adds new invitations to the MongoDB collection
+func (r *mongoInvitationRepository) Insert(invitations []*invitation.Invitation) error {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	docs := make([]interface{}, 0, len(invitations))
+	for _, i := range invitations {
+		docs = append(docs, i)
+	}
+	_, err := r.getCollection().InsertMany(ctx, docs)
+	return err
+}
+
+// LoadBySurvey retrieves the invitations of a survey from the MongoDB collection
+func (r *mongoInvitationRepository) LoadBySurvey(surveyID string) ([]*invitation.Invitation, error) {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := r.getCollection().Find(ctx, bson.M{"survey": surveyID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	invitations := make([]*invitation.Invitation, 0)
+	err = cursor.All(ctx, &invitations)
+	return invitations, err
+}
+
+// Exists checks if an invitation is in the MongoDB collection
+func (r *mongoInvitationRepository) Exists(surveyID, hash string) (bool, error) {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	n, err := r.getCollection().CountDocuments(ctx, bson.M{"survey": surveyID, "hash": hash}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Use marks a question as answered with an invitation in the MongoDB collection
+// The question is only added when missing, so concurrent answers with the same token cannot both succeed
+func (r *mongoInvitationRepository) Use(surveyID, hash string, question int, at int64) error {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	filter := bson.M{"survey": surveyID, "hash": hash, "answered": bson.M{"$ne": question}}
+	update := bson.M{
+		"$addToSet": bson.M{"answered": question},
+		"$min":      bson.M{"respondedAt": at},
+	}
+	result, err := r.getCollection().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	// Tell unknown invitations apart from questions already answered
+	n, err := r.getCollection().CountDocuments(ctx, bson.M{"survey": surveyID, "hash": hash})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return invitation.ErrNotFound
+	}
+	return invitation.ErrAlreadyUsed
+}
+
+// Release marks a question as not answered with an invitation in the MongoDB collection
+// Invitations left without answered questions are no longer reported as responded to
+func (r *mongoInvitationRepository) Release(surveyID, hash string, question int) error {
+	ctx, cancel := r.contextWithTimeout()
+	defer cancel()
+
+	filter := bson.M{"survey": surveyID, "hash": hash}
+	result, err := r.getCollection().UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"answered": question}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return invitation.ErrNotFound
+	}
+
+	// Only clear the response time while no question is answered, so concurrent answers keep it
+	filter["answered"] = bson.M{"$size": 0}
+	_, err = r.getCollection().UpdateOne(ctx, filter, bson.M{"$unset": bson.M{"respondedAt": ""}})
+	return err
+}
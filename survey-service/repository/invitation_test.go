@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/invitation"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInvitationMemoryRepository tests storing invitations and marking the questions answered with them
+func TestInvitationMemoryRepository(t *testing.T) {
+	repo, err := NewInvitationMemoryRepository()
+	assert.NoError(t, err, "Expected no error on creating memory repository")
+
+	assert.NoError(t, repo.Insert([]*invitation.Invitation{
+		{ID: "1", Survey: "survey-1", Email: "ann@example.com", Hash: "hash-ann"},
+		{ID: "2", Survey: "survey-1", Email: "bob@example.com", Hash: "hash-bob"},
+		{ID: "3", Survey: "survey-2", Email: "ann@example.com", Hash: "hash-other"},
+	}), "Expected no error on insert")
+
+	invitations, err := repo.LoadBySurvey("survey-1")
+	assert.NoError(t, err, "Expected no error on loading invitations")
+	assert.Len(t, invitations, 2, "Expected only the invitations of the survey")
+
+	ok, _ := repo.Exists("survey-1", "hash-ann")
+	assert.True(t, ok, "Expected the invitation to exist")
+	ok, _ = repo.Exists("survey-2", "hash-ann")
+	assert.False(t, ok, "Expected invitations to be bound to their survey")
+
+	assert.NoError(t, repo.Use("survey-1", "hash-ann", 1, 100), "Expected the question to be answered")
+	assert.NoError(t, repo.Use("survey-1", "hash-ann", 2, 200), "Expected other questions to be answered")
+	assert.ErrorIs(t, repo.Use("survey-1", "hash-ann", 1, 300), invitation.ErrAlreadyUsed, "Expected a question to be answered once")
+	assert.ErrorIs(t, repo.Use("survey-1", "hash-unknown", 1, 300), invitation.ErrNotFound, "Expected unknown invitations to be reported")
+	assert.Equal(t, int64(100), invitations[0].RespondedAt, "Expected the time of the first answer")
+
+	assert.NoError(t, repo.Release("survey-1", "hash-ann", 1), "Expected the question to be released")
+	assert.Equal(t, int64(100), invitations[0].RespondedAt, "Expected the invitation to stay responded to while questions are answered")
+	assert.NoError(t, repo.Release("survey-1", "hash-ann", 2), "Expected the question to be released")
+	assert.Zero(t, invitations[0].RespondedAt, "Expected the invitation not to be responded to without answered questions")
+	assert.ErrorIs(t, repo.Release("survey-1", "hash-unknown", 1), invitation.ErrNotFound, "Expected unknown invitations to be reported")
+}
@@ -40,15 +40,35 @@ func (r *memorySurveyRepository) LoadByID(id string) (*survey.Survey, error) {
 	return nil, survey.ErrNotFound
 }
 
-// Load retrieves all surveys from the in-memory store
+// Load retrieves all surveys that are not archived from the in-memory store
 func (r *memorySurveyRepository) Load() (*survey.Surveys, error) {
+	return r.load(false, nil)
+}
+
+// LoadArchived retrieves all archived surveys from the in-memory store
+func (r *memorySurveyRepository) LoadArchived() (*survey.Surveys, error) {
+	return r.load(true, nil)
+}
+
+// LoadAccessible retrieves the surveys owned by or shared with a user from the in-memory store
+func (r *memorySurveyRepository) LoadAccessible(userID string, archived bool) (*survey.Surveys, error) {
+	u := &survey.User{ID: userID}
+	return r.load(archived, func(s *survey.Survey) bool {
+		return s.IsOwner(u) || s.IsSharedWith(u)
+	})
+}
+
+// load retrieves the surveys matching the given archived state and optional filter
+func (r *memorySurveyRepository) load(archived bool, filter func(s *survey.Survey) bool) (*survey.Surveys, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	// Convert the map to a slice
 	surveys := make(survey.Surveys, 0, len(r.surveys))
 	for _, s := range r.surveys {
-		surveys = append(surveys, s)
+		if s.IsArchived() == archived && (filter == nil || filter(s)) {
+			surveys = append(surveys, s)
+		}
 	}
 
 	// Sort by creation time, newest first
@@ -8,7 +8,6 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // mongoSurveyRepository implements the survey.Repository interface using MongoDB
@@ -26,16 +25,7 @@ func NewSurveyMongoRepository(cfg config.MongoConfig) (survey.Repository, error)
 
 // connect establishes a connection to the MongoDB server
 func (r *mongoSurveyRepository) connect() error {
-	ctx, cancel := r.contextWithTimeout()
-	defer cancel()
-
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(r.config.URL))
-	if err != nil {
-		return err
-	}
-
-	// Verify connection with a ping
-	err = client.Ping(ctx, readpref.Primary())
+	client, err := connectToMongo(r.config)
 	if err != nil {
 		return err
 	}
@@ -79,14 +69,45 @@ func (r *mongoSurveyRepository) LoadByID(id string) (*survey.Survey, error) {
 	return s, nil
 }
 
-// Load retrieves all surveys from the MongoDB collection
+// Load retrieves all surveys that are not archived from the MongoDB collection
+// Surveys stored before archiving existed have no archivedAt field and are treated as live
 func (r *mongoSurveyRepository) Load() (*survey.Surveys, error) {
+	return r.find(bson.M{"archivedAt": bson.M{"$not": bson.M{"$gt": 0}}}, 25)
+}
+
+// LoadArchived retrieves all archived surveys from the MongoDB collection
+func (r *mongoSurveyRepository) LoadArchived() (*survey.Surveys, error) {
+	return r.find(bson.M{"archivedAt": bson.M{"$gt": 0}}, 0)
+}
+
+// LoadAccessible retrieves the surveys owned by or shared with a user from the MongoDB collection
+func (r *mongoSurveyRepository) LoadAccessible(userID string, archived bool) (*survey.Surveys, error) {
+	archivedFilter := bson.M{"$not": bson.M{"$gt": 0}}
+	if archived {
+		archivedFilter = bson.M{"$gt": 0}
+	}
+
+	return r.find(bson.M{
+		"archivedAt": archivedFilter,
+		"$or": bson.A{
+			bson.M{"ownerId": userID},
+			bson.M{"sharedWith": userID},
+		},
+	}, 0)
+}
+
+// find retrieves the surveys matching a filter, newest first
+// A limit of zero returns all matching surveys
+func (r *mongoSurveyRepository) find(filter bson.M, limit int64) (*survey.Surveys, error) {
 	ctx, cancel := r.contextWithTimeout()
 	defer cancel()
 
 	surveys := make(survey.Surveys, 0)
-	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(25)
-	cursor, err := r.getCollection().Find(ctx, bson.M{}, opts)
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+	cursor, err := r.getCollection().Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+)
+
+// MemoryEventPublisher implements the survey.EventPublisher interface by recording events in memory
+// It is meant for tests and for running the service without RabbitMQ
+type MemoryEventPublisher struct {
+	events []survey.Event
+	mu     sync.RWMutex
+}
+
+// NewMemoryEventPublisher creates a new in-memory survey event publisher
+func NewMemoryEventPublisher() *MemoryEventPublisher {
+	return &MemoryEventPublisher{}
+}
+
+// Publish records a survey event
+func (p *MemoryEventPublisher) Publish(e *survey.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, *e)
+}
+
+// Events returns the recorded survey events in publishing order
+func (p *MemoryEventPublisher) Events() []survey.Event {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	events := make([]survey.Event, len(p.events))
+	copy(events, p.events)
+	return events
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMemoryEventPublisher tests that the memory publisher records events in order
+func TestMemoryEventPublisher(t *testing.T) {
+	p := NewMemoryEventPublisher()
+	p.Publish(&survey.Event{Type: survey.EventCreated, Survey: "1"})
+	p.Publish(&survey.Event{Type: survey.EventDeleted, Survey: "1"})
+
+	events := p.Events()
+	assert.Len(t, events, 2, "Expected two recorded events")
+	assert.Equal(t, survey.EventCreated, events[0].Type, "Expected created event first")
+	assert.Equal(t, survey.EventDeleted, events[1].Type, "Expected deleted event second")
+}
+
+// TestNewEventPublisher tests the selection of the event publisher from configuration
+func TestNewEventPublisher(t *testing.T) {
+	log := zerolog.Nop()
+
+	p, err := NewEventPublisher(config.Config{Publisher: "memory"}, &log)
+	assert.NoError(t, err, "Expected no error for the memory publisher")
+	assert.IsType(t, &MemoryEventPublisher{}, p, "Expected a memory publisher")
+
+	_, err = NewEventPublisher(config.Config{Publisher: "unknown"}, &log)
+	assert.Error(t, err, "Expected an error for an unknown publisher")
+}
+
+// TestServicePublishesEvents tests that survey mutations publish change events
+func TestServicePublishesEvents(t *testing.T) {
+	repo, _ := NewSurveyMemoryRepository()
+	p := NewMemoryEventPublisher()
+	service := survey.NewService(repo, p)
+
+	s := &survey.Survey{
+		Name: "Test Survey",
+		Questions: []survey.Question{
+			{Text: "What is your name?", Type: survey.QuestionTypeText},
+		},
+	}
+	assert.NoError(t, service.Insert(testEditor, s), "Expected no error on insert")
+	assert.NoError(t, service.DeactivateSurvey(testEditor, s.ID), "Expected no error on deactivate")
+	assert.NoError(t, service.ActivateSurvey(testEditor, s.ID), "Expected no error on activate")
+	assert.NoError(t, service.DeleteSurvey(testEditor, s.ID), "Expected no error on delete")
+
+	// Failed mutations must not publish anything
+	assert.ErrorIs(t, service.DeleteSurvey(testEditor, s.ID), survey.ErrNotFound, "Expected not found on second delete")
+
+	var types []survey.EventType
+	for _, e := range p.Events() {
+		assert.Equal(t, s.ID, e.Survey, "Expected events for the inserted survey")
+		assert.NotZero(t, e.Timestamp, "Expected event timestamp to be set")
+		types = append(types, e.Type)
+	}
+	assert.Equal(t, []survey.EventType{
+		survey.EventCreated,
+		survey.EventDeactivated,
+		survey.EventActivated,
+		survey.EventDeleted,
+	}, types, "Expected one event per successful mutation")
+}
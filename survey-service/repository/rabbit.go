@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/rs/zerolog"
+	"github.com/streadway/amqp"
+)
+
+// rabbitEventPublisher implements the survey.EventPublisher interface using a RabbitMQ topic exchange
+// Events are queued in memory and published in the background so requests never wait on RabbitMQ
+type rabbitEventPublisher struct {
+	config     config.RabbitConfig
+	serializer survey.Serializer
+	log        *zerolog.Logger
+	events     chan *survey.Event
+	conn       *amqp.Connection
+	channel    *amqp.Channel
+}
+
+// NewRabbitEventPublisher creates a new RabbitMQ survey event publisher
+// This function connects to RabbitMQ, declares the survey exchange and starts publishing queued events
+func NewRabbitEventPublisher(cfg config.RabbitConfig, sz survey.Serializer, log *zerolog.Logger) (survey.EventPublisher, error) {
+	p := &rabbitEventPublisher{
+		config:     cfg,
+		serializer: sz,
+		log:        log,
+		events:     make(chan *survey.Event, cfg.Buffer),
+	}
+
+	if err := p.connect(); err != nil {
+		return nil, err
+	}
+
+	go p.run()
+	return p, nil
+}
+
+// Publish queues a survey event to be published
+// Events are dropped with an error log when the queue is full
+func (p *rabbitEventPublisher) Publish(e *survey.Event) {
+	select {
+	case p.events <- e:
+	default:
+		p.log.Error().Str("id", e.Survey).Str("type", string(e.Type)).Msg("Survey event queue full, event dropped")
+	}
+}
+
+// run publishes queued events, reconnecting once when publishing fails
+func (p *rabbitEventPublisher) run() {
+	for e := range p.events {
+		body, err := p.serializer.EncodeEvent(e)
+		if err != nil {
+			p.log.Error().Err(err).Str("id", e.Survey).Msg("Unable to encode survey event")
+			continue
+		}
+
+		err = p.publish(e, body)
+		if err != nil {
+			p.close()
+			if err = p.connect(); err == nil {
+				err = p.publish(e, body)
+			}
+		}
+		if err != nil {
+			p.log.Error().Err(err).Str("id", e.Survey).Str("type", string(e.Type)).Msg("Unable to publish survey event")
+			continue
+		}
+
+		p.log.Debug().Str("id", e.Survey).Str("type", string(e.Type)).Msg("Survey event published")
+	}
+}
+
+// connect establishes a connection and channel to RabbitMQ and declares the survey exchange
+func (p *rabbitEventPublisher) connect() error {
+	addr := fmt.Sprintf("amqp://%s:%s@%s:%d/", p.config.Username, p.config.Password, p.config.Hostname, p.config.Port)
+	conn, err := amqp.Dial(addr)
+	if err != nil {
+		return err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	err = ch.ExchangeDeclare(
+		p.config.Exchange,
+		amqp.ExchangeTopic,
+		true,  // Durable
+		false, // Auto-deleted
+		false, // Internal
+		false, // No-wait
+		nil,
+	)
+	if err != nil {
+		ch.Close()
+		conn.Close()
+		return err
+	}
+
+	p.conn = conn
+	p.channel = ch
+	return nil
+}
+
+// close closes the current RabbitMQ channel and connection
+func (p *rabbitEventPublisher) close() {
+	if p.channel != nil {
+		p.channel.Close()
+	}
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.channel = nil
+	p.conn = nil
+}
+
+// publish publishes an encoded event using its type as the routing key
+func (p *rabbitEventPublisher) publish(e *survey.Event, body []byte) error {
+	if p.channel == nil {
+		return amqp.ErrClosed
+	}
+
+	return p.channel.Publish(
+		p.config.Exchange,
+		string(e.Type),
+		false,
+		false,
+		amqp.Publishing{
+			DeliveryMode: amqp.Persistent,
+			ContentType:  p.serializer.GetContentType(),
+			Body:         body,
+		},
+	)
+}
@@ -1,10 +1,18 @@
 package repository
 
 import (
+	"context"
 	"errors"
 
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/apikey"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/invitation"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/serializer"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/survey"
+	"github.com/rs/zerolog"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // NewSurveyRepository creates a new survey repository based on application configuration
@@ -22,3 +30,71 @@ func NewSurveyRepository(cfg config.Config) (survey.Repository, error) {
 		return nil, errors.New("no repository available")
 	}
 }
+
+// NewAPIKeyRepository creates a new API key repository based on application configuration
+// This function selects the same repository type as the survey repository
+func NewAPIKeyRepository(cfg config.Config) (apikey.Repository, error) {
+	switch cfg.Repository {
+	case "memory":
+		// Create a new in-memory repository
+		return NewAPIKeyMemoryRepository()
+	case "mongo":
+		// Create a new MongoDB repository
+		return NewAPIKeyMongoRepository(cfg.Mongo)
+	default:
+		// Return an error if no valid repository type is specified
+		return nil, errors.New("no repository available")
+	}
+}
+
+// NewInvitationRepository creates a new invitation repository based on application configuration
+// This function selects the same repository type as the survey repository
+func NewInvitationRepository(cfg config.Config) (invitation.Repository, error) {
+	switch cfg.Repository {
+	case "memory":
+		// Create a new in-memory repository
+		return NewInvitationMemoryRepository()
+	case "mongo":
+		// Create a new MongoDB repository
+		return NewInvitationMongoRepository(cfg.Mongo)
+	default:
+		// Return an error if no valid repository type is specified
+		return nil, errors.New("no repository available")
+	}
+}
+
+// NewEventPublisher creates a new survey event publisher based on application configuration
+// This function selects the appropriate publisher type based on the configuration
+func NewEventPublisher(cfg config.Config, log *zerolog.Logger) (survey.EventPublisher, error) {
+	switch cfg.Publisher {
+	case "memory":
+		// Record events in memory
+		return NewMemoryEventPublisher(), nil
+	case "rabbit":
+		// Publish events to RabbitMQ
+		return NewRabbitEventPublisher(cfg.Rabbit, serializer.NewSurveyJSONSerializer(), log)
+	default:
+		// Return an error if no valid publisher type is specified
+		return nil, errors.New("no event publisher available")
+	}
+}
+
+// connectToMongo establishes a connection to the MongoDB server
+// This function verifies the connection with a ping before returning the client
+func connectToMongo(cfg config.MongoConfig) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.URL))
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify connection with a ping
+	err = client.Ping(ctx, readpref.Primary())
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
@@ -0,0 +1,62 @@
+package respondent
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Token claims shared with the vote service, which verifies respondent tokens
+const (
+	Issuer   = "survey-service" // Issuer of respondent tokens
+	Audience = "respondent"     // Audience of respondent tokens
+)
+
+// TokenIssuer issues signed respondent tokens
+// A respondent token proves that a vote comes from a client that fetched the survey,
+// and its unique ID lets the vote service reject replayed submissions
+type TokenIssuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// MinSecretLength is the minimum length in bytes of the secret respondent tokens are signed with
+const MinSecretLength = 32
+
+// ErrShortSecret indicates that the respondent token secret is too short to keep tokens from being forged
+var ErrShortSecret = fmt.Errorf("Respondent token secret must be at least %d bytes", MinSecretLength)
+
+// NewTokenIssuer creates a new respondent token issuer
+// This function initializes an issuer that signs tokens with the configured shared secret
+func NewTokenIssuer(cfg config.RespondentConfig) (*TokenIssuer, error) {
+	if len(cfg.Secret) < MinSecretLength {
+		return nil, ErrShortSecret
+	}
+	return &TokenIssuer{
+		secret: []byte(cfg.Secret),
+		ttl:    cfg.TTL,
+	}, nil
+}
+
+// Issue issues a respondent token for a survey
+func (i *TokenIssuer) Issue(surveyID string) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ID:        hex.EncodeToString(nonce),
+		Issuer:    Issuer,
+		Audience:  jwt.ClaimStrings{Audience},
+		Subject:   surveyID,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(i.ttl)),
+	})
+	return token.SignedString(i.secret)
+}
@@ -0,0 +1,37 @@
+package respondent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/config"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTokenIssuerIssue tests that issued tokens are bound to the survey and unique
+func TestTokenIssuerIssue(t *testing.T) {
+	secret := "0123456789abcdef0123456789abcdef"
+	i, err := NewTokenIssuer(config.RespondentConfig{Secret: secret, TTL: time.Hour})
+	assert.NoError(t, err, "Expected no error for a long enough secret")
+
+	first, err := i.Issue("survey-1")
+	assert.NoError(t, err, "Expected no error on issue")
+	second, _ := i.Issue("survey-1")
+	assert.NotEqual(t, first, second, "Expected every token to be unique")
+
+	claims := &jwt.RegisteredClaims{}
+	_, err = jwt.NewParser(jwt.WithAudience(Audience), jwt.WithIssuer(Issuer)).ParseWithClaims(first, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	assert.NoError(t, err, "Expected the token to verify with the shared secret")
+	assert.Equal(t, "survey-1", claims.Subject, "Expected the token to be bound to the survey")
+	assert.NotEmpty(t, claims.ID, "Expected the token to have a unique ID")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, time.Minute, "Expected the token to expire after the TTL")
+}
+
+// TestNewTokenIssuerShortSecret tests that forgeable secrets are rejected
+func TestNewTokenIssuerShortSecret(t *testing.T) {
+	_, err := NewTokenIssuer(config.RespondentConfig{Secret: "change-me-too", TTL: time.Hour})
+	assert.ErrorIs(t, err, ErrShortSecret, "Expected a short secret to be rejected")
+}
@@ -1,50 +1,103 @@
 package router
 
 import (
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/config"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/handler"
 	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/middleware"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/ratelimit"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/respondent"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/cors"
 )
 
 // NewRouter creates a new router
 // This function initializes the router and sets up the routes and middleware
-func NewRouter(h handler.SurveyHTTPHandler) *chi.Mux {
+// Respondent tokens are issued with every fetched survey unless the issuer is nil
+// Requests of each client are throttled with token buckets kept in the rate limit store, clients are identified by the resolver
+// API keys are verified with the key verifier and limited to their scopes and to the roles the owner recorder last saw their owner with
+func NewRouter(h handler.SurveyHTTPHandler, kh *handler.APIKeyHTTPHandler, ih *handler.InvitationHTTPHandler, a *auth.Authenticator, v auth.KeyVerifier, o auth.OwnerRecorder, cfg config.AuthConfig, i *respondent.TokenIssuer, s ratelimit.Store, c *ratelimit.ClientResolver, rl config.RateLimitConfig) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Set up CORS middleware
 	// This middleware allows cross-origin requests from any origin
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"},                                                       // Allow all origins
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},                 // Allow all standard methods
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"}, // Allow specified headers
-		ExposedHeaders:   []string{"Link"},                                                    // Expose specified headers
-		AllowCredentials: true,                                                                // Allow credentials
-		MaxAge:           300,                                                                 // Max age for preflight requests
+		AllowedOrigins:   []string{"*"},                                                                                // Allow all origins
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},                                          // Allow all standard methods
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", middleware.APIKeyHeader, middleware.InvitationTokenHeader}, // Allow specified headers
+		ExposedHeaders:   []string{"Link", "Retry-After", middleware.RespondentTokenHeader},                            // Expose specified headers
+		AllowCredentials: true,                                                                                         // Allow credentials
+		MaxAge:           300,                                                                                          // Max age for preflight requests
 	}))
 
+	// Resolve the address of each client before it is throttled
+	r.Use(ratelimit.ResolveClient(c))
+
+	// Reads are public unless configured otherwise, listings of owned surveys and mutations always require authentication
+	// API keys need the scope of the endpoints they access
+	read := chi.Middlewares{middleware.RequireScope(auth.ScopeSurveysRead)}
+	if !cfg.PublicReads {
+		read = append(read, middleware.RequireAuth)
+	}
+	list := chi.Middlewares{middleware.RequireAuth, middleware.RequireScope(auth.ScopeSurveysRead)}
+	write := chi.Middlewares{middleware.RequireAuth, middleware.RequireScope(auth.ScopeSurveysWrite)}
+
+	// Requests of each client are throttled across the survey and API key routes
+	// The limiter runs before API keys are verified, so requests with unknown keys are throttled by IP address before they reach the key store
+	limit := middleware.RateLimit(s, "surveys", ratelimit.Limit{Rate: rl.SurveysRate, Burst: rl.SurveysBurst})
+
+	// Surveys fetched by respondents carry a token that must be sent with their votes
+	fetch := append(chi.Middlewares{}, read...)
+	if i != nil {
+		fetch = append(fetch, middleware.IssueRespondentToken(i))
+	}
+
 	// Survey routes
 	// This route group handles all survey-related endpoints
 	r.Route("/surveys", func(r chi.Router) {
-		r.Use(middleware.AddSerializer) // Add the serializer middleware
+		r.Use(middleware.AddSerializer)         // Add the serializer middleware
+		r.Use(middleware.Authenticate(a))       // Verify bearer tokens when present
+		r.Use(limit)                            // Throttle each client
+		r.Use(middleware.RecordKeyOwner(o))     // Record the roles of users for their API keys
+		r.Use(middleware.AuthenticateAPIKey(v)) // Verify API keys when present
 
 		// Base survey endpoints
-		r.Get("/", h.Collection)             // GET /surveys - retrieves all surveys
-		r.Get("/active", h.ActiveCollection) // GET /surveys/active - retrieves all active surveys
-		r.Post("/", h.Post)                  // POST /surveys - creates a new survey
+		r.With(list...).Get("/", h.Collection)                 // GET /surveys - retrieves the surveys of the user that are not archived
+		r.With(read...).Get("/active", h.ActiveCollection)     // GET /surveys/active - retrieves all active surveys
+		r.With(list...).Get("/archived", h.ArchivedCollection) // GET /surveys/archived - retrieves the archived surveys of the user
+		r.With(write...).Post("/", h.Post)                     // POST /surveys - creates a new survey
 
 		// Individual survey endpoints
 		r.Route("/{id}", func(r chi.Router) {
-			r.Get("/", h.Get)       // GET /surveys/{id} - retrieves a specific survey by ID
-			r.Put("/", h.Put)       // PUT /surveys/{id} - updates a specific survey
-			r.Delete("/", h.Delete) // DELETE /surveys/{id} - deletes a specific survey
+			r.With(fetch...).Get("/", h.Get)       // GET /surveys/{id} - retrieves a specific survey by ID
+			r.With(write...).Put("/", h.Put)       // PUT /surveys/{id} - updates a specific survey
+			r.With(write...).Delete("/", h.Delete) // DELETE /surveys/{id} - deletes a specific survey
 
 			// Survey management endpoints
-			r.Post("/activate", h.Activate)        // POST /surveys/{id}/activate - activates a survey
-			r.Post("/deactivate", h.Deactivate)    // POST /surveys/{id}/deactivate - deactivates a survey
-			r.Post("/expiration", h.SetExpiration) // POST /surveys/{id}/expiration - sets an expiration date
+			r.With(write...).Post("/activate", h.Activate)        // POST /surveys/{id}/activate - activates a survey
+			r.With(write...).Post("/deactivate", h.Deactivate)    // POST /surveys/{id}/deactivate - deactivates a survey
+			r.With(write...).Post("/expiration", h.SetExpiration) // POST /surveys/{id}/expiration - sets an expiration date
+			r.With(write...).Post("/archive", h.Archive)          // POST /surveys/{id}/archive - archives a survey
+			r.With(write...).Post("/restore", h.Restore)          // POST /surveys/{id}/restore - restores an archived survey
+
+			// Survey invitation endpoints
+			r.With(list...).Get("/invitations", ih.Report) // GET /surveys/{id}/invitations - reports which invitations have been responded to
+			r.With(write...).Post("/invitations", ih.Post) // POST /surveys/{id}/invitations - invites recipients from JSON or CSV
 		})
 	})
 
+	// API key routes
+	// This route group manages the API keys of the user, which requires a bearer token
+	r.Route("/apikeys", func(r chi.Router) {
+		r.Use(middleware.Authenticate(a))   // Verify bearer tokens when present
+		r.Use(middleware.RequireBearer)     // Reject anonymous requests and API keys
+		r.Use(limit)                        // Throttle each client
+		r.Use(middleware.RecordKeyOwner(o)) // Record the roles of users for their API keys
+
+		r.Get("/", kh.Collection)    // GET /apikeys - retrieves the API keys of the user
+		r.Post("/", kh.Post)         // POST /apikeys - issues a new API key
+		r.Delete("/{id}", kh.Delete) // DELETE /apikeys/{id} - revokes an API key
+	})
+
 	return r
 }
@@ -0,0 +1,50 @@
+package serializer
+
+import (
+	"encoding/json"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/apikey"
+)
+
+// apiKeyJSONSerializer is an implementation of the apikey.Serializer interface for JSON
+type apiKeyJSONSerializer struct{}
+
+// NewAPIKeyJSONSerializer creates a new API key JSON serializer
+// This function returns a new instance of apiKeyJSONSerializer
+func NewAPIKeyJSONSerializer() apikey.Serializer {
+	return &apiKeyJSONSerializer{}
+}
+
+// Encode encodes an API key into JSON format
+func (s *apiKeyJSONSerializer) Encode(k *apikey.Key) ([]byte, error) {
+	return json.Marshal(k)
+}
+
+// EncodeIssued encodes a newly issued API key into JSON format
+func (s *apiKeyJSONSerializer) EncodeIssued(k *apikey.IssuedKey) ([]byte, error) {
+	return json.Marshal(k)
+}
+
+// EncodeMultiple encodes multiple API keys into JSON format
+func (s *apiKeyJSONSerializer) EncodeMultiple(keys []*apikey.Key) ([]byte, error) {
+	return json.Marshal(keys)
+}
+
+// EncodeErrorResponse encodes an error response into JSON format
+func (s *apiKeyJSONSerializer) EncodeErrorResponse(er apikey.ErrorResponse) ([]byte, error) {
+	return json.Marshal(er)
+}
+
+// DecodeIssueRequest decodes a request to issue an API key from JSON format
+func (s *apiKeyJSONSerializer) DecodeIssueRequest(data []byte) (*apikey.IssueRequest, error) {
+	r := &apikey.IssueRequest{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetContentType returns the content type for JSON
+func (s *apiKeyJSONSerializer) GetContentType() string {
+	return "application/json"
+}
@@ -0,0 +1,45 @@
+package serializer
+
+import (
+	"encoding/json"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/invitation"
+)
+
+// invitationJSONSerializer is an implementation of the invitation.Serializer interface for JSON
+type invitationJSONSerializer struct{}
+
+// NewInvitationJSONSerializer creates a new invitation JSON serializer
+// This function returns a new instance of invitationJSONSerializer
+func NewInvitationJSONSerializer() invitation.Serializer {
+	return &invitationJSONSerializer{}
+}
+
+// EncodeIssued encodes newly created invitations into JSON format
+func (s *invitationJSONSerializer) EncodeIssued(invitations []*invitation.IssuedInvitation) ([]byte, error) {
+	return json.Marshal(invitations)
+}
+
+// EncodeReport encodes an invitation report into JSON format
+func (s *invitationJSONSerializer) EncodeReport(r *invitation.Report) ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// EncodeErrorResponse encodes an error response into JSON format
+func (s *invitationJSONSerializer) EncodeErrorResponse(er invitation.ErrorResponse) ([]byte, error) {
+	return json.Marshal(er)
+}
+
+// DecodeCreateRequest decodes a request to invite recipients from JSON format
+func (s *invitationJSONSerializer) DecodeCreateRequest(data []byte) (*invitation.CreateRequest, error) {
+	r := &invitation.CreateRequest{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetContentType returns the content type for JSON
+func (s *invitationJSONSerializer) GetContentType() string {
+	return "application/json"
+}
@@ -45,6 +45,11 @@ func (s *surveyJSONSerializer) EncodeSurveysResponse(sr survey.SurveysResponse)
 	return json.Marshal(sr)
 }
 
+// EncodeEvent encodes a survey change event into JSON format
+func (s *surveyJSONSerializer) EncodeEvent(e *survey.Event) ([]byte, error) {
+	return json.Marshal(e)
+}
+
 // Decode decodes a single survey from JSON format
 func (s *surveyJSONSerializer) Decode(data []byte) (*survey.Survey, error) {
 	survey := survey.Survey{}
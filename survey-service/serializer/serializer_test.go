@@ -15,44 +15,44 @@ func TestNewSurveyJSONSerializer(t *testing.T) {
 
 func TestSurveyJSONSerializer_Encode(t *testing.T) {
 	serializer := NewSurveyJSONSerializer()
-	s := &survey.Survey{
+	s := &survey.SurveyWithStatus{Survey: &survey.Survey{
 		ID:   "1",
 		Name: "Test Survey",
 		Questions: []survey.Question{
 			{ID: 1, Text: "What is your name?"},
 			{ID: 2, Text: "How old are you?"},
 		},
-	}
+	}, Status: survey.SurveyStatusActive}
 
 	data, err := serializer.Encode(s)
 	assert.NoError(t, err, "Expected no error on encoding survey")
-	assert.JSONEq(t, `{"id":"1","name":"Test Survey","questions":[{"id":1,"text":"What is your name?"},{"id":2,"text":"How old are you?"}],"createdAt":0}`, string(data), "Expected JSON to match")
+	assert.JSONEq(t, `{"id":"1","name":"Test Survey","description":"","questions":[{"id":1,"text":"What is your name?","type":"","required":false},{"id":2,"text":"How old are you?","type":"","required":false}],"createdAt":0,"active":false,"allowAnonymous":false,"allowEdits":false,"inviteOnly":false,"status":"active"}`, string(data), "Expected JSON to match")
 }
 
 func TestSurveyJSONSerializer_EncodeMultiple(t *testing.T) {
 	serializer := NewSurveyJSONSerializer()
-	surveys := &survey.Surveys{
-		&survey.Survey{
+	surveys := []survey.SurveyWithStatus{
+		{Survey: &survey.Survey{
 			ID:   "1",
 			Name: "Test Survey 1",
 			Questions: []survey.Question{
 				{ID: 1, Text: "What is your name?"},
 				{ID: 2, Text: "How old are you?"},
 			},
-		},
-		&survey.Survey{
+		}, Status: survey.SurveyStatusActive},
+		{Survey: &survey.Survey{
 			ID:   "2",
 			Name: "Test Survey 2",
 			Questions: []survey.Question{
 				{ID: 1, Text: "What is your favorite color?"},
 				{ID: 2, Text: "What is your favorite food?"},
 			},
-		},
+		}, Status: survey.SurveyStatusActive},
 	}
 
 	data, err := serializer.EncodeMultiple(surveys)
 	assert.NoError(t, err, "Expected no error on encoding multiple surveys")
-	expectedJSON := `[{"id":"1","name":"Test Survey 1","questions":[{"id":1,"text":"What is your name?"},{"id":2,"text":"How old are you?"}],"createdAt":0},{"id":"2","name":"Test Survey 2","questions":[{"id":1,"text":"What is your favorite color?"},{"id":2,"text":"What is your favorite food?"}],"createdAt":0}]`
+	expectedJSON := `[{"id":"1","name":"Test Survey 1","description":"","questions":[{"id":1,"text":"What is your name?","type":"","required":false},{"id":2,"text":"How old are you?","type":"","required":false}],"createdAt":0,"active":false,"allowAnonymous":false,"allowEdits":false,"inviteOnly":false,"status":"active"},{"id":"2","name":"Test Survey 2","description":"","questions":[{"id":1,"text":"What is your favorite color?","type":"","required":false},{"id":2,"text":"What is your favorite food?","type":"","required":false}],"createdAt":0,"active":false,"allowAnonymous":false,"allowEdits":false,"inviteOnly":false,"status":"active"}]`
 	assert.JSONEq(t, expectedJSON, string(data), "Expected JSON to match")
 }
 
@@ -0,0 +1,111 @@
+package survey
+
+// Role defines a role granted to a user
+type Role string
+
+// Available roles
+const (
+	RoleAdmin  Role = "admin"  // Can view and manage every survey
+	RoleEditor Role = "editor" // Can create surveys and manage surveys they own or that are shared with them
+	RoleViewer Role = "viewer" // Can view surveys they own or that are shared with them
+)
+
+// User describes the user calling the survey service
+type User struct {
+	ID        string // Unique identifier of the user
+	Roles     []Role // Roles granted to the user
+	InvitedTo string // ID of the invite-only survey the user holds a verified invitation to, empty otherwise
+}
+
+// SystemUser returns the user of internal callers such as other services and background jobs
+// The system user has the admin role
+func SystemUser() *User {
+	return &User{ID: "system", Roles: []Role{RoleAdmin}}
+}
+
+// HasRole checks if the user has been granted a role
+func (u *User) HasRole(role Role) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin checks if the user is an admin
+func (u *User) IsAdmin() bool {
+	return u.HasRole(RoleAdmin)
+}
+
+// CanCreate checks if the user can create surveys
+func (u *User) CanCreate() bool {
+	return u.IsAdmin() || u.HasRole(RoleEditor)
+}
+
+// IsOwner checks if the survey is owned by the user
+func (s *Survey) IsOwner(u *User) bool {
+	return u != nil && s.OwnerID != "" && s.OwnerID == u.ID
+}
+
+// IsSharedWith checks if the survey is shared with the user
+func (s *Survey) IsSharedWith(u *User) bool {
+	if u == nil {
+		return false
+	}
+	for _, id := range s.SharedWith {
+		if id == u.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// CanView checks if the user can list the survey and view its results
+// Admins can view every survey, other users the surveys they own or that are shared with them
+func (s *Survey) CanView(u *User) bool {
+	if u == nil {
+		return false
+	}
+	if u.IsAdmin() {
+		return true
+	}
+	return (u.HasRole(RoleEditor) || u.HasRole(RoleViewer)) && (s.IsOwner(u) || s.IsSharedWith(u))
+}
+
+// CanRead checks if the user can read the survey to respond to it
+// Users who can view the survey read it in any state, other users only the live surveys they can respond to:
+// invite-only surveys are read by their invitees, other surveys by authenticated users, or by anyone when they allow anonymous responses
+func (s *Survey) CanRead(u *User) bool {
+	if s.CanView(u) {
+		return true
+	}
+	if s.IsArchived() {
+		return false
+	}
+	if s.InviteOnly {
+		return u != nil && u.InvitedTo == s.ID
+	}
+	return u != nil || s.AllowAnonymous
+}
+
+// CanEdit checks if the user can change the survey
+// Admins can edit every survey, editors the surveys they own or that are shared with them
+func (s *Survey) CanEdit(u *User) bool {
+	if u == nil {
+		return false
+	}
+	if u.IsAdmin() {
+		return true
+	}
+	return u.HasRole(RoleEditor) && (s.IsOwner(u) || s.IsSharedWith(u))
+}
+
+// CanShare checks if the user can change the owner and the users the survey is shared with
+// Only admins and the owner can share a survey
+func (s *Survey) CanShare(u *User) bool {
+	if u == nil {
+		return false
+	}
+	return u.IsAdmin() || (u.HasRole(RoleEditor) && s.IsOwner(u))
+}
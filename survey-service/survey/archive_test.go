@@ -0,0 +1,113 @@
+package survey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingPublisher is an event publisher keeping published events
+type recordingPublisher struct {
+	events []*Event
+}
+
+// Publish records the event
+func (p *recordingPublisher) Publish(e *Event) {
+	p.events = append(p.events, e)
+}
+
+// types returns the types of the recorded events
+func (p *recordingPublisher) types() []EventType {
+	var types []EventType
+	for _, e := range p.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+// TestArchiveAndRestore tests archiving and restoring a survey
+func TestArchiveAndRestore(t *testing.T) {
+	owner := &User{ID: "editor-1", Roles: []Role{RoleEditor}}
+	stranger := &User{ID: "editor-2", Roles: []Role{RoleEditor}}
+	s := &Survey{ID: "1", Name: "Test Survey", OwnerID: owner.ID, Active: true, AllowAnonymous: true}
+
+	repo := new(MockRepository)
+	repo.On("LoadByID", s.ID).Return(s, nil)
+	repo.On("Update", s).Return(nil)
+	p := &recordingPublisher{}
+	service := NewService(repo, p)
+
+	assert.ErrorIs(t, service.ArchiveSurvey(stranger, s.ID), ErrForbidden, "Expected forbidden error when a stranger archives")
+	assert.NoError(t, service.ArchiveSurvey(owner, s.ID), "Expected no error on archive")
+	assert.NotZero(t, s.ArchivedAt, "Expected archive time to be set")
+
+	loaded, err := service.LoadByID(owner, s.ID)
+	assert.NoError(t, err, "Expected archived survey to still be loadable by its owner")
+	assert.Equal(t, SurveyStatusArchived, loaded.Status, "Expected archived status")
+	_, err = service.LoadByID(nil, s.ID)
+	assert.ErrorIs(t, err, ErrNotFound, "Expected archived survey to be hidden from respondents")
+
+	// Archived surveys cannot be changed
+	assert.ErrorIs(t, service.ArchiveSurvey(owner, s.ID), ErrSurveyArchived, "Expected archived error on second archive")
+	assert.ErrorIs(t, service.DeactivateSurvey(owner, s.ID), ErrSurveyArchived, "Expected archived error on deactivate")
+
+	assert.ErrorIs(t, service.RestoreSurvey(stranger, s.ID), ErrForbidden, "Expected forbidden error when a stranger restores")
+	assert.NoError(t, service.RestoreSurvey(owner, s.ID), "Expected no error on restore")
+	assert.Zero(t, s.ArchivedAt, "Expected archive time to be cleared")
+	assert.ErrorIs(t, service.RestoreSurvey(owner, s.ID), ErrSurveyNotArchived, "Expected not archived error on second restore")
+
+	loaded, _ = service.LoadByID(owner, s.ID)
+	assert.Equal(t, SurveyStatusActive, loaded.Status, "Expected restored survey to be active again")
+
+	assert.Equal(t, []EventType{EventArchived, EventRestored}, p.types(), "Expected one event per successful mutation")
+	repo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+// TestLoadArchived tests that archived surveys are listed apart from live ones
+func TestLoadArchived(t *testing.T) {
+	live := &Survey{ID: "1", Name: "Live"}
+	archived := &Survey{ID: "2", Name: "Archived", ArchivedAt: time.Now().UTC().Unix()}
+
+	repo := new(MockRepository)
+	repo.On("Load").Return(&Surveys{live}, nil)
+	repo.On("LoadArchived").Return(&Surveys{archived}, nil)
+	service := NewService(repo, testPublisher{})
+
+	surveys, err := service.Load(testAdmin)
+	assert.NoError(t, err, "Expected no error on load")
+	assert.Len(t, surveys, 1, "Expected only the live survey")
+	assert.Equal(t, live.ID, surveys[0].ID, "Expected only the live survey")
+
+	surveys, err = service.LoadArchived(testAdmin)
+	assert.NoError(t, err, "Expected no error on loading archived surveys")
+	assert.Len(t, surveys, 1, "Expected only the archived survey")
+	assert.Equal(t, SurveyStatusArchived, surveys[0].Status, "Expected archived status")
+
+	_, err = service.LoadArchived(nil)
+	assert.ErrorIs(t, err, ErrForbidden, "Expected forbidden error for anonymous users")
+}
+
+// TestPurgeArchived tests that only surveys archived before the cutoff are purged
+func TestPurgeArchived(t *testing.T) {
+	now := time.Now().UTC().Unix()
+	old := &Survey{ID: "1", ArchivedAt: now - 3600}
+	gone := &Survey{ID: "2", ArchivedAt: now - 3600}
+	recent := &Survey{ID: "3", ArchivedAt: now}
+
+	repo := new(MockRepository)
+	repo.On("LoadArchived").Return(&Surveys{old, gone, recent}, nil)
+	repo.On("Delete", old.ID).Return(nil)
+	repo.On("Delete", gone.ID).Return(ErrNotFound)
+	p := &recordingPublisher{}
+	service := NewService(repo, p)
+
+	purged, err := service.PurgeArchived(now - 60)
+	assert.NoError(t, err, "Expected no error on purge")
+	assert.Equal(t, 2, purged, "Expected only the old surveys to be purged")
+
+	repo.AssertNotCalled(t, "Delete", recent.ID)
+	assert.Equal(t, []EventType{EventDeleted, EventDeleted}, p.types(), "Expected a deleted event per purged survey")
+	assert.Equal(t, old.ID, p.events[0].Survey, "Expected the deleted event to reference the purged survey")
+	assert.Equal(t, gone.ID, p.events[1].Survey, "Expected surveys deleted concurrently to be reported as purged")
+}
@@ -3,6 +3,9 @@ package survey
 import (
 	"errors"
 	"fmt"
+	"mime"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator"
@@ -24,6 +27,15 @@ var (
 
 	// ErrQuestionConditionalLogic indicates an issue with conditional logic
 	ErrQuestionConditionalLogic = errors.New("invalid conditional logic")
+
+	// ErrSurveyArchived indicates that the survey is archived and cannot be changed
+	ErrSurveyArchived = errors.New("survey is archived")
+
+	// ErrSurveyNotArchived indicates that the survey is not archived and cannot be restored
+	ErrSurveyNotArchived = errors.New("survey is not archived")
+
+	// ErrForbidden indicates that the user is not allowed to access the survey
+	ErrForbidden = errors.New("access to survey forbidden")
 )
 
 // ErrorResponse provides a structure for error responses
@@ -44,14 +56,16 @@ type SurveysResponse struct {
 // surveyService implements the Service interface for managing surveys
 type surveyService struct {
 	repository Repository
+	publisher  EventPublisher
 	validator  *validator.Validate
 }
 
 // NewService creates a new survey service
-// This function initializes a new surveyService with the given repository and a validator
-func NewService(repository Repository) Service {
+// This function initializes a new surveyService with the given repository, event publisher and a validator
+func NewService(repository Repository, publisher EventPublisher) Service {
 	s := &surveyService{
 		repository: repository,
+		publisher:  publisher,
 		validator:  validator.New(),
 	}
 
@@ -66,7 +80,8 @@ func validateQuestionType(fl validator.FieldLevel) bool {
 	qt := fl.Field().String()
 	switch QuestionType(qt) {
 	case QuestionTypeSingleChoice, QuestionTypeMultipleChoice, QuestionTypeText,
-		QuestionTypeRating, QuestionTypeScale, QuestionTypeDate:
+		QuestionTypeRating, QuestionTypeScale, QuestionTypeDate, QuestionTypeNPS,
+		QuestionTypeMatrix, QuestionTypeRanking, QuestionTypeFile:
 		return true
 	default:
 		return false
@@ -74,8 +89,12 @@ func validateQuestionType(fl validator.FieldLevel) bool {
 }
 
 // Insert validates and inserts a new survey into the repository
-// Generates a unique ID and sets the creation time for the survey
-func (s *surveyService) Insert(survey *Survey) error {
+// Generates a unique ID and sets the creation time and owner for the survey
+func (s *surveyService) Insert(user *User, survey *Survey) error {
+	if user == nil || !user.CanCreate() {
+		return ErrForbidden
+	}
+
 	// Validate the survey structure
 	if err := s.validator.Struct(survey); err != nil {
 		return fmt.Errorf("%w: %v", ErrInvalidRequest, err)
@@ -84,6 +103,8 @@ func (s *surveyService) Insert(survey *Survey) error {
 	// Generate a unique ID and set the creation time
 	survey.ID = shortid.MustGenerate()
 	survey.CreatedAt = time.Now().UTC().Unix()
+	survey.ArchivedAt = 0
+	survey.OwnerID = user.ID
 
 	// Set active flag to true by default if not specified
 	if !survey.Active {
@@ -107,16 +128,30 @@ func (s *surveyService) Insert(survey *Survey) error {
 			}
 		}
 
-		// Assign IDs to options if not already assigned
-		if len(survey.Questions[k].Options) > 0 {
-			for j := range survey.Questions[k].Options {
-				survey.Questions[k].Options[j].ID = j + 1
-			}
-		}
+		// Assign IDs to options, rows and columns
+		assignOptionIDs(survey.Questions[k].Options)
+		assignOptionIDs(survey.Questions[k].Rows)
+		assignOptionIDs(survey.Questions[k].Columns)
 	}
 
 	// Insert the survey into the repository
-	return s.repository.Insert(survey)
+	if err := s.repository.Insert(survey); err != nil {
+		return err
+	}
+
+	s.publish(EventCreated, survey.ID)
+	return nil
+}
+
+// secondsPerDay is the number of seconds in a day, days start at multiples of it in Unix time
+// Days before 1970 are negative multiples, whose remainder is zero too
+const secondsPerDay = 24 * 60 * 60
+
+// assignOptionIDs numbers options in order, starting at 1
+func assignOptionIDs(options []Option) {
+	for j := range options {
+		options[j].ID = j + 1
+	}
 }
 
 // validateQuestionByType validates a question based on its type
@@ -127,6 +162,14 @@ func (s *surveyService) validateQuestionByType(q *Question) error {
 		if len(q.Options) < 2 {
 			return errors.New("choice questions must have at least 2 options")
 		}
+		if err := validateOtherOption(q.Options); err != nil {
+			return err
+		}
+	case QuestionTypeRanking:
+		// Ranking questions order their options
+		if len(q.Options) < 2 {
+			return errors.New("ranking questions must have at least 2 options")
+		}
 	case QuestionTypeRating, QuestionTypeScale:
 		// Scale questions must have min and max values
 		if q.MinValue == nil || q.MaxValue == nil {
@@ -135,6 +178,151 @@ func (s *surveyService) validateQuestionByType(q *Question) error {
 		if *q.MinValue >= *q.MaxValue {
 			return errors.New("min value must be less than max value")
 		}
+	case QuestionTypeMatrix:
+		// Matrix questions rate each row on the shared columns
+		if len(q.Rows) < 1 {
+			return errors.New("matrix questions must have at least 1 row")
+		}
+		if len(q.Columns) < 2 {
+			return errors.New("matrix questions must have at least 2 columns")
+		}
+	case QuestionTypeNPS:
+		// NPS questions always use the standard 0-10 scale
+		if (q.MinValue != nil && *q.MinValue != NPSMinValue) || (q.MaxValue != nil && *q.MaxValue != NPSMaxValue) {
+			return errors.New("nps questions must range from 0 to 10")
+		}
+		min, max := NPSMinValue, NPSMaxValue
+		q.MinValue, q.MaxValue = &min, &max
+	}
+
+	// Only multiple choice questions select several options
+	if q.MinSelections != nil || q.MaxSelections != nil {
+		if q.Type != QuestionTypeMultipleChoice {
+			return errors.New("selection limits can only be set on multiple choice questions")
+		}
+		if err := validateSelectionLimits(q); err != nil {
+			return err
+		}
+	}
+
+	// Only uploaded files have types and sizes
+	if q.FileConstraints != nil {
+		if q.Type != QuestionTypeFile {
+			return errors.New("file constraints can only be set on file questions")
+		}
+		if err := validateFileConstraints(q.FileConstraints); err != nil {
+			return err
+		}
+	}
+
+	// Only date answers have a mode and a range
+	if q.DateConstraints != nil {
+		if q.Type != QuestionTypeDate {
+			return errors.New("date constraints can only be set on date questions")
+		}
+		if err := validateDateConstraints(q.DateConstraints); err != nil {
+			return err
+		}
+	}
+
+	// Only text answers can be constrained
+	if q.TextConstraints != nil {
+		if q.Type != QuestionTypeText {
+			return errors.New("text constraints can only be set on text questions")
+		}
+		if err := validateTextConstraints(q.TextConstraints); err != nil {
+			return err
+		}
+	}
+
+	// NPS results of tagged scale questions assume the standard 0-10 scale
+	if q.HasTag(QuestionTagNPS) && q.Type != QuestionTypeNPS {
+		if q.Type != QuestionTypeScale || *q.MinValue != NPSMinValue || *q.MaxValue != NPSMaxValue {
+			return errors.New("nps questions must be scale questions from 0 to 10")
+		}
+	}
+	return nil
+}
+
+// validateOtherOption checks that at most one option asks for a text value
+func validateOtherOption(options []Option) error {
+	others := 0
+	for _, o := range options {
+		if o.Other {
+			others++
+		}
+	}
+	if others > 1 {
+		return errors.New("choice questions can have only one other option")
+	}
+	return nil
+}
+
+// validateSelectionLimits validates the selection limits of a multiple choice question
+func validateSelectionLimits(q *Question) error {
+	if (q.MinSelections != nil && *q.MinSelections < 1) || (q.MaxSelections != nil && *q.MaxSelections < 1) {
+		return errors.New("selection limits must be positive")
+	}
+	if (q.MinSelections != nil && *q.MinSelections > len(q.Options)) || (q.MaxSelections != nil && *q.MaxSelections > len(q.Options)) {
+		return errors.New("selection limits cannot exceed the number of options")
+	}
+	if q.MinSelections != nil && q.MaxSelections != nil && *q.MinSelections > *q.MaxSelections {
+		return errors.New("min selections must not be greater than max selections")
+	}
+	return nil
+}
+
+// validateFileConstraints validates the constraints of a file question
+func validateFileConstraints(c *FileConstraints) error {
+	if c.MaxSize < 0 {
+		return errors.New("max file size must not be negative")
+	}
+	for _, t := range c.AllowedTypes {
+		if _, _, err := mime.ParseMediaType(t); err != nil || !strings.Contains(t, "/") {
+			return fmt.Errorf("invalid file type %q", t)
+		}
+	}
+	return nil
+}
+
+// validateDateConstraints validates the constraints of a date question
+func validateDateConstraints(c *DateConstraints) error {
+	switch c.Mode {
+	case "", DateModeDate:
+		// Days are answered as midnight UTC, so bounds must be days too
+		for _, bound := range []*int64{c.MinDate, c.MaxDate} {
+			if bound != nil && *bound%secondsPerDay != 0 {
+				return errors.New("date bounds must fall on midnight UTC")
+			}
+		}
+	case DateModeDateTime:
+	default:
+		return fmt.Errorf("unknown date mode %q", c.Mode)
+	}
+	if c.MinDate != nil && c.MaxDate != nil && *c.MinDate > *c.MaxDate {
+		return errors.New("min date must not be after max date")
+	}
+	return nil
+}
+
+// validateTextConstraints validates the constraints of a text question
+func validateTextConstraints(c *TextConstraints) error {
+	if (c.MinLength != nil && *c.MinLength < 0) || (c.MaxLength != nil && *c.MaxLength < 1) {
+		return errors.New("text length constraints must be positive")
+	}
+	if c.MinLength != nil && c.MaxLength != nil && *c.MinLength > *c.MaxLength {
+		return errors.New("min length must not be greater than max length")
+	}
+	if c.Pattern != "" {
+		// Compile the pattern anchored, as the vote service matches it against whole answers
+		if _, err := regexp.Compile("^(?:" + c.Pattern + ")$"); err != nil {
+			return fmt.Errorf("invalid text pattern: %v", err)
+		}
+	}
+	switch c.Format {
+	case "", TextFormatEmail, TextFormatNumber, TextFormatURL:
+	default:
+		return fmt.Errorf("unknown text format %q", c.Format)
 	}
 	return nil
 }
@@ -183,26 +371,74 @@ func (s *surveyService) validateConditionalLogic(logic *ConditionalLogic, curren
 
 // LoadByID retrieves a survey by its ID from the repository
 // Returns the survey with its current status
-func (s *surveyService) LoadByID(id string) (*SurveyWithStatus, error) {
+func (s *surveyService) LoadByID(user *User, id string) (*SurveyWithStatus, error) {
 	survey, err := s.repository.LoadByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	// Archived surveys are hidden from respondents like deleted ones
+	if !survey.CanRead(user) {
+		if survey.IsArchived() {
+			return nil, ErrNotFound
+		}
+		return nil, ErrForbidden
+	}
+
 	return &SurveyWithStatus{
 		Survey: survey,
 		Status: survey.GetStatus(),
 	}, nil
 }
 
-// Load retrieves all surveys from the repository
+// Load retrieves all surveys that are not archived and the user can view from the repository
 // Returns surveys with their current status
-func (s *surveyService) Load() ([]SurveyWithStatus, error) {
-	surveys, err := s.repository.Load()
+func (s *surveyService) Load(user *User) ([]SurveyWithStatus, error) {
+	return s.loadVisible(user, false)
+}
+
+// LoadArchived retrieves all archived surveys the user can view from the repository
+// Returns surveys with their current status
+func (s *surveyService) LoadArchived(user *User) ([]SurveyWithStatus, error) {
+	return s.loadVisible(user, true)
+}
+
+// loadVisible retrieves the live or archived surveys the user can view
+// Admins see every survey, other users the surveys they own or that are shared with them
+func (s *surveyService) loadVisible(user *User, archived bool) ([]SurveyWithStatus, error) {
+	if user == nil {
+		return nil, ErrForbidden
+	}
+
+	var (
+		surveys *Surveys
+		err     error
+	)
+	switch {
+	case user.IsAdmin() && archived:
+		surveys, err = s.repository.LoadArchived()
+	case user.IsAdmin():
+		surveys, err = s.repository.Load()
+	default:
+		surveys, err = s.repository.LoadAccessible(user.ID, archived)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	// Drop surveys the roles of the user do not allow to view
+	visible := make(Surveys, 0, len(*surveys))
+	for _, survey := range *surveys {
+		if survey.CanView(user) {
+			visible = append(visible, survey)
+		}
+	}
+
+	return withStatus(&visible), nil
+}
+
+// withStatus converts surveys to surveys with their current status
+func withStatus(surveys *Surveys) []SurveyWithStatus {
 	// Convert surveys to surveys with status
 	surveysWithStatus := make([]SurveyWithStatus, 0, len(*surveys))
 	for _, survey := range *surveys {
@@ -212,7 +448,7 @@ func (s *surveyService) Load() ([]SurveyWithStatus, error) {
 		})
 	}
 
-	return surveysWithStatus, nil
+	return surveysWithStatus
 }
 
 // LoadActive retrieves all active surveys that haven't expired
@@ -222,10 +458,10 @@ func (s *surveyService) LoadActive() ([]SurveyWithStatus, error) {
 		return nil, err
 	}
 
-	// Filter active non-expired surveys
+	// Filter active non-expired surveys, invite-only surveys are never listed publicly
 	activeNonExpired := make([]SurveyWithStatus, 0)
 	for _, survey := range *surveys {
-		if survey.Active && !survey.IsExpired() {
+		if survey.Active && !survey.IsExpired() && !survey.InviteOnly {
 			activeNonExpired = append(activeNonExpired, SurveyWithStatus{
 				Survey: survey,
 				Status: SurveyStatusActive,
@@ -237,7 +473,8 @@ func (s *surveyService) LoadActive() ([]SurveyWithStatus, error) {
 }
 
 // Update updates an existing survey
-func (s *surveyService) Update(id string, survey *Survey) error {
+// Only admins and the owner can change the owner and the users the survey is shared with
+func (s *surveyService) Update(user *User, id string, survey *Survey) error {
 	// Ensure the ID is set correctly
 	survey.ID = id
 
@@ -246,14 +483,23 @@ func (s *surveyService) Update(id string, survey *Survey) error {
 		return fmt.Errorf("%w: %v", ErrInvalidRequest, err)
 	}
 
-	// Check if the survey exists
-	existing, err := s.repository.LoadByID(id)
+	// Check if the survey exists and can still be changed
+	existing, err := s.loadEditable(user, id)
 	if err != nil {
 		return err
 	}
 
-	// Preserve creation time
+	// Preserve creation time and archive state
 	survey.CreatedAt = existing.CreatedAt
+	survey.ArchivedAt = existing.ArchivedAt
+
+	// Preserve ownership unless the user may share the survey
+	if !existing.CanShare(user) {
+		survey.OwnerID = existing.OwnerID
+		survey.SharedWith = existing.SharedWith
+	} else if survey.OwnerID == "" || !user.IsAdmin() {
+		survey.OwnerID = existing.OwnerID
+	}
 
 	// Validate questions
 	for k := range survey.Questions {
@@ -272,43 +518,56 @@ func (s *surveyService) Update(id string, survey *Survey) error {
 			}
 		}
 
-		// Assign IDs to options if not already assigned
-		if len(survey.Questions[k].Options) > 0 {
-			for j := range survey.Questions[k].Options {
-				survey.Questions[k].Options[j].ID = j + 1
-			}
-		}
+		// Assign IDs to options, rows and columns
+		assignOptionIDs(survey.Questions[k].Options)
+		assignOptionIDs(survey.Questions[k].Rows)
+		assignOptionIDs(survey.Questions[k].Columns)
 	}
 
 	// Update the survey in the repository
-	return s.repository.Update(survey)
+	if err := s.repository.Update(survey); err != nil {
+		return err
+	}
+
+	s.publish(EventUpdated, id)
+	return nil
 }
 
 // ActivateSurvey activates a survey
-func (s *surveyService) ActivateSurvey(id string) error {
-	survey, err := s.repository.LoadByID(id)
+func (s *surveyService) ActivateSurvey(user *User, id string) error {
+	survey, err := s.loadEditable(user, id)
 	if err != nil {
 		return err
 	}
 
 	survey.Active = true
-	return s.repository.Update(survey)
+	if err := s.repository.Update(survey); err != nil {
+		return err
+	}
+
+	s.publish(EventActivated, id)
+	return nil
 }
 
 // DeactivateSurvey deactivates a survey
-func (s *surveyService) DeactivateSurvey(id string) error {
-	survey, err := s.repository.LoadByID(id)
+func (s *surveyService) DeactivateSurvey(user *User, id string) error {
+	survey, err := s.loadEditable(user, id)
 	if err != nil {
 		return err
 	}
 
 	survey.Active = false
-	return s.repository.Update(survey)
+	if err := s.repository.Update(survey); err != nil {
+		return err
+	}
+
+	s.publish(EventDeactivated, id)
+	return nil
 }
 
 // SetExpirationDate sets the expiration date for a survey
-func (s *surveyService) SetExpirationDate(id string, expiresAt int64) error {
-	survey, err := s.repository.LoadByID(id)
+func (s *surveyService) SetExpirationDate(user *User, id string, expiresAt int64) error {
+	survey, err := s.loadEditable(user, id)
 	if err != nil {
 		return err
 	}
@@ -319,10 +578,123 @@ func (s *surveyService) SetExpirationDate(id string, expiresAt int64) error {
 	}
 
 	survey.ExpiresAt = expiresAt
-	return s.repository.Update(survey)
+	if err := s.repository.Update(survey); err != nil {
+		return err
+	}
+
+	s.publish(EventExpired, id)
+	return nil
 }
 
 // DeleteSurvey deletes a survey by ID
-func (s *surveyService) DeleteSurvey(id string) error {
-	return s.repository.Delete(id)
+func (s *surveyService) DeleteSurvey(user *User, id string) error {
+	survey, err := s.repository.LoadByID(id)
+	if err != nil {
+		return err
+	}
+
+	if !survey.CanEdit(user) {
+		return ErrForbidden
+	}
+
+	if err := s.repository.Delete(id); err != nil {
+		return err
+	}
+
+	s.publish(EventDeleted, id)
+	return nil
+}
+
+// ArchiveSurvey archives a survey
+// Archived surveys are hidden from listings and cannot be changed until they are restored
+func (s *surveyService) ArchiveSurvey(user *User, id string) error {
+	survey, err := s.loadEditable(user, id)
+	if err != nil {
+		return err
+	}
+
+	survey.ArchivedAt = time.Now().UTC().Unix()
+	if err := s.repository.Update(survey); err != nil {
+		return err
+	}
+
+	s.publish(EventArchived, id)
+	return nil
+}
+
+// RestoreSurvey restores an archived survey
+func (s *surveyService) RestoreSurvey(user *User, id string) error {
+	survey, err := s.repository.LoadByID(id)
+	if err != nil {
+		return err
+	}
+
+	if !survey.CanEdit(user) {
+		return ErrForbidden
+	}
+
+	if !survey.IsArchived() {
+		return ErrSurveyNotArchived
+	}
+
+	survey.ArchivedAt = 0
+	if err := s.repository.Update(survey); err != nil {
+		return err
+	}
+
+	s.publish(EventRestored, id)
+	return nil
+}
+
+// PurgeArchived permanently deletes surveys archived before the given timestamp
+// A deletion event is published for every purged survey so dependent services can clean up
+func (s *surveyService) PurgeArchived(before int64) (int, error) {
+	surveys, err := s.repository.LoadArchived()
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, survey := range *surveys {
+		if survey.ArchivedAt >= before {
+			continue
+		}
+
+		if err := s.repository.Delete(survey.ID); err != nil && !errors.Is(err, ErrNotFound) {
+			return purged, err
+		}
+
+		s.publish(EventDeleted, survey.ID)
+		purged++
+	}
+
+	return purged, nil
+}
+
+// loadEditable loads a survey that the user is about to change
+// Archived surveys cannot be changed until they are restored
+func (s *surveyService) loadEditable(user *User, id string) (*Survey, error) {
+	survey, err := s.repository.LoadByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !survey.CanEdit(user) {
+		return nil, ErrForbidden
+	}
+
+	if survey.IsArchived() {
+		return nil, ErrSurveyArchived
+	}
+
+	return survey, nil
+}
+
+// publish publishes a change event for a survey
+func (s *surveyService) publish(t EventType, id string) {
+	s.publisher.Publish(&Event{
+		Type:      t,
+		Survey:    id,
+		Timestamp: time.Now().UTC().Unix(),
+	})
 }
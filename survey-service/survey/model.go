@@ -13,6 +13,39 @@ const (
 	QuestionTypeRating         QuestionType = "rating"          // Rating on a scale
 	QuestionTypeScale          QuestionType = "scale"           // Likert scale or other numeric scale
 	QuestionTypeDate           QuestionType = "date"            // Date selection
+	QuestionTypeNPS            QuestionType = "nps"             // Net Promoter Score from 0 to 10
+	QuestionTypeMatrix         QuestionType = "matrix"          // Row statements rated on a shared column scale
+	QuestionTypeRanking        QuestionType = "ranking"         // Options ordered by preference
+	QuestionTypeFile           QuestionType = "file"            // Uploaded file such as a screenshot or document
+)
+
+// Fixed bounds of Net Promoter Score questions
+const (
+	NPSMinValue = 0  // Least likely to recommend
+	NPSMaxValue = 10 // Most likely to recommend
+)
+
+// QuestionTagNPS tags a 0-10 scale question as a Net Promoter Score question
+// The results of tagged questions include the promoter, passive and detractor breakdown
+const QuestionTagNPS = "nps"
+
+// TextFormat defines the format expected from the answers to a text question
+type TextFormat string
+
+// Available text formats
+const (
+	TextFormatEmail  TextFormat = "email"  // Email address
+	TextFormatNumber TextFormat = "number" // Decimal number
+	TextFormatURL    TextFormat = "url"    // Absolute HTTP or HTTPS URL
+)
+
+// DateMode defines whether date questions ask for a day or a moment
+type DateMode string
+
+// Available date modes
+const (
+	DateModeDate     DateMode = "date"     // Calendar day, answered as midnight UTC
+	DateModeDateTime DateMode = "datetime" // Date and time of day
 )
 
 // MediaType defines the type of media attached to a question
@@ -40,12 +73,17 @@ type Survey struct {
 	ID              string     `json:"id" bson:"id"`                                               // Unique identifier for the survey
 	Name            string     `json:"name" bson:"name" validate:"required"`                       // Name of the survey, required field
 	Description     string     `json:"description" bson:"description"`                             // Description of the survey
-	Questions       []Question `json:"questions" bson:"questions" validate:"required,min=1"`       // List of questions, required field with minimum of 1 question
+	Questions       []Question `json:"questions" bson:"questions" validate:"required,min=1,dive"`  // List of questions, required field with minimum of 1 question
 	CreatedAt       int64      `json:"createdAt" bson:"createdAt"`                                 // Timestamp of when the survey was created
 	ExpiresAt       int64      `json:"expiresAt,omitempty" bson:"expiresAt,omitempty"`             // Optional expiration timestamp
 	Active          bool       `json:"active" bson:"active"`                                       // Whether the survey is active
 	AllowAnonymous  bool       `json:"allowAnonymous" bson:"allowAnonymous"`                       // Whether anonymous responses are allowed
+	AllowEdits      bool       `json:"allowEdits" bson:"allowEdits"`                               // Whether users can replace their previous response
 	ThankYouMessage string     `json:"thankYouMessage,omitempty" bson:"thankYouMessage,omitempty"` // Message to show after completion
+	ArchivedAt      int64      `json:"archivedAt,omitempty" bson:"archivedAt"`                     // Timestamp of when the survey was archived, zero when not archived
+	OwnerID         string     `json:"ownerId,omitempty" bson:"ownerId,omitempty"`                 // ID of the user who owns the survey
+	SharedWith      []string   `json:"sharedWith,omitempty" bson:"sharedWith,omitempty"`           // IDs of the users the survey is shared with
+	InviteOnly      bool       `json:"inviteOnly" bson:"inviteOnly"`                               // Whether only invited recipients can respond
 }
 
 // Surveys is a slice of survey pointers
@@ -57,22 +95,66 @@ type Surveys []*Survey
 type Question struct {
 	ID               int               `json:"id" bson:"id"`                                                 // Unique identifier for the question within the survey
 	Text             string            `json:"text" bson:"text" validate:"required"`                         // Text of the question, required field
-	Type             QuestionType      `json:"type" bson:"type" validate:"required"`                         // Type of the question, required field
+	Type             QuestionType      `json:"type" bson:"type" validate:"required,questiontype"`            // Type of the question, required field with one of the supported types
 	Required         bool              `json:"required" bson:"required"`                                     // Whether an answer is required
-	Options          []Option          `json:"options,omitempty" bson:"options,omitempty"`                   // Options for choice-based questions
+	Options          []Option          `json:"options,omitempty" bson:"options,omitempty"`                   // Options for choice-based and ranking questions
+	Rows             []Option          `json:"rows,omitempty" bson:"rows,omitempty"`                         // Row statements of matrix questions
+	Columns          []Option          `json:"columns,omitempty" bson:"columns,omitempty"`                   // Shared column scale of matrix questions
 	Media            *Media            `json:"media,omitempty" bson:"media,omitempty"`                       // Optional media attachment
-	MinValue         *int              `json:"minValue,omitempty" bson:"minValue,omitempty"`                 // Min value for scale questions
-	MaxValue         *int              `json:"maxValue,omitempty" bson:"maxValue,omitempty"`                 // Max value for scale questions
+	MinValue         *int              `json:"minValue,omitempty" bson:"minValue,omitempty"`                 // Min value for scale questions, fixed for NPS questions
+	MaxValue         *int              `json:"maxValue,omitempty" bson:"maxValue,omitempty"`                 // Max value for scale questions, fixed for NPS questions
+	MinSelections    *int              `json:"minSelections,omitempty" bson:"minSelections,omitempty"`       // Minimum number of options selected on multiple choice questions
+	MaxSelections    *int              `json:"maxSelections,omitempty" bson:"maxSelections,omitempty"`       // Maximum number of options selected on multiple choice questions
 	ConditionalLogic *ConditionalLogic `json:"conditionalLogic,omitempty" bson:"conditionalLogic,omitempty"` // Conditional logic for question visibility
 	Placeholder      string            `json:"placeholder,omitempty" bson:"placeholder,omitempty"`           // Placeholder text for text questions
+	TextConstraints  *TextConstraints  `json:"textConstraints,omitempty" bson:"textConstraints,omitempty"`   // Constraints on the answers to text questions
+	FileConstraints  *FileConstraints  `json:"fileConstraints,omitempty" bson:"fileConstraints,omitempty"`   // Allowed types and size of the files uploaded to file questions
+	DateConstraints  *DateConstraints  `json:"dateConstraints,omitempty" bson:"dateConstraints,omitempty"`   // Mode and range of the answers to date questions
 	HelpText         string            `json:"helpText,omitempty" bson:"helpText,omitempty"`                 // Additional help text for the question
+	Tags             []string          `json:"tags,omitempty" bson:"tags,omitempty"`                         // Tags changing how results are reported, such as nps
+}
+
+// HasTag checks if the question is tagged with a tag
+func (q *Question) HasTag(tag string) bool {
+	for _, t := range q.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
 }
 
 // Option represents an answer option for choice-based questions
+// Matrix questions also use options for their rows and columns
 type Option struct {
 	ID    int    `json:"id" bson:"id"`                           // Unique identifier for the option
 	Text  string `json:"text" bson:"text"`                       // Text of the option
 	Image string `json:"image,omitempty" bson:"image,omitempty"` // Optional image URL for the option
+	Other bool   `json:"other,omitempty" bson:"other,omitempty"` // Whether the option asks respondents to specify a text value
+}
+
+// TextConstraints restricts the answers to a text question
+// Lengths count characters, and the pattern must match the whole answer
+type TextConstraints struct {
+	MinLength *int       `json:"minLength,omitempty" bson:"minLength,omitempty"` // Minimum number of characters
+	MaxLength *int       `json:"maxLength,omitempty" bson:"maxLength,omitempty"` // Maximum number of characters
+	Pattern   string     `json:"pattern,omitempty" bson:"pattern,omitempty"`     // Regular expression the answer must match
+	Format    TextFormat `json:"format,omitempty" bson:"format,omitempty"`       // Format of the answer, such as email
+}
+
+// FileConstraints restricts the files uploaded to a file question
+// Types are MIME types such as application/pdf, or wildcards such as image/*
+type FileConstraints struct {
+	AllowedTypes []string `json:"allowedTypes,omitempty" bson:"allowedTypes,omitempty"` // MIME types accepted, any type when empty
+	MaxSize      int64    `json:"maxSize,omitempty" bson:"maxSize,omitempty"`           // Maximum size in bytes, the vote service limit applies when zero
+}
+
+// DateConstraints restricts the answers to a date question
+// Bounds are Unix timestamps and inclusive, in date mode they must fall on midnight UTC
+type DateConstraints struct {
+	Mode    DateMode `json:"mode,omitempty" bson:"mode,omitempty"`       // Whether a day or a moment is asked, date when empty
+	MinDate *int64   `json:"minDate,omitempty" bson:"minDate,omitempty"` // Earliest accepted answer
+	MaxDate *int64   `json:"maxDate,omitempty" bson:"maxDate,omitempty"` // Latest accepted answer
 }
 
 // Media represents a media attachment for a question
@@ -106,6 +188,7 @@ const (
 	SurveyStatusInactive  SurveyStatus = "inactive"  // Survey is inactive
 	SurveyStatusExpired   SurveyStatus = "expired"   // Survey has expired
 	SurveyStatusScheduled SurveyStatus = "scheduled" // Survey is scheduled for future
+	SurveyStatusArchived  SurveyStatus = "archived"  // Survey is archived and can be restored
 )
 
 // IsExpired checks if the survey has expired
@@ -116,8 +199,16 @@ func (s *Survey) IsExpired() bool {
 	return time.Now().UTC().Unix() > s.ExpiresAt
 }
 
+// IsArchived checks if the survey has been archived
+func (s *Survey) IsArchived() bool {
+	return s.ArchivedAt != 0
+}
+
 // GetStatus returns the current status of the survey
 func (s *Survey) GetStatus() SurveyStatus {
+	if s.IsArchived() {
+		return SurveyStatusArchived
+	}
 	if s.IsExpired() {
 		return SurveyStatusExpired
 	}
@@ -126,3 +217,26 @@ func (s *Survey) GetStatus() SurveyStatus {
 	}
 	return SurveyStatusInactive
 }
+
+// EventType defines the type of a survey change event
+type EventType string
+
+// Available survey event types
+const (
+	EventCreated     EventType = "survey.created"     // Survey was created
+	EventUpdated     EventType = "survey.updated"     // Survey definition was updated
+	EventActivated   EventType = "survey.activated"   // Survey was activated
+	EventDeactivated EventType = "survey.deactivated" // Survey was deactivated
+	EventExpired     EventType = "survey.expired"     // Survey expiration date was set
+	EventDeleted     EventType = "survey.deleted"     // Survey was deleted
+	EventArchived    EventType = "survey.archived"    // Survey was archived
+	EventRestored    EventType = "survey.restored"    // Archived survey was restored
+)
+
+// Event describes a change made to a survey
+// This struct represents a domain event published whenever a survey is mutated
+type Event struct {
+	Type      EventType `json:"type"`      // Type of the change
+	Survey    string    `json:"survey"`    // ID of the changed survey
+	Timestamp int64     `json:"timestamp"` // Timestamp when the change happened
+}
@@ -0,0 +1,9 @@
+package survey
+
+// EventPublisher contains functions to publish survey change events
+// This interface defines the methods required for notifying other services about survey changes
+type EventPublisher interface {
+	// Publish publishes a survey change event
+	// This method must not block the caller; delivery failures are handled by the publisher
+	Publish(e *Event)
+}
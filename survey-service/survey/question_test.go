@@ -0,0 +1,214 @@
+package survey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// questionTest is a question inserted through the service with the error expected, nil when it is valid
+type questionTest struct {
+	name     string
+	question Question
+	err      error
+}
+
+// insertQuestion inserts a survey with a single question through the service
+func insertQuestion(service Service, q Question) error {
+	return service.Insert(testAdmin, &Survey{Name: "Test Survey", Questions: []Question{q}})
+}
+
+// runQuestionTests inserts the question of each test and checks the error returned
+func runQuestionTests(t *testing.T, tests []questionTest) {
+	repo := new(MockRepository)
+	repo.On("Insert", mock.Anything).Return(nil)
+	service := NewService(repo, testPublisher{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := insertQuestion(service, tt.question)
+			if tt.err == nil {
+				assert.NoError(t, err, "Expected the question to be valid")
+			} else {
+				assert.ErrorIs(t, err, tt.err, "Expected the question to be rejected")
+			}
+		})
+	}
+}
+
+// withChange returns a copy of a question changed by a function
+func withChange(q Question, change func(q *Question)) Question {
+	change(&q)
+	return q
+}
+
+// intPtr returns a pointer to an int
+func intPtr(i int) *int {
+	return &i
+}
+
+// int64Ptr returns a pointer to an int64
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+// TestServiceNPSTag tests that only 0-10 scale questions can be tagged as NPS
+func TestServiceNPSTag(t *testing.T) {
+	valid := Question{
+		Text:     "How likely are you to recommend us?",
+		Type:     QuestionTypeScale,
+		MinValue: intPtr(0),
+		MaxValue: intPtr(10),
+		Tags:     []string{QuestionTagNPS},
+	}
+
+	runQuestionTests(t, []questionTest{
+		{"0-10 scale", valid, nil},
+		{"other range", withChange(valid, func(q *Question) { q.MaxValue = intPtr(5) }), ErrInvalidRequest},
+		{"rating question", withChange(valid, func(q *Question) { q.Type = QuestionTypeRating }), ErrInvalidRequest},
+	})
+}
+
+// TestServiceNPSQuestion tests that NPS questions get the fixed 0-10 bounds
+func TestServiceNPSQuestion(t *testing.T) {
+	repo := new(MockRepository)
+	repo.On("Insert", mock.Anything).Return(nil)
+	service := NewService(repo, testPublisher{})
+
+	s := &Survey{
+		Name:      "Test Survey",
+		Questions: []Question{{Text: "How likely are you to recommend us?", Type: QuestionTypeNPS}},
+	}
+	assert.NoError(t, service.Insert(testAdmin, s), "Expected no error on insert")
+	assert.Equal(t, NPSMinValue, *s.Questions[0].MinValue, "Expected the NPS minimum to be set")
+	assert.Equal(t, NPSMaxValue, *s.Questions[0].MaxValue, "Expected the NPS maximum to be set")
+
+	runQuestionTests(t, []questionTest{
+		{"custom bounds", Question{Text: "Recommend?", Type: QuestionTypeNPS, MinValue: intPtr(1), MaxValue: intPtr(5)}, ErrInvalidRequest},
+	})
+}
+
+// TestServiceMatrixQuestion tests that matrix questions need rows and columns, which get IDs
+func TestServiceMatrixQuestion(t *testing.T) {
+	repo := new(MockRepository)
+	repo.On("Insert", mock.Anything).Return(nil)
+	service := NewService(repo, testPublisher{})
+
+	valid := Question{
+		Text:    "How satisfied are you with",
+		Type:    QuestionTypeMatrix,
+		Rows:    []Option{{Text: "Price"}, {Text: "Quality"}},
+		Columns: []Option{{Text: "Unsatisfied"}, {Text: "Neutral"}, {Text: "Satisfied"}},
+	}
+	s := &Survey{Name: "Test Survey", Questions: []Question{valid}}
+	assert.NoError(t, service.Insert(testAdmin, s), "Expected no error on insert")
+	assert.Equal(t, 2, s.Questions[0].Rows[1].ID, "Expected rows to be numbered")
+	assert.Equal(t, 3, s.Questions[0].Columns[2].ID, "Expected columns to be numbered")
+
+	runQuestionTests(t, []questionTest{
+		{"no rows", withChange(valid, func(q *Question) { q.Rows = nil }), ErrInvalidRequest},
+		{"one column", withChange(valid, func(q *Question) { q.Columns = []Option{{Text: "Yes"}} }), ErrInvalidRequest},
+	})
+}
+
+// TestServiceRankingQuestion tests that ranking questions need at least two options
+func TestServiceRankingQuestion(t *testing.T) {
+	valid := Question{
+		Text:    "Order these features by importance",
+		Type:    QuestionTypeRanking,
+		Options: []Option{{Text: "Speed"}, {Text: "Price"}, {Text: "Support"}},
+	}
+
+	runQuestionTests(t, []questionTest{
+		{"three options", valid, nil},
+		{"one option", withChange(valid, func(q *Question) { q.Options = []Option{{Text: "Speed"}} }), ErrInvalidRequest},
+	})
+}
+
+// TestServiceTextConstraints tests the validation of text question constraints
+func TestServiceTextConstraints(t *testing.T) {
+	valid := Question{
+		Text: "What is your email?",
+		Type: QuestionTypeText,
+		TextConstraints: &TextConstraints{
+			MinLength: intPtr(3),
+			MaxLength: intPtr(100),
+			Pattern:   `.+@example\.com`,
+			Format:    TextFormatEmail,
+		},
+	}
+	withConstraints := func(c *TextConstraints) Question {
+		return withChange(valid, func(q *Question) { q.TextConstraints = c })
+	}
+
+	runQuestionTests(t, []questionTest{
+		{"valid constraints", valid, nil},
+		{"inverted lengths", withConstraints(&TextConstraints{MinLength: intPtr(10), MaxLength: intPtr(5)}), ErrInvalidRequest},
+		{"negative length", withConstraints(&TextConstraints{MinLength: intPtr(-1)}), ErrInvalidRequest},
+		{"invalid pattern", withConstraints(&TextConstraints{Pattern: "("}), ErrInvalidRequest},
+		{"unknown format", withConstraints(&TextConstraints{Format: "phone"}), ErrInvalidRequest},
+		{"other question", Question{Text: "Rate", Type: QuestionTypeNPS, TextConstraints: &TextConstraints{MaxLength: intPtr(5)}}, ErrInvalidRequest},
+	})
+}
+
+// TestServiceSelectionLimits tests the validation of selection limits and other options
+func TestServiceSelectionLimits(t *testing.T) {
+	valid := Question{
+		Text:          "Which channels do you use?",
+		Type:          QuestionTypeMultipleChoice,
+		Options:       []Option{{Text: "Email"}, {Text: "Phone"}, {Text: "Other", Other: true}},
+		MinSelections: intPtr(1),
+		MaxSelections: intPtr(2),
+	}
+
+	runQuestionTests(t, []questionTest{
+		{"valid limits", valid, nil},
+		{"inverted limits", withChange(valid, func(q *Question) { q.MinSelections, q.MaxSelections = intPtr(3), intPtr(2) }), ErrInvalidRequest},
+		{"above option count", withChange(valid, func(q *Question) { q.MaxSelections = intPtr(4) }), ErrInvalidRequest},
+		{"single choice", withChange(valid, func(q *Question) { q.Type = QuestionTypeSingleChoice }), ErrInvalidRequest},
+		{"two other options", withChange(valid, func(q *Question) {
+			q.Options = []Option{{Text: "Email"}, {Text: "Other", Other: true}, {Text: "Else", Other: true}}
+		}), ErrInvalidRequest},
+	})
+}
+
+// TestServiceFileConstraints tests the validation of file question constraints
+func TestServiceFileConstraints(t *testing.T) {
+	valid := Question{
+		Text:            "Attach a screenshot",
+		Type:            QuestionTypeFile,
+		FileConstraints: &FileConstraints{AllowedTypes: []string{"image/*", "application/pdf"}, MaxSize: 5 << 20},
+	}
+
+	runQuestionTests(t, []questionTest{
+		{"valid constraints", valid, nil},
+		{"invalid type", withChange(valid, func(q *Question) { q.FileConstraints = &FileConstraints{AllowedTypes: []string{"pdf"}} }), ErrInvalidRequest},
+		{"negative size", withChange(valid, func(q *Question) { q.FileConstraints = &FileConstraints{MaxSize: -1} }), ErrInvalidRequest},
+		{"other question", withChange(valid, func(q *Question) { q.Type = QuestionTypeText }), ErrInvalidRequest},
+	})
+}
+
+// TestServiceDateConstraints tests the validation of date question constraints
+func TestServiceDateConstraints(t *testing.T) {
+	minDate, maxDate := int64(1704067200), int64(1735603200)
+	noon := minDate + 12*60*60
+	valid := Question{
+		Text:            "When did you start?",
+		Type:            QuestionTypeDate,
+		DateConstraints: &DateConstraints{Mode: DateModeDate, MinDate: &minDate, MaxDate: &maxDate},
+	}
+	withConstraints := func(c *DateConstraints) Question {
+		return withChange(valid, func(q *Question) { q.DateConstraints = c })
+	}
+
+	runQuestionTests(t, []questionTest{
+		{"valid constraints", valid, nil},
+		{"reversed range", withConstraints(&DateConstraints{MinDate: &maxDate, MaxDate: &minDate}), ErrInvalidRequest},
+		{"date off midnight", withConstraints(&DateConstraints{MinDate: int64Ptr(noon)}), ErrInvalidRequest},
+		{"date before 1970", withConstraints(&DateConstraints{MinDate: int64Ptr(-365 * secondsPerDay)}), nil},
+		{"date off midnight before 1970", withConstraints(&DateConstraints{MinDate: int64Ptr(-12 * 60 * 60)}), ErrInvalidRequest},
+		{"datetime at noon", withConstraints(&DateConstraints{Mode: DateModeDateTime, MinDate: int64Ptr(noon)}), nil},
+		{"unknown mode", withConstraints(&DateConstraints{Mode: "week"}), ErrInvalidRequest},
+		{"other question", withChange(valid, func(q *Question) { q.Type = QuestionTypeText }), ErrInvalidRequest},
+	})
+}
@@ -11,10 +11,18 @@ type Repository interface {
 	// This method retrieves a survey from the repository by its unique ID
 	LoadByID(id string) (*Survey, error)
 
-	// Load loads all surveys
-	// This method retrieves all surveys from the repository
+	// Load loads all surveys that are not archived
+	// This method retrieves all live surveys from the repository
 	Load() (*Surveys, error)
 
+	// LoadArchived loads all archived surveys
+	// This method retrieves all archived surveys from the repository
+	LoadArchived() (*Surveys, error)
+
+	// LoadAccessible loads the surveys owned by or shared with a user
+	// This method retrieves either the live or the archived surveys of the user
+	LoadAccessible(userID string, archived bool) (*Surveys, error)
+
 	// Update updates an existing survey
 	// This method updates a survey in the repository
 	Update(survey *Survey) error
@@ -27,6 +27,10 @@ type Serializer interface {
 	// This method converts a surveys response into a byte slice (e.g., JSON)
 	EncodeSurveysResponse(sr SurveysResponse) ([]byte, error)
 
+	// EncodeEvent encodes a survey change event
+	// This method converts a survey event into a byte slice (e.g., JSON)
+	EncodeEvent(e *Event) ([]byte, error)
+
 	// Decode decodes a survey
 	// This method converts a byte slice into a survey (e.g., from JSON)
 	Decode(data []byte) (*Survey, error)
@@ -2,18 +2,24 @@ package survey
 
 // Service contains functions to create and fetch surveys
 // This interface defines the methods required for managing surveys in the application
+// Methods taking a user return ErrForbidden when the user is not allowed to perform them
 type Service interface {
-	// Insert stores a new survey
+	// Insert stores a new survey owned by the user
 	// This method saves a new survey to the repository
-	Insert(survey *Survey) error
+	Insert(user *User, survey *Survey) error
 
-	// LoadByID loads a survey by ID
-	// This method retrieves a survey from the repository by its unique ID
-	LoadByID(id string) (*SurveyWithStatus, error)
+	// LoadByID loads a survey the user can read by ID
+	// This method retrieves a survey from the repository by its unique ID, archived surveys are
+	// only found by the users who can view them
+	LoadByID(user *User, id string) (*SurveyWithStatus, error)
 
-	// Load loads all surveys
-	// This method retrieves all surveys from the repository
-	Load() ([]SurveyWithStatus, error)
+	// Load loads all surveys that are not archived and the user can view
+	// This method retrieves the live surveys visible to the user from the repository
+	Load(user *User) ([]SurveyWithStatus, error)
+
+	// LoadArchived loads all archived surveys the user can view
+	// This method retrieves the archived surveys visible to the user from the repository
+	LoadArchived(user *User) ([]SurveyWithStatus, error)
 
 	// LoadActive loads all active surveys that haven't expired
 	// This method retrieves all active non-expired surveys
@@ -21,21 +27,33 @@ type Service interface {
 
 	// Update updates an existing survey
 	// This method updates a survey in the repository
-	Update(id string, survey *Survey) error
+	Update(user *User, id string, survey *Survey) error
 
 	// ActivateSurvey activates a survey
 	// This method sets a survey to active
-	ActivateSurvey(id string) error
+	ActivateSurvey(user *User, id string) error
 
 	// DeactivateSurvey deactivates a survey
 	// This method sets a survey to inactive
-	DeactivateSurvey(id string) error
+	DeactivateSurvey(user *User, id string) error
 
 	// SetExpirationDate sets the expiration date for a survey
 	// This method sets when a survey will expire
-	SetExpirationDate(id string, expiresAt int64) error
+	SetExpirationDate(user *User, id string, expiresAt int64) error
 
 	// DeleteSurvey deletes a survey by ID
 	// This method removes a survey from the repository
-	DeleteSurvey(id string) error
+	DeleteSurvey(user *User, id string) error
+
+	// ArchiveSurvey archives a survey
+	// This method hides a survey without deleting it so it can be restored
+	ArchiveSurvey(user *User, id string) error
+
+	// RestoreSurvey restores an archived survey
+	// This method makes an archived survey visible again
+	RestoreSurvey(user *User, id string) error
+
+	// PurgeArchived deletes surveys archived before the given timestamp
+	// This method permanently removes archived surveys and returns how many were deleted
+	PurgeArchived(before int64) (int, error)
 }
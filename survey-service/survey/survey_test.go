@@ -29,10 +29,39 @@ func (m *MockRepository) Load() (*Surveys, error) {
 	return args.Get(0).(*Surveys), args.Error(1)
 }
 
+func (m *MockRepository) LoadArchived() (*Surveys, error) {
+	args := m.Called()
+	return args.Get(0).(*Surveys), args.Error(1)
+}
+
+func (m *MockRepository) LoadAccessible(userID string, archived bool) (*Surveys, error) {
+	args := m.Called(userID, archived)
+	return args.Get(0).(*Surveys), args.Error(1)
+}
+
+func (m *MockRepository) Update(survey *Survey) error {
+	args := m.Called(survey)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// testPublisher is an event publisher discarding events
+type testPublisher struct{}
+
+// Publish discards the event
+func (testPublisher) Publish(e *Event) {}
+
+// testAdmin is an administrator allowed to manage every survey in tests
+var testAdmin = &User{ID: "admin-1", Roles: []Role{RoleAdmin}}
+
 // TestNewService tests the creation of a new survey service
 func TestNewService(t *testing.T) {
 	repo := new(MockRepository)
-	service := NewService(repo)
+	service := NewService(repo, testPublisher{})
 
 	assert.NotNil(t, service, "Expected new service to be non-nil")
 	assert.IsType(t, &surveyService{}, service, "Expected service to be of type surveyService")
@@ -41,14 +70,14 @@ func TestNewService(t *testing.T) {
 // TestInsert tests the insertion of a new survey
 func TestInsert(t *testing.T) {
 	repo := new(MockRepository)
-	service := NewService(repo)
+	service := NewService(repo, testPublisher{})
 
 	// Create a valid survey
 	validSurvey := &Survey{
 		Name: "Test Survey",
 		Questions: []Question{
-			{Text: "What is your name?"},
-			{Text: "How old are you?"},
+			{Text: "What is your name?", Type: QuestionTypeText},
+			{Text: "How old are you?", Type: QuestionTypeText},
 		},
 	}
 
@@ -56,7 +85,7 @@ func TestInsert(t *testing.T) {
 	repo.On("Insert", validSurvey).Return(nil)
 
 	// Insert the survey
-	err := service.Insert(validSurvey)
+	err := service.Insert(testAdmin, validSurvey)
 
 	// Validate the results
 	assert.NoError(t, err, "Expected no error on valid survey insert")
@@ -70,18 +99,18 @@ func TestInsert(t *testing.T) {
 // TestInsertInvalid tests the insertion of an invalid survey
 func TestInsertInvalid(t *testing.T) {
 	repo := new(MockRepository)
-	service := NewService(repo)
+	service := NewService(repo, testPublisher{})
 
 	// Create an invalid survey (missing name)
 	invalidSurvey := &Survey{
 		Questions: []Question{
-			{Text: "What is your name?"},
-			{Text: "How old are you?"},
+			{Text: "What is your name?", Type: QuestionTypeText},
+			{Text: "How old are you?", Type: QuestionTypeText},
 		},
 	}
 
 	// Insert the survey
-	err := service.Insert(invalidSurvey)
+	err := service.Insert(testAdmin, invalidSurvey)
 
 	// Validate the results
 	assert.Error(t, err, "Expected error on invalid survey insert")
@@ -89,10 +118,26 @@ func TestInsertInvalid(t *testing.T) {
 	repo.AssertNotCalled(t, "Insert", invalidSurvey)
 }
 
+// TestInsertUnknownQuestionType tests that questions of unsupported types are rejected
+func TestInsertUnknownQuestionType(t *testing.T) {
+	repo := new(MockRepository)
+	service := NewService(repo, testPublisher{})
+
+	invalidSurvey := &Survey{
+		Name:      "Test Survey",
+		Questions: []Question{{Text: "How do you feel?", Type: "slider"}},
+	}
+
+	err := service.Insert(testAdmin, invalidSurvey)
+
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected error to be ErrInvalidRequest")
+	repo.AssertNotCalled(t, "Insert", invalidSurvey)
+}
+
 // TestLoadByID tests loading a survey by ID
 func TestLoadByID(t *testing.T) {
 	repo := new(MockRepository)
-	service := NewService(repo)
+	service := NewService(repo, testPublisher{})
 
 	// Create a mock survey
 	mockSurvey := &Survey{
@@ -109,18 +154,67 @@ func TestLoadByID(t *testing.T) {
 	repo.On("LoadByID", "testID").Return(mockSurvey, nil)
 
 	// Load the survey
-	survey, err := service.LoadByID("testID")
+	survey, err := service.LoadByID(testAdmin, "testID")
 
 	// Validate the results
 	assert.NoError(t, err, "Expected no error on load by ID")
-	assert.Equal(t, mockSurvey, survey, "Expected loaded survey to match mock survey")
+	assert.Equal(t, mockSurvey, survey.Survey, "Expected loaded survey to match mock survey")
 	repo.AssertExpectations(t)
 }
 
+// TestLoadByIDAccess tests which users can read a survey by ID
+func TestLoadByIDAccess(t *testing.T) {
+	owner := &User{ID: "editor-1", Roles: []Role{RoleEditor}}
+	stranger := &User{ID: "editor-2", Roles: []Role{RoleEditor}}
+	invitee := &User{InvitedTo: "invite-only"}
+
+	surveys := map[string]*Survey{
+		"private":     {ID: "private", OwnerID: owner.ID},
+		"anonymous":   {ID: "anonymous", OwnerID: owner.ID, AllowAnonymous: true},
+		"invite-only": {ID: "invite-only", OwnerID: owner.ID, InviteOnly: true, AllowAnonymous: true},
+		"archived":    {ID: "archived", OwnerID: owner.ID, AllowAnonymous: true, ArchivedAt: time.Now().UTC().Unix()},
+	}
+	repo := new(MockRepository)
+	for id, s := range surveys {
+		repo.On("LoadByID", id).Return(s, nil)
+	}
+	service := NewService(repo, testPublisher{})
+
+	tests := []struct {
+		name   string
+		user   *User
+		survey string
+		err    error
+	}{
+		{"owner reads private survey", owner, "private", nil},
+		{"user reads private survey", stranger, "private", nil},
+		{"anonymous user cannot read private survey", nil, "private", ErrForbidden},
+		{"anonymous user reads anonymous survey", nil, "anonymous", nil},
+		{"owner reads invite-only survey", owner, "invite-only", nil},
+		{"invitee reads invite-only survey", invitee, "invite-only", nil},
+		{"user cannot read invite-only survey", stranger, "invite-only", ErrForbidden},
+		{"anonymous user cannot read invite-only survey", nil, "invite-only", ErrForbidden},
+		{"owner reads archived survey", owner, "archived", nil},
+		{"user cannot find archived survey", stranger, "archived", ErrNotFound},
+		{"anonymous user cannot find archived survey", nil, "archived", ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := service.LoadByID(tt.user, tt.survey)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err, "Expected the survey not to be read")
+				return
+			}
+			assert.NoError(t, err, "Expected the survey to be read")
+			assert.Equal(t, surveys[tt.survey], loaded.Survey, "Expected the requested survey")
+		})
+	}
+}
+
 // TestLoad tests loading all surveys
 func TestLoad(t *testing.T) {
 	repo := new(MockRepository)
-	service := NewService(repo)
+	service := NewService(repo, testPublisher{})
 
 	// Create mock surveys
 	mockSurveys := &Surveys{
@@ -148,10 +242,13 @@ func TestLoad(t *testing.T) {
 	repo.On("Load").Return(mockSurveys, nil)
 
 	// Load the surveys
-	surveys, err := service.Load()
+	surveys, err := service.Load(testAdmin)
 
 	// Validate the results
 	assert.NoError(t, err, "Expected no error on load")
-	assert.Equal(t, mockSurveys, surveys, "Expected loaded surveys to match mock surveys")
+	assert.Len(t, surveys, len(*mockSurveys), "Expected every survey to be loaded")
+	for i, s := range surveys {
+		assert.Equal(t, (*mockSurveys)[i], s.Survey, "Expected loaded surveys to match mock surveys")
+	}
 	repo.AssertExpectations(t)
 }
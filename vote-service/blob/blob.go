@@ -0,0 +1,22 @@
+package blob
+
+import (
+	"errors"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+)
+
+// ErrUnknownStorage indicates that the configured blob storage is not supported
+var ErrUnknownStorage = errors.New("Unknown blob storage")
+
+// NewStorage creates a new blob storage of uploaded files based on the configuration
+// This function returns the storage selected by the configuration
+func NewStorage(cfg config.FileConfig) (vote.BlobStorage, error) {
+	switch cfg.Storage {
+	case "local":
+		return NewLocalStorage(cfg.Directory)
+	default:
+		return nil, ErrUnknownStorage
+	}
+}
@@ -0,0 +1,94 @@
+package blob
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+)
+
+// ErrInvalidKey indicates that a blob key could escape the storage directory
+var ErrInvalidKey = errors.New("Invalid blob key")
+
+// localStorage implements the vote.BlobStorage interface on the local filesystem
+type localStorage struct {
+	directory string
+}
+
+// NewLocalStorage creates a new blob storage keeping each blob in a file of a directory
+// This function creates the directory when it does not exist
+func NewLocalStorage(directory string) (vote.BlobStorage, error) {
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, err
+	}
+	return &localStorage{directory: directory}, nil
+}
+
+// Put writes content to the file of a key
+// The content is written to a temporary file first, so a failed upload never leaves a partial blob
+func (l *localStorage) Put(key string, content io.Reader, limit int64) (int64, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(l.directory, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	// Read one byte past the limit to tell a full blob from a longer one
+	n, err := io.Copy(tmp, io.LimitReader(content, limit+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n > limit {
+		return 0, vote.ErrFileTooLarge
+	}
+
+	return n, os.Rename(tmp.Name(), path)
+}
+
+// Open opens the file of a key
+func (l *localStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, vote.ErrFileNotFound
+	}
+	return f, err
+}
+
+// Delete removes the file of a key, deleting an unknown key is not an error
+func (l *localStorage) Delete(key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path returns the path of the file of a key
+// Keys are file names, so they cannot contain separators or refer to parent directories
+func (l *localStorage) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) || strings.HasPrefix(key, ".") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(l.directory, key), nil
+}
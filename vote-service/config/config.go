@@ -3,43 +3,64 @@ package config
 import (
 	"time"
 
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
 	"github.com/joeshaw/envdecode"
 )
 
 // Config stores complete application configuration
 // This struct holds the configuration for HTTP, RabbitMQ, Survey gRPC, and Postgres
 type Config struct {
-	HTTP       HTTPConfig
-	Rabbit     RabbitConfig
-	SurveyGrpc SurveyGrpcConfig
-	Postgres   PostgresConfig
+	HTTP        HTTPConfig
+	Rabbit      RabbitConfig
+	SurveyGrpc  SurveyGrpcConfig
+	SurveyCache SurveyCacheConfig
+	Postgres    PostgresConfig
+	Live        LiveConfig
+	Auth        AuthConfig
+	Guard       GuardConfig
+	RateLimit   RateLimitConfig
+	Session     SessionConfig
+	File        FileConfig
 }
 
 // HTTPConfig stores HTTP configuration
 // This struct holds the configuration for the HTTP server
 type HTTPConfig struct {
-	Hostname     string        `env:"HTTP_HOSTNAME"`                         // Hostname for the HTTP server
-	Port         uint16        `env:"HTTP_PORT,default=8082"`                // Port for the HTTP server
-	ReadTimeout  time.Duration `env:"HTTP_READ_TIMEOUT,default=5s"`          // Read timeout for the HTTP server
-	WriteTimeout time.Duration `env:"HTTP_WRITE_TIMEOUT,default=10s"`        // Write timeout for the HTTP server
-	IdleTimeout  time.Duration `env:"HTTP_IDLE_TIMEOUT,default=2m"`          // Idle timeout for the HTTP server
+	Hostname       string        `env:"HTTP_HOSTNAME"`                         // Hostname for the HTTP server
+	Port           uint16        `env:"HTTP_PORT,default=8082"`                // Port for the HTTP server
+	ReadTimeout    time.Duration `env:"HTTP_READ_TIMEOUT,default=5s"`          // Read timeout for the HTTP server
+	WriteTimeout   time.Duration `env:"HTTP_WRITE_TIMEOUT,default=10s"`        // Write timeout for the HTTP server
+	IdleTimeout    time.Duration `env:"HTTP_IDLE_TIMEOUT,default=2m"`          // Idle timeout for the HTTP server
+	ClientIPHeader string        `env:"HTTP_CLIENT_IP_HEADER"`                 // Header trusted proxies carry the client address in, such as X-Forwarded-For, the remote address is used when empty
+	TrustedProxies []string      `env:"HTTP_TRUSTED_PROXIES"`                  // Addresses or CIDR ranges of the trusted proxies, separated by semicolons
 }
 
 // RabbitConfig stores RabbitMQ configuration
 // This struct holds the configuration for RabbitMQ
 type RabbitConfig struct {
-	Hostname  string `env:"RABBITMQ_HOSTNAME,default=localhost"`             // Hostname for RabbitMQ
-	Port      uint16 `env:"RABBITMQ_PORT,default=5672"`                      // Port for RabbitMQ
-	Username  string `env:"RABBITMQ_USER,default=guest"`                     // Username for RabbitMQ
-	Password  string `env:"RABBITMQ_PASSWORD,default=guest"`                 // Password for RabbitMQ
-	QueueName string `env:"RABBITMQ_QUEUE,default=votes"`                    // Queue name for RabbitMQ
+	Hostname     string        `env:"RABBITMQ_HOSTNAME,default=localhost"`         // Hostname for RabbitMQ
+	Port         uint16        `env:"RABBITMQ_PORT,default=5672"`                  // Port for RabbitMQ
+	Username     string        `env:"RABBITMQ_USER,default=guest"`                 // Username for RabbitMQ
+	Password     string        `env:"RABBITMQ_PASSWORD,default=guest"`             // Password for RabbitMQ
+	QueueName    string        `env:"RABBITMQ_QUEUE,default=votes"`                // Queue name for RabbitMQ
+	Exchange     string        `env:"RABBITMQ_SURVEY_EXCHANGE,default=surveys"`    // Exchange survey change events are published to
+	ReconnectMin time.Duration `env:"RABBITMQ_RECONNECT_MIN,default=1s"`           // Initial wait before reconnecting to the survey events
+	ReconnectMax time.Duration `env:"RABBITMQ_RECONNECT_MAX,default=1m"`           // Maximum wait before reconnecting to the survey events
 }
 
 // SurveyGrpcConfig stores configuration to connect to the survey gRPC service
 // This struct holds the configuration for the survey gRPC service
 type SurveyGrpcConfig struct {
-	Hostname string `env:"SURVEY_GRPC_HOSTNAME,default=localhost"`           // Hostname for the survey gRPC service
-	Port     uint16 `env:"SURVEY_GRPC_PORT,default=9000"`                    // Port for the survey gRPC service
+	Hostname string        `env:"SURVEY_GRPC_HOSTNAME,default=localhost"`    // Hostname for the survey gRPC service
+	Port     uint16        `env:"SURVEY_GRPC_PORT,default=9000"`             // Port for the survey gRPC service
+	Timeout  time.Duration `env:"SURVEY_GRPC_TIMEOUT,default=2s"`            // Timeout for requests to the survey gRPC service
+}
+
+// SurveyCacheConfig stores configuration of the survey cache
+// This struct holds the limits of the cache of survey definitions used to validate votes
+type SurveyCacheConfig struct {
+	TTL  time.Duration `env:"SURVEY_CACHE_TTL,default=1m"`    // Time a survey is cached before it is fetched again
+	Size int           `env:"SURVEY_CACHE_SIZE,default=1000"` // Maximum number of cached surveys
 }
 
 // PostgresConfig stores Postgres configuration
@@ -56,7 +77,90 @@ type PostgresConfig struct {
 // PostgresTablesConfig stores Postgres tables configuration
 // This struct holds the table configuration for Postgres
 type PostgresTablesConfig struct {
-	Results string `env:"POSTGRES_TABLES_RESULTS,default=results"`           // Table name for results
+	Results  string `env:"POSTGRES_TABLES_RESULTS,default=results"`               // Table name for results
+	Votes    string `env:"POSTGRES_TABLES_VOTES,default=votes"`                   // Table name for votes
+	Audit    string `env:"POSTGRES_TABLES_AUDIT,default=vote_audit"`              // Table name for rejected vote audit records
+	Sessions string `env:"POSTGRES_TABLES_SESSIONS,default=sessions"`             // Table name for response sessions
+	Files    string `env:"POSTGRES_TABLES_FILES,default=files"`                   // Table name for uploaded file metadata
+	Replays  string `env:"POSTGRES_TABLES_REPLAYS,default=respondent_token_uses"` // Table name for respondent tokens used to answer questions
+}
+
+// LiveConfig stores live results configuration
+// This struct holds the configuration for the WebSocket live results endpoint
+type LiveConfig struct {
+	PollInterval   time.Duration `env:"LIVE_POLL_INTERVAL,default=2s"`                      // Interval between results refreshes for subscribed surveys
+	PingInterval   time.Duration `env:"LIVE_PING_INTERVAL,default=30s"`                     // Interval between heartbeat pings sent to clients
+	PongTimeout    time.Duration `env:"LIVE_PONG_TIMEOUT,default=60s"`                      // Time allowed to receive a pong before a client is dropped
+	WriteTimeout   time.Duration `env:"LIVE_WRITE_TIMEOUT,default=10s"`                     // Time allowed to write a message to a client
+	SendBuffer     int           `env:"LIVE_SEND_BUFFER,default=16"`                        // Number of pending messages buffered per client
+	MaxSurveys     int           `env:"LIVE_MAX_SURVEYS,default=20"`                        // Maximum number of surveys a single client can subscribe to
+	AllowedOrigins []string      `env:"LIVE_ALLOWED_ORIGINS,default=http://localhost:8080"` // Origins of the pages allowed to open live connections, separated by semicolons
+}
+
+// AuthConfig stores configuration of the JWT bearer authentication
+// This struct holds the token verification settings and which endpoints are public
+type AuthConfig struct {
+	KeySource    string        `env:"AUTH_KEY_SOURCE,default=hmac"`     // Source of token verification keys, hmac or jwks
+	Secret       string        `env:"AUTH_HMAC_SECRET"`                 // Shared secret used to verify HMAC signed tokens
+	JWKSFile     string        `env:"AUTH_JWKS_FILE"`                   // Path of a JWKS file with the public keys used to verify tokens
+	Issuer       string        `env:"AUTH_ISSUER"`                      // Required token issuer, not checked when empty
+	Audience     string        `env:"AUTH_AUDIENCE"`                    // Required token audience, not checked when empty
+	Leeway       time.Duration `env:"AUTH_LEEWAY,default=30s"`          // Allowed clock skew when validating token times
+	PublicReads  bool          `env:"AUTH_PUBLIC_READS,default=false"`  // Whether results of every survey can be read without a token, otherwise only by users who can view each survey
+	PublicVotes  bool          `env:"AUTH_PUBLIC_VOTES,default=true"`   // Whether votes can be cast without a token
+	KeyCacheTTL  time.Duration `env:"API_KEY_CACHE_TTL,default=30s"`    // How long API key verifications are cached, revocations take effect after at most this long
+	KeyCacheSize int           `env:"API_KEY_CACHE_SIZE,default=10000"` // Maximum number of cached API key verifications
+}
+
+// Tokens returns the settings used to verify bearer tokens
+func (c AuthConfig) Tokens() auth.Config {
+	return auth.Config{
+		KeySource: c.KeySource,
+		Secret:    c.Secret,
+		JWKSFile:  c.JWKSFile,
+		Issuer:    c.Issuer,
+		Audience:  c.Audience,
+		Leeway:    c.Leeway,
+	}
+}
+
+// GuardConfig stores configuration of the duplicate submission protection of anonymous votes
+// Anonymous votes need a respondent token when a secret is set, used tokens are shared by every instance through Postgres,
+// while the per client rate limits are counted by each instance and restart empty, a zero limit disables them
+// With several instances behind a load balancer a client can reach the limits on each of them, so they should be divided by the instances
+// Clients are identified by the address resolved from HTTP_CLIENT_IP_HEADER behind trusted proxies, and by their remote address otherwise
+type GuardConfig struct {
+	TokenSecret      string        `env:"RESPONDENT_TOKEN_SECRET"`           // Secret shared with the survey service to verify respondent tokens, tokens are not checked when empty
+	IPLimit          int           `env:"VOTE_IP_LIMIT,default=100"`         // Maximum anonymous votes per survey from one IP within the window
+	FingerprintLimit int           `env:"VOTE_FINGERPRINT_LIMIT,default=20"` // Maximum anonymous votes per survey from one fingerprint within the window
+	LimitWindow      time.Duration `env:"VOTE_LIMIT_WINDOW,default=1h"`      // Window of the per client rate limits
+}
+
+// RateLimitConfig stores configuration of the token bucket rate limits of the HTTP API
+// Clients are identified by user, API key or IP address, a zero rate disables the limit
+type RateLimitConfig struct {
+	Store        string  `env:"RATE_LIMIT_STORE,default=memory"`     // Store of the token buckets
+	VoteRate     float64 `env:"RATE_LIMIT_VOTE_RATE,default=5"`      // Requests per second allowed on /vote per client
+	VoteBurst    int     `env:"RATE_LIMIT_VOTE_BURST,default=20"`    // Requests a client can burst on /vote
+	ResultsRate  float64 `env:"RATE_LIMIT_RESULTS_RATE,default=10"`  // Requests per second allowed on /results per client
+	ResultsBurst int     `env:"RATE_LIMIT_RESULTS_BURST,default=20"` // Requests a client can burst on /results
+}
+
+// SessionConfig stores configuration of the save-and-resume response sessions
+// This struct holds how long unfinished sessions are kept and how often they are expired
+type SessionConfig struct {
+	TTL           time.Duration `env:"SESSION_TTL,default=72h"`            // Time a session is kept after answers were last saved
+	SweepInterval time.Duration `env:"SESSION_SWEEP_INTERVAL,default=10m"` // How often unfinished sessions are expired
+}
+
+// FileConfig stores configuration of the files uploaded to file questions
+// This struct holds where uploaded files are stored, how large they can be and how long orphaned files are kept
+type FileConfig struct {
+	Storage       string        `env:"FILE_STORAGE,default=local"`             // Storage of uploaded files
+	Directory     string        `env:"FILE_STORAGE_DIRECTORY,default=uploads"` // Directory of the local storage
+	MaxSize       int64         `env:"FILE_MAX_SIZE,default=10485760"`         // Maximum size of an uploaded file in bytes
+	Retention     time.Duration `env:"FILE_ORPHAN_RETENTION,default=96h"`      // Time an uploaded file is kept until a vote references it, longer than sessions are kept
+	SweepInterval time.Duration `env:"FILE_SWEEP_INTERVAL,default=1h"`         // How often orphaned files are deleted
 }
 
 // GetConfig loads and returns application configuration
@@ -0,0 +1,129 @@
+package guard
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims of the respondent tokens issued by the survey service
+const (
+	tokenIssuer   = "survey-service"
+	tokenAudience = "respondent"
+)
+
+// MinSecretLength is the minimum length in bytes of the secret respondent tokens are signed with
+const MinSecretLength = 32
+
+// ErrShortSecret indicates that the respondent token secret is too short to keep tokens from being forged
+var ErrShortSecret = fmt.Errorf("Respondent token secret must be at least %d bytes", MinSecretLength)
+
+// tokenGuard implements the vote.Guard interface with respondent tokens and per client rate limits
+// Used tokens are kept in the replay repository, shared by every instance, while the rate limits are counted in memory
+// by each instance and restart empty, so behind a load balancer a client can cast up to the limit on every instance
+type tokenGuard struct {
+	config       config.GuardConfig
+	parser       *jwt.Parser
+	replays      vote.ReplayRepository
+	ips          *windowCounter
+	fingerprints *windowCounter
+}
+
+// NewGuard creates a new guard of anonymous votes
+// This function initializes a guard verifying respondent tokens signed with the configured secret
+// Anonymous votes need a token whenever a secret is configured, and tokens are ignored without one
+func NewGuard(cfg config.GuardConfig, replays vote.ReplayRepository) (vote.Guard, error) {
+	if cfg.TokenSecret != "" && len(cfg.TokenSecret) < MinSecretLength {
+		return nil, ErrShortSecret
+	}
+
+	return &tokenGuard{
+		config: cfg,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithIssuer(tokenIssuer),
+			jwt.WithAudience(tokenAudience),
+			jwt.WithExpirationRequired(),
+		),
+		replays:      replays,
+		ips:          newWindowCounter(cfg.LimitWindow),
+		fingerprints: newWindowCounter(cfg.LimitWindow),
+	}, nil
+}
+
+// Check verifies the respondent token and rate limits of an anonymous vote
+// The token is only marked as used once the vote passed every other check
+func (g *tokenGuard) Check(v *vote.Vote) error {
+	claims, err := g.verifyToken(v)
+	if err != nil {
+		return err
+	}
+
+	if v.ClientIP != "" && !g.ips.Allow(v.Survey+"|"+v.ClientIP, g.config.IPLimit) {
+		return &vote.Rejection{Reason: vote.RejectionIPRateLimited}
+	}
+	if v.Fingerprint != "" && !g.fingerprints.Allow(v.Survey+"|"+v.Fingerprint, g.config.FingerprintLimit) {
+		return &vote.Rejection{Reason: vote.RejectionFingerprintRateLimited}
+	}
+
+	// A token answers each question of its survey once
+	if claims != nil {
+		fresh, err := g.replays.Use(replayKey(claims, v), claims.ExpiresAt.Unix())
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return &vote.Rejection{Reason: vote.RejectionReplay}
+		}
+	}
+
+	return nil
+}
+
+// Release releases the respondent token of a vote that could not be stored
+// Votes that passed the checks carry a valid token, votes checked without a secret have none to release
+func (g *tokenGuard) Release(v *vote.Vote) error {
+	claims, err := g.verifyToken(v)
+	if err != nil || claims == nil {
+		return err
+	}
+	return g.replays.Release(replayKey(claims, v))
+}
+
+// replayKey returns the key marking the question of a vote as answered with a respondent token
+func replayKey(claims *jwt.RegisteredClaims, v *vote.Vote) string {
+	return claims.ID + ":" + strconv.Itoa(v.Question)
+}
+
+// verifyToken verifies the respondent token of a vote
+// This method returns nil claims when no secret is configured, as tokens cannot be verified then
+func (g *tokenGuard) verifyToken(v *vote.Vote) (*jwt.RegisteredClaims, error) {
+	if g.config.TokenSecret == "" {
+		return nil, nil
+	}
+	if v.RespondentToken == "" {
+		return nil, &vote.Rejection{Reason: vote.RejectionMissingToken}
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	_, err := g.parser.ParseWithClaims(v.RespondentToken, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(g.config.TokenSecret), nil
+	})
+	if err != nil || claims.ID == "" {
+		return nil, &vote.Rejection{Reason: vote.RejectionInvalidToken}
+	}
+	if claims.Subject != v.Survey {
+		return nil, &vote.Rejection{Reason: vote.RejectionTokenSurveyMismatch}
+	}
+
+	return claims, nil
+}
+
+// now returns the current time, used by the replay and rate limit state
+func now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,61 @@
+package guard
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is the interval between removals of expired entries
+const sweepInterval = time.Minute
+
+// window is the count of a key within the current window
+type window struct {
+	start time.Time
+	count int
+}
+
+// windowCounter counts events per key in fixed windows
+// This struct implements the per client rate limits
+type windowCounter struct {
+	length    time.Duration
+	windows   map[string]*window
+	nextSweep time.Time
+	mutex     sync.Mutex
+}
+
+// newWindowCounter creates a new counter with windows of the given length
+func newWindowCounter(length time.Duration) *windowCounter {
+	return &windowCounter{length: length, windows: make(map[string]*window)}
+}
+
+// Allow counts an event of a key and checks it is within the limit of the current window
+// This method always allows events when the limit is zero
+func (c *windowCounter) Allow(key string, limit int) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t := now()
+	if t.After(c.nextSweep) {
+		for k, w := range c.windows {
+			if t.Sub(w.start) >= c.length {
+				delete(c.windows, k)
+			}
+		}
+		c.nextSweep = t.Add(sweepInterval)
+	}
+
+	w, ok := c.windows[key]
+	if !ok || t.Sub(w.start) >= c.length {
+		w = &window{start: t}
+		c.windows[key] = w
+	}
+	if w.count >= limit {
+		return false
+	}
+	w.count++
+	return true
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/go-chi/chi"
+)
+
+// GetFunnel handles get requests to get the completion funnel of a survey
+func (h *VoteHTTPHandler) GetFunnel(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("GET request received: GetFunnel")
+
+	funnel, err := h.service.GetFunnel(currentUser(r), id)
+	if err != nil {
+		h.resultsError(w, r, id, "funnel", err)
+		return
+	}
+
+	res, err := h.GetSerializer(r).EncodeFunnel(&funnel)
+	if err != nil {
+		h.log.Error().Str("id", id).Err(err).Msg("Unable to encode funnel")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, r, res, http.StatusOK)
+}
+
+// GetTimeSeries handles get requests to get the votes of a survey bucketed over time
+// The interval query parameter selects hour or day buckets, defaulting to day, and the optional
+// from and to query parameters limit the votes counted to a range of Unix timestamps
+func (h *VoteHTTPHandler) GetTimeSeries(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("GET request received: GetTimeSeries")
+
+	interval := vote.Interval(r.URL.Query().Get("interval"))
+	if interval == "" {
+		interval = vote.IntervalDay
+	}
+
+	from, fromErr := timestampParam(r, "from")
+	to, toErr := timestampParam(r, "to")
+	if fromErr != nil || toErr != nil {
+		h.log.Debug().Str("id", id).Msg("Invalid time series range")
+		h.Error(w, r, vote.ErrInvalidRequest.Error()+": from and to must be Unix timestamps", http.StatusBadRequest)
+		return
+	}
+
+	series, err := h.service.GetTimeSeries(currentUser(r), id, interval, from, to)
+	if err != nil {
+		h.resultsError(w, r, id, "time series", err)
+		return
+	}
+
+	res, err := h.GetSerializer(r).EncodeTimeSeries(&series)
+	if err != nil {
+		h.log.Error().Str("id", id).Err(err).Msg("Unable to encode time series")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, r, res, http.StatusOK)
+}
+
+// GetCrossTab handles get requests to get the contingency table of two questions of a survey
+// The row and col query parameters are the IDs of the questions
+func (h *VoteHTTPHandler) GetCrossTab(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("GET request received: GetCrossTab")
+
+	row, rowErr := strconv.Atoi(r.URL.Query().Get("row"))
+	col, colErr := strconv.Atoi(r.URL.Query().Get("col"))
+	if rowErr != nil || colErr != nil {
+		h.log.Debug().Str("id", id).Msg("Invalid cross-tabulation questions")
+		h.Error(w, r, vote.ErrInvalidRequest.Error()+": row and col must be question IDs", http.StatusBadRequest)
+		return
+	}
+
+	table, err := h.service.GetCrossTab(currentUser(r), id, row, col)
+	if err != nil {
+		h.resultsError(w, r, id, "cross-tabulation", err)
+		return
+	}
+
+	res, err := h.GetSerializer(r).EncodeCrossTab(&table)
+	if err != nil {
+		h.log.Error().Str("id", id).Err(err).Msg("Unable to encode cross-tabulation")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, r, res, http.StatusOK)
+}
+
+// timestampParam returns a Unix timestamp query parameter of a request, zero when it is missing
+func timestampParam(r *http.Request, name string) (int64, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/go-chi/chi"
+)
+
+// multipartMemory is the part of an upload kept in memory, the rest is buffered on disk
+const multipartMemory = 1 << 20
+
+// multipartOverhead is the room left in upload requests for the form fields around the file
+const multipartOverhead = 1 << 20
+
+// FileHTTPHandler handles HTTP requests for the files answering file questions
+type FileHTTPHandler struct {
+	*VoteHTTPHandler
+	files   vote.FileService
+	maxSize int64
+}
+
+// NewFileHTTPHandler creates a new uploaded file HTTP handler
+// This function initializes a new FileHTTPHandler rejecting upload requests larger than maxSize
+func NewFileHTTPHandler(h *VoteHTTPHandler, files vote.FileService, maxSize int64) *FileHTTPHandler {
+	return &FileHTTPHandler{
+		h,
+		files,
+		maxSize,
+	}
+}
+
+// Upload handles multipart post requests to upload a file for a file question
+// The form has a survey field, a question field and the file field
+func (h *FileHTTPHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	h.log.Info().Msg("POST request received: UploadFile")
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.Error(w, r, vote.ErrFileTooLarge.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.log.Debug().Err(err).Msg("Invalid file upload form")
+		h.Error(w, r, vote.ErrInvalidRequest.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	question, err := strconv.Atoi(r.FormValue("question"))
+	content, header, fileErr := r.FormFile("file")
+	if err != nil || fileErr != nil || r.FormValue("survey") == "" {
+		h.log.Debug().Msg("File upload without survey, question or file")
+		h.Error(w, r, vote.ErrInvalidRequest.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	defer content.Close()
+
+	f, err := h.files.Upload(currentUser(r), &vote.FileUpload{
+		Survey:      r.FormValue("survey"),
+		Question:    question,
+		Name:        header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Content:     content,
+	})
+	if err != nil {
+		h.fileError(w, r, err)
+		return
+	}
+
+	h.log.Info().Str("id", f.ID).Str("survey", f.Survey).Int64("size", f.Size).Msg("File uploaded")
+
+	res, err := h.GetSerializer(r).EncodeFile(f)
+	if err != nil {
+		h.log.Error().Str("id", f.ID).Err(err).Msg("Unable to encode file")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, r, res, http.StatusCreated)
+}
+
+// Download handles get requests to download an uploaded file
+// Files are sent as attachments so browsers never render them in the page of the service
+func (h *FileHTTPHandler) Download(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("GET request received: DownloadFile")
+
+	f, content, err := h.files.Download(currentUser(r), id)
+	if err != nil {
+		h.fileError(w, r, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", f.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(f.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": f.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content); err != nil {
+		h.log.Error().Str("id", id).Err(err).Msg("Unable to send file")
+	}
+}
+
+// fileError sends the HTTP error response of a file that could not be uploaded or downloaded
+// This method maps the errors of the file service to status codes
+func (h *FileHTTPHandler) fileError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, vote.ErrInvalidRequest) {
+		h.log.Debug().Err(err).Msg("Invalid file upload")
+		h.Error(w, r, err.Error(), http.StatusUnprocessableEntity)
+	} else if errors.Is(err, vote.ErrFileTooLarge) {
+		h.log.Debug().Msg("Uploaded file too large")
+		h.Error(w, r, err.Error(), http.StatusRequestEntityTooLarge)
+	} else if errors.Is(err, vote.ErrAuthenticationRequired) {
+		h.log.Debug().Msg("Anonymous upload on a survey requiring authentication")
+		h.Error(w, r, err.Error(), http.StatusUnauthorized)
+	} else if errors.Is(err, vote.ErrFileNotFound) || errors.Is(err, vote.ErrResultsNotFound) {
+		h.log.Debug().Err(err).Msg("File not found")
+		h.Error(w, r, vote.ErrFileNotFound.Error(), http.StatusNotFound)
+	} else if errors.Is(err, vote.ErrForbidden) {
+		h.log.Debug().Msg("Access to file forbidden")
+		h.Error(w, r, err.Error(), http.StatusForbidden)
+	} else if errors.Is(err, vote.ErrSurveyUnavailable) {
+		h.log.Error().Err(err).Msg("Unable to check file, survey service unavailable")
+		h.Error(w, r, vote.ErrSurveyUnavailable.Error(), http.StatusServiceUnavailable)
+	} else {
+		h.log.Error().Err(err).Msg("Unable to handle file")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
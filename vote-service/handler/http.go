@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/ratelimit"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/middleware"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
 	"github.com/go-chi/chi"
@@ -47,16 +49,16 @@ func (h *VoteHTTPHandler) Vote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Attach the client details checked for anonymous votes
+	v.ClientIP = clientIP(r)
+	v.Fingerprint = r.Header.Get(middleware.FingerprintHeader)
+	v.RespondentToken = r.Header.Get(middleware.RespondentTokenHeader)
+	v.InvitationToken = r.Header.Get(middleware.InvitationTokenHeader)
+
 	// Save the vote
-	err = h.service.Insert(v)
+	err = h.service.Insert(currentUser(r), v)
 	if err != nil {
-		if errors.Is(err, vote.ErrInvalidRequest) {
-			h.log.Debug().Err(err).Msg("Invalid vote data in POST")
-			h.Error(w, r, err.Error(), http.StatusUnprocessableEntity)
-		} else {
-			h.log.Error().Err(err).Msg("Unable to save vote")
-			h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		}
+		h.voteError(w, r, v, err)
 		return
 	}
 
@@ -73,24 +75,79 @@ func (h *VoteHTTPHandler) Vote(w http.ResponseWriter, r *http.Request) {
 	h.Response(w, r, res, http.StatusCreated)
 }
 
+// voteError sends the HTTP error response of a vote that could not be cast
+// This method maps the errors of the vote service to status codes
+func (h *VoteHTTPHandler) voteError(w http.ResponseWriter, r *http.Request, v *vote.Vote, err error) {
+	if errors.Is(err, vote.ErrInvalidRequest) {
+		h.log.Debug().Err(err).Msg("Invalid vote data in POST")
+		h.Error(w, r, err.Error(), http.StatusUnprocessableEntity)
+	} else if errors.Is(err, vote.ErrAuthenticationRequired) {
+		h.log.Debug().Str("survey", v.Survey).Msg("Anonymous vote on a survey requiring authentication")
+		h.Error(w, r, err.Error(), http.StatusUnauthorized)
+	} else if errors.Is(err, vote.ErrAlreadyVoted) {
+		h.log.Debug().Str("survey", v.Survey).Int("question", v.Question).Msg("Duplicate vote rejected")
+		h.Error(w, r, err.Error(), http.StatusConflict)
+	} else if errors.Is(err, vote.ErrInvitationRequired) || errors.Is(err, vote.ErrInvalidInvitation) {
+		h.log.Debug().Str("survey", v.Survey).Msg("Vote without a valid invitation rejected")
+		h.Error(w, r, err.Error(), http.StatusForbidden)
+	} else if errors.Is(err, vote.ErrInvitationUsed) {
+		h.log.Debug().Str("survey", v.Survey).Int("question", v.Question).Msg("Invitation reused for question")
+		h.Error(w, r, err.Error(), http.StatusConflict)
+	} else if errors.Is(err, vote.ErrRateLimited) {
+		h.log.Warn().Err(err).Str("survey", v.Survey).Str("ip", v.ClientIP).Msg("Anonymous vote rate limited")
+		h.Error(w, r, vote.ErrRateLimited.Error(), http.StatusTooManyRequests)
+	} else if errors.Is(err, vote.ErrVoteRejected) {
+		h.log.Warn().Err(err).Str("survey", v.Survey).Str("ip", v.ClientIP).Msg("Anonymous vote rejected")
+		h.Error(w, r, err.Error(), http.StatusForbidden)
+	} else if errors.Is(err, vote.ErrSurveyUnavailable) {
+		h.log.Error().Err(err).Msg("Unable to validate vote, survey service unavailable")
+		h.Error(w, r, vote.ErrSurveyUnavailable.Error(), http.StatusServiceUnavailable)
+	} else {
+		h.log.Error().Err(err).Msg("Unable to save vote")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
 // GetResults handles get requests to get results for a given survey
+// Each filter query parameter, such as 1.optionId=2 or 3.ratingValue>=4, narrows the results to
+// the respondents whose answers match it, and the dateGroup query parameter groups date answers by day, week or month
 func (h *VoteHTTPHandler) GetResults(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	h.log.Info().Str("id", id).Msg("GET request received: GetResults")
 
+	// Parse the segment filters
+	var filters []vote.Filter
+	for _, raw := range r.URL.Query()["filter"] {
+		f, err := vote.ParseFilter(raw)
+		if err != nil {
+			h.resultsError(w, r, id, "results", err)
+			return
+		}
+		filters = append(filters, f)
+	}
+
 	// Retrieve the results for the given survey ID
-	results, err := h.service.GetResults(id)
+	var results vote.Results
+	var err error
+	if len(filters) > 0 {
+		results, err = h.service.GetFilteredResults(currentUser(r), id, filters)
+	} else {
+		results, err = h.service.GetResults(currentUser(r), id)
+	}
 	if err != nil {
-		if errors.Is(err, vote.ErrResultsNotFound) {
-			h.log.Debug().Str("id", id).Msg("Invalid survey requested for results")
-			h.Error(w, r, err.Error(), http.StatusNotFound)
-		} else {
-			h.log.Error().Str("id", id).Err(err).Msg("Unable to load results")
-			h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		}
+		h.resultsError(w, r, id, "results", err)
 		return
 	}
 
+	// Regroup the date answers, which are counted per day
+	if g := r.URL.Query().Get("dateGroup"); g != "" {
+		results, err = vote.GroupDates(results, vote.DateGrouping(g))
+		if err != nil {
+			h.resultsError(w, r, id, "results", err)
+			return
+		}
+	}
+
 	// Encode the results to be returned
 	res, err := h.GetSerializer(r).EncodeResults(&results)
 	if err != nil {
@@ -102,6 +159,44 @@ func (h *VoteHTTPHandler) GetResults(w http.ResponseWriter, r *http.Request) {
 	h.Response(w, r, res, http.StatusOK)
 }
 
+// resultsError sends the HTTP error response of results that could not be loaded
+// This method maps the errors of the results of a survey and the reports derived from them
+func (h *VoteHTTPHandler) resultsError(w http.ResponseWriter, r *http.Request, id, what string, err error) {
+	if errors.Is(err, vote.ErrInvalidRequest) {
+		h.log.Debug().Err(err).Str("id", id).Msgf("Invalid %s request", what)
+		h.Error(w, r, err.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, vote.ErrResultsNotFound) {
+		h.log.Debug().Str("id", id).Msgf("Invalid survey requested for %s", what)
+		h.Error(w, r, err.Error(), http.StatusNotFound)
+	} else if errors.Is(err, vote.ErrForbidden) {
+		h.log.Debug().Str("id", id).Msgf("Access to %s forbidden", what)
+		h.Error(w, r, err.Error(), http.StatusForbidden)
+	} else if errors.Is(err, vote.ErrSurveyUnavailable) {
+		h.log.Error().Err(err).Str("id", id).Msgf("Unable to authorize %s, survey service unavailable", what)
+		h.Error(w, r, vote.ErrSurveyUnavailable.Error(), http.StatusServiceUnavailable)
+	} else {
+		h.log.Error().Str("id", id).Err(err).Msgf("Unable to load %s", what)
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// currentUser returns the vote user of the authenticated principal of a request
+// This function returns nil for anonymous requests
+func currentUser(r *http.Request) *vote.User {
+	p, ok := auth.FromContext(r.Context())
+	if !ok {
+		return nil
+	}
+	return &vote.User{ID: p.Subject, Roles: p.Roles}
+}
+
+// clientIP returns the IP address of the client of a request
+// Forwarding headers are only read from trusted proxies, since any client can set them
+// The address keys the per IP limits of anonymous votes, which each instance counts in memory
+func clientIP(r *http.Request) string {
+	return ratelimit.ClientIP(r)
+}
+
 // GetSerializer gets a serializer from the request, which is added via middleware
 func (h *VoteHTTPHandler) GetSerializer(r *http.Request) vote.Serializer {
 	return r.Context().Value(middleware.SerializerKey).(vote.Serializer)
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/live"
+)
+
+// LiveHTTPHandler handles WebSocket requests for live results
+type LiveHTTPHandler struct {
+	*VoteHTTPHandler
+	hub *live.Hub
+}
+
+// NewLiveHTTPHandler creates a new live results HTTP handler
+// This function initializes a new LiveHTTPHandler that serves clients from the given hub
+func NewLiveHTTPHandler(h *VoteHTTPHandler, hub *live.Hub) *LiveHTTPHandler {
+	return &LiveHTTPHandler{
+		h,
+		hub,
+	}
+}
+
+// Subscribe handles requests to open a live results WebSocket connection
+// Surveys listed in the "survey" query parameter are subscribed to immediately
+func (h *LiveHTTPHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	surveys := parseSurveyIDs(r.URL.Query()["survey"])
+	h.log.Info().Strs("surveys", surveys).Msg("GET request received: LiveResults")
+
+	err := h.hub.Serve(w, r, surveys, currentUser(r))
+	if err != nil {
+		if errors.Is(err, live.ErrTooManySurveys) {
+			h.log.Debug().Err(err).Msg("Too many surveys requested for live results")
+			h.Error(w, r, err.Error(), http.StatusBadRequest)
+		} else {
+			// The upgrader has already replied to the client
+			h.log.Debug().Err(err).Msg("Unable to upgrade live results connection")
+		}
+		return
+	}
+}
+
+// parseSurveyIDs splits comma separated survey IDs from query parameter values
+func parseSurveyIDs(values []string) []string {
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		for _, id := range strings.Split(v, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
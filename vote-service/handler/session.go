@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/middleware"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/go-chi/chi"
+)
+
+// SessionHTTPHandler handles HTTP requests for save-and-resume response sessions
+type SessionHTTPHandler struct {
+	*VoteHTTPHandler
+	sessions vote.SessionService
+}
+
+// NewSessionHTTPHandler creates a new response session HTTP handler
+// This function initializes a new SessionHTTPHandler that shares the responses of the vote handler
+func NewSessionHTTPHandler(h *VoteHTTPHandler, sessions vote.SessionService) *SessionHTTPHandler {
+	return &SessionHTTPHandler{
+		h,
+		sessions,
+	}
+}
+
+// Start handles post requests to start a response session
+func (h *SessionHTTPHandler) Start(w http.ResponseWriter, r *http.Request) {
+	h.log.Info().Msg("POST request received: StartSession")
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.log.Error().Err(err).Msg("Unable to read session POST body")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	req, err := h.GetSerializer(r).DecodeSessionRequest(requestBody)
+	if err != nil || req.Survey == "" {
+		h.log.Debug().Err(err).Msg("Invalid session POST body")
+		h.Error(w, r, vote.ErrInvalidRequest.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	session, err := h.sessions.StartSession(currentUser(r), req.Survey)
+	if err != nil {
+		h.sessionError(w, r, err)
+		return
+	}
+
+	h.log.Info().Str("id", session.ID).Str("survey", session.Survey).Msg("Session started")
+	h.session(w, r, session, http.StatusCreated)
+}
+
+// Resume handles get requests to resume a response session by token
+func (h *SessionHTTPHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	h.log.Info().Msg("GET request received: ResumeSession")
+
+	session, err := h.sessions.ResumeSession(currentUser(r), chi.URLParam(r, "token"))
+	if err != nil {
+		h.sessionError(w, r, err)
+		return
+	}
+
+	h.session(w, r, session, http.StatusOK)
+}
+
+// SaveAnswers handles put requests to save answers in a response session
+func (h *SessionHTTPHandler) SaveAnswers(w http.ResponseWriter, r *http.Request) {
+	h.log.Info().Msg("PUT request received: SaveAnswers")
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.log.Error().Err(err).Msg("Unable to read answers PUT body")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	answers, err := h.GetSerializer(r).DecodeAnswers(requestBody)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Invalid answers PUT body")
+		h.Error(w, r, vote.ErrInvalidRequest.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	session, err := h.sessions.SaveAnswers(currentUser(r), chi.URLParam(r, "token"), answers)
+	if err != nil {
+		h.sessionError(w, r, err)
+		return
+	}
+
+	h.log.Info().Str("id", session.ID).Int("answers", len(answers)).Msg("Session answers saved")
+	h.session(w, r, session, http.StatusOK)
+}
+
+// Finalize handles post requests to submit the answers of a response session as votes
+func (h *SessionHTTPHandler) Finalize(w http.ResponseWriter, r *http.Request) {
+	h.log.Info().Msg("POST request received: FinalizeSession")
+
+	// Attach the client details checked for anonymous votes
+	c := vote.Client{
+		IP:              clientIP(r),
+		Fingerprint:     r.Header.Get(middleware.FingerprintHeader),
+		RespondentToken: r.Header.Get(middleware.RespondentTokenHeader),
+		InvitationToken: r.Header.Get(middleware.InvitationTokenHeader),
+	}
+
+	session, err := h.sessions.FinalizeSession(currentUser(r), chi.URLParam(r, "token"), c)
+	if err != nil {
+		h.sessionError(w, r, err)
+		return
+	}
+
+	h.log.Info().Str("id", session.ID).Str("survey", session.Survey).Msg("Session finalized")
+	h.session(w, r, session, http.StatusOK)
+}
+
+// GetReport handles get requests to get the session completion report of a survey
+func (h *SessionHTTPHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	h.log.Info().Str("id", id).Msg("GET request received: GetSessionReport")
+
+	report, err := h.sessions.GetSessionReport(currentUser(r), id)
+	if err != nil {
+		h.resultsError(w, r, id, "session report", err)
+		return
+	}
+
+	res, err := h.GetSerializer(r).EncodeSessionReport(&report)
+	if err != nil {
+		h.log.Error().Str("id", id).Err(err).Msg("Unable to encode session report")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, r, res, http.StatusOK)
+}
+
+// session encodes and sends a response session
+func (h *SessionHTTPHandler) session(w http.ResponseWriter, r *http.Request, session *vote.Session, code int) {
+	res, err := h.GetSerializer(r).EncodeSession(session)
+	if err != nil {
+		h.log.Error().Str("id", session.ID).Err(err).Msg("Unable to encode session")
+		h.Error(w, r, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.Response(w, r, res, code)
+}
+
+// sessionError sends the HTTP error response of a failed session request
+// Errors of answers submitted as votes are mapped like the errors of casting a vote
+func (h *SessionHTTPHandler) sessionError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, vote.ErrSessionNotFound) {
+		h.log.Debug().Msg("Unknown session requested")
+		h.Error(w, r, err.Error(), http.StatusNotFound)
+	} else if errors.Is(err, vote.ErrSessionExpired) {
+		h.log.Debug().Msg("Expired session requested")
+		h.Error(w, r, err.Error(), http.StatusGone)
+	} else if errors.Is(err, vote.ErrSessionCompleted) {
+		h.log.Debug().Msg("Completed session changed")
+		h.Error(w, r, err.Error(), http.StatusConflict)
+	} else if errors.Is(err, vote.ErrSessionConflict) {
+		h.log.Debug().Msg("Session changed by concurrent requests")
+		h.Error(w, r, err.Error(), http.StatusConflict)
+	} else {
+		h.voteError(w, r, &vote.Vote{ClientIP: clientIP(r)}, err)
+	}
+}
@@ -0,0 +1,128 @@
+package live
+
+import (
+	"sync"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+)
+
+// client is a single WebSocket connection subscribed to live results
+type client struct {
+	hub  *Hub
+	conn *websocket.Conn
+	cfg  config.LiveConfig
+	log  *zerolog.Logger
+	user *vote.User // Authenticated user of the connection, nil when anonymous
+
+	send chan Message  // Buffered outgoing messages
+	done chan struct{} // Closed when the client is disconnected
+	once sync.Once
+}
+
+// newClient creates a client for an upgraded WebSocket connection
+func newClient(h *Hub, conn *websocket.Conn, user *vote.User) *client {
+	return &client{
+		hub:  h,
+		conn: conn,
+		cfg:  h.cfg,
+		log:  h.log,
+		user: user,
+		send: make(chan Message, h.cfg.SendBuffer),
+		done: make(chan struct{}),
+	}
+}
+
+// enqueue queues a message for the client without blocking
+// A client whose buffer is full cannot keep up with the updates and is disconnected,
+// so a single slow consumer never delays the delivery to other subscribers
+func (c *client) enqueue(m Message) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case c.send <- m:
+		return true
+	default:
+		c.log.Warn().Str("remote", c.conn.RemoteAddr().String()).Msg("Live results client too slow, disconnecting")
+		c.close()
+		return false
+	}
+}
+
+// close removes the client from the hub and signals the write pump to disconnect it
+func (c *client) close() {
+	c.once.Do(func() {
+		close(c.done)
+		c.hub.remove(c)
+	})
+}
+
+// readPump reads subscription requests from the client until the connection fails
+// Each pong received from the client extends the read deadline
+func (c *client) readPump() {
+	defer c.close()
+
+	c.conn.SetReadLimit(4096)
+	c.conn.SetReadDeadline(time.Now().Add(c.cfg.PongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(c.cfg.PongTimeout))
+	})
+
+	for {
+		var req Request
+		if err := c.conn.ReadJSON(&req); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				c.log.Debug().Err(err).Msg("Live results client disconnected")
+			}
+			return
+		}
+
+		switch req.Action {
+		case ActionSubscribe:
+			if err := c.hub.Subscribe(c, req.Surveys); err != nil {
+				c.enqueue(Message{Type: MessageTypeError, Error: err.Error()})
+			}
+		case ActionUnsubscribe:
+			c.hub.Unsubscribe(c, req.Surveys)
+		default:
+			c.enqueue(Message{Type: MessageTypeError, Error: ErrInvalidAction.Error()})
+		}
+	}
+}
+
+// writePump writes queued messages and heartbeat pings to the client
+func (c *client) writePump() {
+	ticker := time.NewTicker(c.cfg.PingInterval)
+	defer func() {
+		ticker.Stop()
+		c.close()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case m := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
+			if err := c.conn.WriteJSON(m); err != nil {
+				c.log.Debug().Err(err).Msg("Unable to write to live results client")
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-c.done:
+			c.conn.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
+			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
+}
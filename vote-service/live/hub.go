@@ -0,0 +1,327 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+)
+
+var (
+	// ErrInvalidAction indicates that a client sent an unknown action
+	ErrInvalidAction = errors.New("Invalid live results action")
+
+	// ErrTooManySurveys indicates that a client subscribed to more surveys than allowed
+	ErrTooManySurveys = errors.New("Too many survey subscriptions")
+)
+
+// Hub tracks live results subscriptions and pushes result changes to subscribed clients
+// Results are refreshed periodically for every survey that has at least one subscriber
+type Hub struct {
+	service  vote.Service
+	cfg      config.LiveConfig
+	log      *zerolog.Logger
+	upgrader websocket.Upgrader
+
+	mutex       sync.RWMutex
+	clients     map[*client]struct{}            // All connected clients
+	subscribers map[string]map[*client]struct{} // Clients subscribed to each survey
+	snapshots   map[string]vote.Results         // Last known results of each subscribed survey
+}
+
+// NewHub creates a new live results hub
+// This function initializes a hub that reads results from the given service
+func NewHub(service vote.Service, cfg config.LiveConfig, log *zerolog.Logger) *Hub {
+	return &Hub{
+		service: service,
+		cfg:     cfg,
+		log:     log,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Browsers send cookies and tokens cross-site, and CORS does not apply to upgrades
+			CheckOrigin: func(r *http.Request) bool { return allowedOrigin(r, cfg.AllowedOrigins) },
+		},
+		clients:     make(map[*client]struct{}),
+		subscribers: make(map[string]map[*client]struct{}),
+		snapshots:   make(map[string]vote.Results),
+	}
+}
+
+// allowedOrigin checks the origin of a WebSocket upgrade request against the allowed origins
+// Requests without an origin do not come from browsers, and * allows every origin
+func allowedOrigin(r *http.Request, allowed []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, a := range allowed {
+		if a == "*" || strings.EqualFold(strings.TrimSuffix(strings.TrimSpace(a), "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Serve upgrades an HTTP request to a WebSocket connection and starts serving the client
+// The client is subscribed to the given surveys before any message is read
+func (h *Hub) Serve(w http.ResponseWriter, r *http.Request, surveys []string, user *vote.User) error {
+	if len(surveys) > h.cfg.MaxSurveys {
+		return ErrTooManySurveys
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+
+	c := newClient(h, conn, user)
+	h.mutex.Lock()
+	h.clients[c] = struct{}{}
+	h.mutex.Unlock()
+
+	// Subscriptions of a client are only changed by its read pump once the initial ones are done
+	go c.writePump()
+	if len(surveys) > 0 {
+		if err := h.Subscribe(c, surveys); err != nil {
+			c.enqueue(Message{Type: MessageTypeError, Error: err.Error()})
+		}
+	}
+	go c.readPump()
+
+	return nil
+}
+
+// Run refreshes the results of subscribed surveys until the context is cancelled
+func (h *Hub) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.closeAll()
+			return
+		case <-ticker.C:
+			for _, id := range h.surveys() {
+				h.refresh(id)
+			}
+		}
+	}
+}
+
+// Subscribe subscribes a client to the given surveys and sends it a snapshot of each
+// The client must be allowed to view the results of every survey, otherwise none are subscribed
+func (h *Hub) Subscribe(c *client, surveys []string) error {
+	h.mutex.RLock()
+	count := 0
+	for _, clients := range h.subscribers {
+		if _, ok := clients[c]; ok {
+			count++
+		}
+	}
+
+	// Work out which surveys are new before subscribing to any of them
+	added := make([]string, 0, len(surveys))
+	for _, id := range surveys {
+		if _, ok := h.subscribers[id][c]; ok || id == "" || contains(added, id) {
+			continue
+		}
+		added = append(added, id)
+	}
+	h.mutex.RUnlock()
+
+	if count+len(added) > h.cfg.MaxSurveys {
+		return fmt.Errorf("%w: at most %d surveys allowed", ErrTooManySurveys, h.cfg.MaxSurveys)
+	}
+
+	// Load the current results as the client, so deltas can be applied on top of them
+	snapshots := make(map[string]vote.Results, len(added))
+	for _, id := range added {
+		results, err := h.load(c.user, id)
+		if errors.Is(err, vote.ErrForbidden) {
+			return fmt.Errorf("%w: %s", err, id)
+		}
+		if err != nil {
+			h.log.Error().Err(err).Str("id", id).Msg("Unable to load results for live subscription")
+			continue
+		}
+		snapshots[id] = results
+	}
+
+	h.mutex.Lock()
+	for _, id := range added {
+		if h.subscribers[id] == nil {
+			h.subscribers[id] = make(map[*client]struct{})
+		}
+		h.subscribers[id][c] = struct{}{}
+
+		if _, ok := h.snapshots[id]; !ok {
+			if results, ok := snapshots[id]; ok {
+				h.snapshots[id] = results
+			}
+		}
+	}
+	h.mutex.Unlock()
+
+	for _, id := range added {
+		if results, ok := snapshots[id]; ok {
+			c.enqueue(Message{Type: MessageTypeSnapshot, Snapshot: &results})
+		}
+	}
+
+	return nil
+}
+
+// Unsubscribe removes the subscriptions of a client to the given surveys
+func (h *Hub) Unsubscribe(c *client, surveys []string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, id := range surveys {
+		h.unsubscribe(c, id)
+	}
+}
+
+// remove removes a client and all of its subscriptions
+func (h *Hub) remove(c *client) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delete(h.clients, c)
+	for id := range h.subscribers {
+		h.unsubscribe(c, id)
+	}
+}
+
+// unsubscribe removes a single subscription, forgetting surveys nobody watches
+// The caller must hold the hub lock
+func (h *Hub) unsubscribe(c *client, id string) {
+	clients, ok := h.subscribers[id]
+	if !ok {
+		return
+	}
+
+	delete(clients, c)
+	if len(clients) == 0 {
+		delete(h.subscribers, id)
+		delete(h.snapshots, id)
+	}
+}
+
+// surveys returns the IDs of all surveys with at least one subscriber
+func (h *Hub) surveys() []string {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	ids := make([]string, 0, len(h.subscribers))
+	for id := range h.subscribers {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// refresh loads the results of a survey and broadcasts the changes to its subscribers
+func (h *Hub) refresh(id string) {
+	results, err := h.load(vote.SystemUser(), id)
+	if err != nil {
+		h.log.Error().Err(err).Str("id", id).Msg("Unable to refresh live results")
+		return
+	}
+
+	h.mutex.Lock()
+	previous, ok := h.snapshots[id]
+	if !ok {
+		// Nobody is subscribed anymore
+		h.mutex.Unlock()
+		return
+	}
+	h.snapshots[id] = results
+
+	clients := make([]*client, 0, len(h.subscribers[id]))
+	for c := range h.subscribers[id] {
+		clients = append(clients, c)
+	}
+	h.mutex.Unlock()
+
+	delta := diff(previous, results)
+	if delta == nil {
+		return
+	}
+
+	// Messages are queued outside the lock as slow clients are removed from the hub
+	for _, c := range clients {
+		c.enqueue(Message{Type: MessageTypeDelta, Delta: delta})
+	}
+}
+
+// load loads the results of a survey as a user, treating a survey without votes as empty results
+// Uploaded files are left out, as changes are pushed to every subscriber whatever files they can download
+func (h *Hub) load(user *vote.User, id string) (vote.Results, error) {
+	results, err := h.service.GetResults(user, id)
+	if errors.Is(err, vote.ErrResultsNotFound) {
+		return vote.Results{Survey: id}, nil
+	}
+	for i := range results.Results {
+		results.Results[i].Files = nil
+	}
+	return results, err
+}
+
+// closeAll disconnects every client
+func (h *Hub) closeAll() {
+	h.mutex.RLock()
+	clients := make([]*client, 0, len(h.clients))
+	for c := range h.clients {
+		clients = append(clients, c)
+	}
+	h.mutex.RUnlock()
+
+	for _, c := range clients {
+		c.close()
+	}
+}
+
+// diff returns the question results that changed between two versions of the results
+// This function returns nil when nothing changed
+func diff(previous, current vote.Results) *vote.ResultsDelta {
+	known := make(map[int]vote.QuestionResults, len(previous.Results))
+	for _, qr := range previous.Results {
+		known[qr.Question] = qr
+	}
+
+	delta := &vote.ResultsDelta{
+		Survey:    current.Survey,
+		UpdatedAt: current.UpdatedAt,
+	}
+	for _, qr := range current.Results {
+		if old, ok := known[qr.Question]; ok && reflect.DeepEqual(old, qr) {
+			continue
+		}
+		delta.Results = append(delta.Results, qr)
+	}
+
+	if len(delta.Results) == 0 {
+		return nil
+	}
+	return delta
+}
+
+// contains reports whether a survey ID is in the given list
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package live
+
+import (
+	"testing"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDiff tests that only the question results that changed are pushed
+func TestDiff(t *testing.T) {
+	previous := vote.Results{Survey: "survey-1", UpdatedAt: 10, Results: []vote.QuestionResults{
+		{Question: 1, TotalVotes: 2},
+		{Question: 2, TotalVotes: 1, OptionResults: []vote.OptionResult{{OptionID: 1, Count: 1, Percentage: 100}}},
+	}}
+
+	assert.Nil(t, diff(previous, previous), "Expected no delta when nothing changed")
+
+	current := vote.Results{Survey: "survey-1", UpdatedAt: 20, Results: []vote.QuestionResults{
+		{Question: 1, TotalVotes: 2},
+		{Question: 2, TotalVotes: 2, OptionResults: []vote.OptionResult{{OptionID: 1, Count: 1, Percentage: 50}, {OptionID: 2, Count: 1, Percentage: 50}}},
+		{Question: 3, TotalVotes: 1},
+	}}
+	assert.Equal(t, &vote.ResultsDelta{
+		Survey:    "survey-1",
+		UpdatedAt: 20,
+		Results:   current.Results[1:],
+	}, diff(previous, current), "Expected the changed and new questions")
+
+	assert.Equal(t, &vote.ResultsDelta{
+		Survey:    "survey-1",
+		UpdatedAt: 10,
+		Results:   previous.Results,
+	}, diff(vote.Results{}, previous), "Expected every question of the first results")
+}
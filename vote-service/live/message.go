@@ -0,0 +1,36 @@
+package live
+
+import "github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+
+// Action defines an action requested by a live results client
+type Action string
+
+// Available client actions
+const (
+	ActionSubscribe   Action = "subscribe"   // Subscribe to one or more surveys
+	ActionUnsubscribe Action = "unsubscribe" // Unsubscribe from one or more surveys
+)
+
+// MessageType defines the type of a message sent to a live results client
+type MessageType string
+
+// Available message types
+const (
+	MessageTypeSnapshot MessageType = "snapshot" // Full results of a survey, sent on subscription
+	MessageTypeDelta    MessageType = "delta"    // Results of the questions that changed
+	MessageTypeError    MessageType = "error"    // Error caused by a client request
+)
+
+// Request describes a message received from a live results client
+type Request struct {
+	Action  Action   `json:"action"`  // Action to perform
+	Surveys []string `json:"surveys"` // Survey IDs the action applies to
+}
+
+// Message describes a message sent to a live results client
+type Message struct {
+	Type     MessageType        `json:"type"`               // Type of the message
+	Snapshot *vote.Results      `json:"snapshot,omitempty"` // Full results for snapshot messages
+	Delta    *vote.ResultsDelta `json:"delta,omitempty"`    // Changed results for delta messages
+	Error    string             `json:"error,omitempty"`    // Error description for error messages
+}
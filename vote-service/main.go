@@ -11,9 +11,15 @@ import (
 	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
 	"google.golang.org/grpc"
 
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/ratelimit"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/blob"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/guard"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/handler"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/live"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/logger"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/queue"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/repository"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/router"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/serializer"
@@ -57,12 +63,138 @@ func main() {
 	cli := protos.NewSurveyClient(conn)
 	log.Info().Str("on", grpcAddr).Msg("Connected to survey gRPC service")
 
+	// Cache surveys loaded from the survey service
+	surveys := repository.NewCachedSurveyRepository(repository.NewGrpcSurveyRepository(cli, cfg.SurveyGrpc), cfg.SurveyCache)
+
+	// Invalidate cached surveys when they change, reconnecting to the survey events when they drop
+	ec := make(chan *vote.SurveyEvent)
+	eventCtx, stopEvents := context.WithCancel(context.Background())
+	go queue.ConsumeWithRetry(eventCtx, queue.NewRabbitSurveyEventQueue(cfg.Rabbit, sz, &log), ec, cfg.Rabbit.ReconnectMin, cfg.Rabbit.ReconnectMax, &log)
+	go func() {
+		for e := range ec {
+			surveys.Invalidate(e.Survey)
+			log.Debug().Str("id", e.Survey).Str("type", string(e.Type)).Msg("Cached survey invalidated")
+		}
+	}()
+
+	// Load the audit repository of rejected anonymous votes
+	audit, err := repository.NewPostgresAuditRepository(cfg.Postgres)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot connect to audit repository")
+		os.Exit(1)
+	}
+
+	// Load the guard protecting anonymous surveys from ballot stuffing
+	replays, err := repository.NewPostgresReplayRepository(cfg.Postgres)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot connect to replay repository")
+		os.Exit(1)
+	}
+	g, err := guard.NewGuard(cfg.Guard, replays)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load anonymous vote guard")
+		os.Exit(1)
+	}
+
+	// Load the metadata repository and the blob storage of uploaded files
+	fileRepo, err := repository.NewPostgresFileRepository(cfg.Postgres)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot connect to file repository")
+		os.Exit(1)
+	}
+	blobs, err := blob.NewStorage(cfg.File)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load file storage")
+		os.Exit(1)
+	}
+
 	// Load the service
-	service := vote.NewService(writer, results, cli)
+	invitations := repository.NewGrpcInvitationRepository(cli, cfg.SurveyGrpc)
+	service := vote.NewService(writer, results, surveys, g, audit, invitations, fileRepo, cfg.Auth.PublicReads)
+	files := vote.NewFileService(fileRepo, blobs, surveys, cfg.File.MaxSize, cfg.File.Retention)
+
+	// Load the response sessions saved by respondents
+	sessionRepo, err := repository.NewPostgresSessionRepository(cfg.Postgres)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot connect to session repository")
+		os.Exit(1)
+	}
+	sessions := vote.NewSessionService(service, surveys, sessionRepo, cfg.Session.TTL, cfg.Auth.PublicReads)
+
+	// Periodically expire unfinished sessions, reported as partial completions
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(cfg.Session.SweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sweepCtx.Done():
+				return
+			case <-ticker.C:
+				expired, err := sessions.ExpireSessions()
+				if err != nil {
+					log.Error().Err(err).Msg("Unable to expire sessions")
+				} else if expired > 0 {
+					log.Info().Int("count", expired).Msg("Expired unfinished sessions")
+				}
+			}
+		}
+	}()
+
+	// Periodically delete uploaded files that no vote references
+	go func() {
+		ticker := time.NewTicker(cfg.File.SweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sweepCtx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := files.DeleteOrphans()
+				if err != nil {
+					log.Error().Err(err).Msg("Unable to delete orphaned files")
+				}
+				if deleted > 0 {
+					log.Info().Int("count", deleted).Msg("Deleted orphaned files")
+				}
+			}
+		}
+	}()
+
+	// Start the live results hub
+	hubCtx, stopHub := context.WithCancel(context.Background())
+	hub := live.NewHub(service, cfg.Live, &log)
+	go hub.Run(hubCtx)
+
+	// Load the authenticator of vote and results requests
+	keys, err := auth.NewKeySource(cfg.Auth.Tokens())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load authentication keys")
+		os.Exit(1)
+	}
+	authenticator := auth.NewAuthenticator(keys, cfg.Auth.Tokens())
+
+	// Load the store of the HTTP rate limits
+	limits, err := ratelimit.NewStore(cfg.RateLimit.Store)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load rate limit store")
+		os.Exit(1)
+	}
+
+	// Resolve client addresses from the header set by the trusted proxies
+	clients, err := ratelimit.NewClientResolver(cfg.HTTP.ClientIPHeader, cfg.HTTP.TrustedProxies)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot load trusted proxies")
+		os.Exit(1)
+	}
 
 	// Load HTTP dependencies
 	httpHandler := handler.NewVoteHTTPHandler(service, &log)
-	httpRouter := router.NewRouter(httpHandler)
+	liveHandler := handler.NewLiveHTTPHandler(httpHandler, hub)
+	sessionHandler := handler.NewSessionHTTPHandler(httpHandler, sessions)
+	fileHandler := handler.NewFileHTTPHandler(httpHandler, files, cfg.File.MaxSize)
+	keyVerifier := auth.NewCachedKeyVerifier(repository.NewGrpcKeyVerifier(cli, cfg.SurveyGrpc), cfg.Auth.KeyCacheTTL, cfg.Auth.KeyCacheSize)
+	httpRouter := router.NewRouter(httpHandler, liveHandler, sessionHandler, fileHandler, authenticator, keyVerifier, cfg.Auth, limits, clients, cfg.RateLimit)
 	httpServer := server.NewHTTPServer(httpRouter, cfg.HTTP)
 
 	// Start the HTTP server
@@ -86,5 +218,8 @@ func main() {
 	// Gracefully shutdown the server allowing up to 30 seconds
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
+	stopHub()
+	stopSweep()
+	stopEvents()
 	httpServer.Shutdown(ctx)
 }
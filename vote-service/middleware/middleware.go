@@ -14,6 +14,13 @@ type key int
 // SerializerKey is used as a key to store the serializer in the context
 const SerializerKey key = 0
 
+// Request headers identifying the client of anonymous votes and invited respondents
+const (
+	FingerprintHeader     = "X-Client-Fingerprint" // Fingerprint computed by the client
+	RespondentTokenHeader = "X-Respondent-Token"   // Respondent token issued by the survey service
+	InvitationTokenHeader = "X-Invitation-Token"   // Invitation token of invite-only surveys
+)
+
 var (
 	// serializers stores instances of different serializers
 	serializers      = make(map[string]vote.Serializer)
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRateLimitRequest creates a request from an IP address, authenticated as the principal when not nil
+func newRateLimitRequest(ip, apiKey string, p *auth.Principal) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/results/1", nil)
+	r.RemoteAddr = ip + ":1234"
+	if apiKey != "" {
+		r.Header.Set(APIKeyHeader, apiKey)
+	}
+	if p != nil {
+		r = r.WithContext(auth.NewContext(r.Context(), p))
+	}
+	return r
+}
+
+// TestRateLimit tests that requests beyond the burst of a client are rejected with a Retry-After header
+func TestRateLimit(t *testing.T) {
+	h := RateLimit(ratelimit.NewMemoryStore(), "results", ratelimit.Limit{Rate: 1, Burst: 2})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Changing the API key header must not give an unauthenticated client a new bucket
+	for i, key := range []string{"a", "b"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newRateLimitRequest("10.0.0.1", key, nil))
+		assert.Equal(t, http.StatusOK, w.Code, "Expected request %d within the burst to pass", i)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitRequest("10.0.0.1", "c", nil))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code, "Expected requests beyond the burst to be throttled")
+	assert.Equal(t, "1", w.Header().Get("Retry-After"), "Expected to wait a second for the next token")
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitRequest("10.0.0.2", "", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "Expected other IP addresses to have their own bucket")
+
+	disabled := RateLimit(ratelimit.NewMemoryStore(), "results", ratelimit.Limit{})(http.NotFoundHandler())
+	for i := 0; i < 10; i++ {
+		w = httptest.NewRecorder()
+		disabled.ServeHTTP(w, newRateLimitRequest("10.0.0.1", "", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, "Expected a disabled limit to pass every request")
+	}
+}
@@ -0,0 +1,40 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/rs/zerolog"
+)
+
+// SurveyEventQueue contains functions to receive survey change events from a queue
+// This interface defines the methods required for consuming survey events
+type SurveyEventQueue interface {
+	// Consume consumes survey events and passes them through a channel to be processed
+	// This method blocks until the queue connection is lost
+	Consume(ec chan<- *vote.SurveyEvent) error
+}
+
+// ConsumeWithRetry consumes survey events until the context is cancelled, reconnecting whenever the queue connection is lost
+// Reconnects wait minWait, doubled after every failed attempt up to maxWait, and reset once a connection lasted longer than maxWait
+func ConsumeWithRetry(ctx context.Context, q SurveyEventQueue, ec chan<- *vote.SurveyEvent, minWait, maxWait time.Duration, log *zerolog.Logger) {
+	wait := minWait
+	for {
+		start := time.Now()
+		err := q.Consume(ec)
+		if time.Since(start) > maxWait {
+			wait = minWait
+		}
+		log.Error().Err(err).Dur("retry", wait).Msg("Survey events unavailable, cached surveys expire by TTL until reconnected")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		if wait *= 2; wait > maxWait {
+			wait = maxWait
+		}
+	}
+}
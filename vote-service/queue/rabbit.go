@@ -0,0 +1,108 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/rs/zerolog"
+	"github.com/streadway/amqp"
+)
+
+// ErrQueueClosed indicates that the queue connection was closed
+var ErrQueueClosed = errors.New("Queue closed")
+
+type rabbitSurveyEventQueue struct {
+	config     config.RabbitConfig
+	serializer vote.Serializer
+	log        *zerolog.Logger
+}
+
+// NewRabbitSurveyEventQueue creates a new RabbitMQ survey event queue
+// This function initializes and returns a new instance of rabbitSurveyEventQueue
+func NewRabbitSurveyEventQueue(cfg config.RabbitConfig, sz vote.Serializer, l *zerolog.Logger) SurveyEventQueue {
+	return &rabbitSurveyEventQueue{
+		config:     cfg,
+		serializer: sz,
+		log:        l,
+	}
+}
+
+// Consume consumes survey events from the RabbitMQ survey exchange
+// Every instance gets its own temporary queue so that each one sees all events
+func (r *rabbitSurveyEventQueue) Consume(ec chan<- *vote.SurveyEvent) error {
+	addr := fmt.Sprintf("amqp://%s:%s@%s:%d/", r.config.Username, r.config.Password, r.config.Hostname, r.config.Port)
+	conn, err := amqp.Dial(addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	q, err := r.declare(ch)
+	if err != nil {
+		return err
+	}
+
+	msgs, err := ch.Consume(
+		q.Name,
+		"",
+		true,  // Auto-ack
+		true,  // Exclusive
+		false, // No-local
+		false, // No-wait
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	r.log.Info().Str("exchange", r.config.Exchange).Msg("Listening for survey events")
+
+	for msg := range msgs {
+		e, err := r.serializer.DecodeSurveyEvent(msg.Body)
+		if err != nil {
+			r.log.Error().Err(err).Str("body", string(msg.Body)).Msg("Unable to parse survey event")
+			continue
+		}
+		ec <- e
+	}
+
+	return ErrQueueClosed
+}
+
+// declare declares the survey exchange and a temporary queue bound to all of its events
+func (r *rabbitSurveyEventQueue) declare(ch *amqp.Channel) (amqp.Queue, error) {
+	err := ch.ExchangeDeclare(
+		r.config.Exchange,
+		amqp.ExchangeTopic,
+		true,  // Durable
+		false, // Auto-deleted
+		false, // Internal
+		false, // No-wait
+		nil,
+	)
+	if err != nil {
+		return amqp.Queue{}, err
+	}
+
+	q, err := ch.QueueDeclare(
+		"",
+		false, // Durable
+		true,  // Delete when unused
+		true,  // Exclusive
+		false, // No-wait
+		nil,
+	)
+	if err != nil {
+		return q, err
+	}
+
+	return q, ch.QueueBind(q.Name, "survey.#", r.config.Exchange, false, nil)
+}
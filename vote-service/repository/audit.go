@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/jackc/pgx/v4"
+	uuid "github.com/satori/go.uuid"
+)
+
+// postgresAuditRepository implements the vote.AuditRepository interface using PostgreSQL
+type postgresAuditRepository struct {
+	config     config.PostgresConfig
+	connection *pgx.Conn
+	mutex      sync.Mutex // A single connection cannot run concurrent queries
+}
+
+// NewPostgresAuditRepository creates a new Postgres audit repository of rejected votes
+// This function initializes a new PostgreSQL connection and returns a repository instance
+func NewPostgresAuditRepository(cfg config.PostgresConfig) (vote.AuditRepository, error) {
+	conn, err := ConnectToPostgres(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresAuditRepository{config: cfg, connection: conn}, nil
+}
+
+// Record stores the rejection of a vote in the PostgreSQL database
+func (p *postgresAuditRepository) Record(v *vote.Vote, reason vote.RejectionReason) error {
+	q := fmt.Sprintf("INSERT INTO %s (id, survey, question, reason, client_ip, fingerprint, created) VALUES ($1, $2, $3, $4, $5, $6, $7)", p.config.Tables.Audit)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	_, err := p.connection.Exec(context.Background(), q,
+		uuid.NewV4().String(),
+		v.Survey,
+		v.Question,
+		string(reason),
+		v.ClientIP,
+		v.Fingerprint,
+		time.Now().UTC().Unix(),
+	)
+	return err
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+)
+
+// cachedSurvey is a survey held by the cache
+type cachedSurvey struct {
+	id        string
+	survey    *protos.SurveyResponse
+	expiresAt time.Time
+}
+
+// cachedSurveyRepository implements the vote.SurveyCache interface with a TTL bounded LRU cache
+type cachedSurveyRepository struct {
+	next    vote.SurveyRepository
+	config  config.SurveyCacheConfig
+	entries map[string]*list.Element
+	order   *list.List // Most recently used surveys at the front
+	mutex   sync.Mutex
+}
+
+// NewCachedSurveyRepository creates a survey repository that caches surveys loaded from another repository
+// Surveys are evicted once their TTL expires or when the cache is full, least recently used first
+func NewCachedSurveyRepository(next vote.SurveyRepository, cfg config.SurveyCacheConfig) vote.SurveyCache {
+	return &cachedSurveyRepository{
+		next:    next,
+		config:  cfg,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// GetSurvey returns a cached survey, loading it from the underlying repository when missing or expired
+// Errors are never cached so an unavailable survey service is retried on the next lookup
+func (r *cachedSurveyRepository) GetSurvey(id string) (*protos.SurveyResponse, error) {
+	if s, ok := r.get(id); ok {
+		return s, nil
+	}
+
+	s, err := r.next.GetSurvey(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.put(id, s)
+	return s, nil
+}
+
+// Invalidate removes a survey from the cache
+func (r *cachedSurveyRepository) Invalidate(id string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if e, ok := r.entries[id]; ok {
+		r.remove(e)
+	}
+}
+
+// get returns a survey from the cache if it is present and not expired
+func (r *cachedSurveyRepository) get(id string) (*protos.SurveyResponse, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	e, ok := r.entries[id]
+	if !ok {
+		return nil, false
+	}
+
+	entry := e.Value.(*cachedSurvey)
+	if time.Now().After(entry.expiresAt) {
+		r.remove(e)
+		return nil, false
+	}
+
+	r.order.MoveToFront(e)
+	return entry.survey, true
+}
+
+// put stores a survey in the cache, evicting the least recently used surveys when full
+func (r *cachedSurveyRepository) put(id string, s *protos.SurveyResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := &cachedSurvey{
+		id:        id,
+		survey:    s,
+		expiresAt: time.Now().Add(r.config.TTL),
+	}
+
+	if e, ok := r.entries[id]; ok {
+		e.Value = entry
+		r.order.MoveToFront(e)
+		return
+	}
+
+	r.entries[id] = r.order.PushFront(entry)
+	for r.order.Len() > r.config.Size {
+		r.remove(r.order.Back())
+	}
+}
+
+// remove deletes an element from the cache
+// The caller must hold the cache lock
+func (r *cachedSurveyRepository) remove(e *list.Element) {
+	r.order.Remove(e)
+	delete(r.entries, e.Value.(*cachedSurvey).id)
+}
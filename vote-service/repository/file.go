@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/jackc/pgx/v4"
+)
+
+// postgresFileRepository implements the vote.FileRepository interface using PostgreSQL
+type postgresFileRepository struct {
+	config     config.PostgresConfig
+	connection *pgx.Conn
+	mutex      sync.Mutex // A single connection cannot run concurrent queries
+}
+
+// NewPostgresFileRepository creates a new Postgres repository of uploaded file metadata
+// This function initializes a new PostgreSQL connection and returns a repository instance
+func NewPostgresFileRepository(cfg config.PostgresConfig) (vote.FileRepository, error) {
+	conn, err := ConnectToPostgres(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresFileRepository{config: cfg, connection: conn}, nil
+}
+
+// Insert stores the metadata of a new file in the PostgreSQL database
+func (p *postgresFileRepository) Insert(f *vote.File) error {
+	q := fmt.Sprintf("INSERT INTO %s (id, survey, question, name, content_type, size, user_id, created) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", p.config.Tables.Files)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	_, err := p.connection.Exec(context.Background(), q,
+		f.ID,
+		f.Survey,
+		f.Question,
+		f.Name,
+		f.ContentType,
+		f.Size,
+		nullString(f.UserID),
+		f.CreatedAt,
+	)
+	return err
+}
+
+// Load retrieves the metadata of a file by ID from the PostgreSQL database
+func (p *postgresFileRepository) Load(id string) (*vote.File, error) {
+	q := fmt.Sprintf("SELECT id, survey, question, name, content_type, size, COALESCE(user_id, ''), created, COALESCE(attached_at, 0) FROM %s WHERE id = $1", p.config.Tables.Files)
+
+	f := &vote.File{}
+	p.mutex.Lock()
+	err := p.connection.QueryRow(context.Background(), q, id).Scan(
+		&f.ID,
+		&f.Survey,
+		&f.Question,
+		&f.Name,
+		&f.ContentType,
+		&f.Size,
+		&f.UserID,
+		&f.CreatedAt,
+		&f.AttachedAt,
+	)
+	p.mutex.Unlock()
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, vote.ErrFileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Attach marks a file as answering a vote in the PostgreSQL database, unless it already does
+func (p *postgresFileRepository) Attach(id string, at int64) error {
+	q := fmt.Sprintf("UPDATE %s SET attached_at = $2 WHERE id = $1 AND attached_at IS NULL", p.config.Tables.Files)
+	exists := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)", p.config.Tables.Files)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	tag, err := p.connection.Exec(context.Background(), q, id, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 1 {
+		return nil
+	}
+
+	// Tell attached files from unknown ones
+	var found bool
+	if err := p.connection.QueryRow(context.Background(), exists, id).Scan(&found); err != nil {
+		return err
+	}
+	if !found {
+		return vote.ErrFileNotFound
+	}
+	return vote.ErrFileAttached
+}
+
+// Detach marks a file as answering no vote in the PostgreSQL database
+func (p *postgresFileRepository) Detach(id string) error {
+	q := fmt.Sprintf("UPDATE %s SET attached_at = NULL WHERE id = $1", p.config.Tables.Files)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	_, err := p.connection.Exec(context.Background(), q, id)
+	return err
+}
+
+// DeleteUnattached deletes the files uploaded before a time and answering no vote from the PostgreSQL database
+func (p *postgresFileRepository) DeleteUnattached(before int64) ([]string, error) {
+	q := fmt.Sprintf("DELETE FROM %s WHERE attached_at IS NULL AND created < $1 RETURNING id", p.config.Tables.Files)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	rows, err := p.connection.Query(context.Background(), q, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
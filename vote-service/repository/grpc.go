@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcSurveyRepository implements the vote.SurveyRepository interface using the survey gRPC service
+type grpcSurveyRepository struct {
+	client protos.SurveyClient
+	config config.SurveyGrpcConfig
+}
+
+// NewGrpcSurveyRepository creates a new survey repository backed by the survey gRPC service
+// This function wraps a gRPC client so that its errors are translated into vote errors
+func NewGrpcSurveyRepository(cli protos.SurveyClient, cfg config.SurveyGrpcConfig) vote.SurveyRepository {
+	return &grpcSurveyRepository{
+		client: cli,
+		config: cfg,
+	}
+}
+
+// GetSurvey fetches a survey from the survey gRPC service
+// This method distinguishes unknown surveys from an unreachable or failing survey service
+func (r *grpcSurveyRepository) GetSurvey(id string) (*protos.SurveyResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.Timeout)
+	defer cancel()
+
+	res, err := r.client.GetSurvey(ctx, &protos.SurveyRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, vote.ErrSurveyNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", vote.ErrSurveyUnavailable, err)
+	}
+
+	return res, nil
+}
+
+// grpcKeyVerifier implements the auth.KeyVerifier interface using the survey gRPC service
+type grpcKeyVerifier struct {
+	client protos.SurveyClient
+	config config.SurveyGrpcConfig
+}
+
+// NewGrpcKeyVerifier creates a new API key verifier backed by the survey gRPC service
+// The survey service issues API keys, so every key is verified there and revocations apply immediately
+func NewGrpcKeyVerifier(cli protos.SurveyClient, cfg config.SurveyGrpcConfig) auth.KeyVerifier {
+	return &grpcKeyVerifier{
+		client: cli,
+		config: cfg,
+	}
+}
+
+// VerifyAPIKey verifies an API key with the survey gRPC service
+// This method distinguishes invalid keys from an unreachable or failing survey service
+func (v *grpcKeyVerifier) VerifyAPIKey(key string) (*auth.Principal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), v.config.Timeout)
+	defer cancel()
+
+	res, err := v.client.VerifyAPIKey(ctx, &protos.APIKeyRequest{Key: key})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return nil, auth.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("%w: %v", vote.ErrSurveyUnavailable, err)
+	}
+
+	return &auth.Principal{
+		Subject: res.GetOwnerId(),
+		Roles:   res.GetRoles(),
+		Scopes:  res.GetScopes(),
+		KeyID:   res.GetId(),
+	}, nil
+}
+
+// grpcInvitationRepository implements the vote.InvitationRepository interface using the survey gRPC service
+type grpcInvitationRepository struct {
+	client protos.SurveyClient
+	config config.SurveyGrpcConfig
+}
+
+// NewGrpcInvitationRepository creates a new invitation repository backed by the survey gRPC service
+// This function wraps a gRPC client so that its errors are translated into vote errors
+func NewGrpcInvitationRepository(cli protos.SurveyClient, cfg config.SurveyGrpcConfig) vote.InvitationRepository {
+	return &grpcInvitationRepository{
+		client: cli,
+		config: cfg,
+	}
+}
+
+// Use consumes an invitation token with the survey gRPC service
+func (r *grpcInvitationRepository) Use(surveyID, token string, question int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.Timeout)
+	defer cancel()
+
+	_, err := r.client.UseInvitation(ctx, &protos.InvitationRequest{
+		SurveyId:   surveyID,
+		Token:      token,
+		QuestionId: int32(question),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.PermissionDenied:
+		return vote.ErrInvalidInvitation
+	case codes.AlreadyExists:
+		return vote.ErrInvitationUsed
+	default:
+		return fmt.Errorf("%w: %v", vote.ErrSurveyUnavailable, err)
+	}
+}
+
+// Release releases an invitation token with the survey gRPC service
+func (r *grpcInvitationRepository) Release(surveyID, token string, question int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.Timeout)
+	defer cancel()
+
+	_, err := r.client.ReleaseInvitation(ctx, &protos.InvitationRequest{
+		SurveyId:   surveyID,
+		Token:      token,
+		QuestionId: int32(question),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.PermissionDenied:
+		return vote.ErrInvalidInvitation
+	default:
+		return fmt.Errorf("%w: %v", vote.ErrSurveyUnavailable, err)
+	}
+}
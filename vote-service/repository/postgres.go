@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
@@ -13,6 +15,7 @@ import (
 type postgresResultsRepository struct {
 	config     config.PostgresConfig
 	connection *pgx.Conn
+	mutex      sync.Mutex // A single connection cannot run concurrent queries
 }
 
 // NewPostgresResultsRepository creates a new Postgres vote results repository
@@ -51,6 +54,8 @@ func (p *postgresResultsRepository) GetResults(surveyID string) (vote.Results, e
 	// Extract query generation to a separate method (Extract Method refactoring)
 	query := p.buildQuery()
 
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	rows, err := p.connection.Query(context.Background(), query, surveyID)
 	if err != nil {
 		return vote.Results{}, err
@@ -70,10 +75,51 @@ func (p *postgresResultsRepository) GetResults(surveyID string) (vote.Results, e
 	return results, rows.Err()
 }
 
+// HasVoted checks if a user already answered a survey question in the PostgreSQL database
+func (p *postgresResultsRepository) HasVoted(surveyID string, question int, userID string) (bool, error) {
+	q := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE survey = $1 AND question = $2 AND user_id = $3 AND archived_at IS NULL)", p.config.Tables.Votes)
+
+	var voted bool
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	err := p.connection.QueryRow(context.Background(), q, surveyID, question, userID).Scan(&voted)
+	return voted, err
+}
+
+// LoadVotes retrieves the live votes of a survey ordered by time from the PostgreSQL database
+func (p *postgresResultsRepository) LoadVotes(surveyID string) ([]*vote.Vote, error) {
+	q := fmt.Sprintf("SELECT id, question, created, COALESCE(user_id, ''), COALESCE(respondent, ''), COALESCE(answer, '{}'::jsonb) FROM %s WHERE survey = $1 AND archived_at IS NULL ORDER BY created", p.config.Tables.Votes)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	rows, err := p.connection.Query(context.Background(), q, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := []*vote.Vote{}
+	for rows.Next() {
+		v := &vote.Vote{Survey: surveyID}
+		var data []byte
+		if err := rows.Scan(&v.ID, &v.Question, &v.Timestamp, &v.UserID, &v.Respondent, &data); err != nil {
+			return nil, err
+		}
+
+		var answer vote.Answer
+		if err := json.Unmarshal(data, &answer); err != nil {
+			return nil, err
+		}
+		v.SetAnswer(answer)
+		votes = append(votes, v)
+	}
+	return votes, rows.Err()
+}
+
 // buildQuery generates the SQL query for retrieving survey results
 // Extract Method refactoring applied here
 func (p *postgresResultsRepository) buildQuery() string {
-	return fmt.Sprintf("SELECT question, votes, last_update FROM %s WHERE survey = $1", p.config.Tables.Results)
+	return fmt.Sprintf("SELECT question, votes, last_update FROM %s WHERE survey = $1 AND archived_at IS NULL", p.config.Tables.Results)
 }
 
 // processRows processes the result rows from the query
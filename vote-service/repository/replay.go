@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/jackc/pgx/v4"
+)
+
+// replaySweepInterval is the interval between removals of expired respondent token uses
+const replaySweepInterval = 10 * time.Minute
+
+// postgresReplayRepository implements the vote.ReplayRepository interface using PostgreSQL
+type postgresReplayRepository struct {
+	config     config.PostgresConfig
+	connection *pgx.Conn
+	mutex      sync.Mutex // A single connection cannot run concurrent queries
+	nextSweep  time.Time
+}
+
+// NewPostgresReplayRepository creates a new Postgres repository of used respondent tokens
+// This function initializes a new PostgreSQL connection and returns a repository instance
+func NewPostgresReplayRepository(cfg config.PostgresConfig) (vote.ReplayRepository, error) {
+	conn, err := ConnectToPostgres(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresReplayRepository{config: cfg, connection: conn}, nil
+}
+
+// Use marks a key as used in the PostgreSQL database
+// Expired keys are removed now and then, their tokens are rejected as expired before they are looked up
+func (p *postgresReplayRepository) Use(key string, expiresAt int64) (bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now().UTC()
+	if now.After(p.nextSweep) {
+		q := fmt.Sprintf("DELETE FROM %s WHERE expires_at < $1", p.config.Tables.Replays)
+		if _, err := p.connection.Exec(context.Background(), q, now.Unix()); err != nil {
+			return false, err
+		}
+		p.nextSweep = now.Add(replaySweepInterval)
+	}
+
+	q := fmt.Sprintf("INSERT INTO %s (key, expires_at) VALUES ($1, $2) ON CONFLICT (key) DO NOTHING", p.config.Tables.Replays)
+	tag, err := p.connection.Exec(context.Background(), q, key, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Release removes a used key from the PostgreSQL database
+func (p *postgresReplayRepository) Release(key string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	q := fmt.Sprintf("DELETE FROM %s WHERE key = $1", p.config.Tables.Replays)
+	_, err := p.connection.Exec(context.Background(), q, key)
+	return err
+}
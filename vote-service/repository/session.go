@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/jackc/pgx/v4"
+)
+
+// postgresSessionRepository implements the vote.SessionRepository interface using PostgreSQL
+type postgresSessionRepository struct {
+	config     config.PostgresConfig
+	connection *pgx.Conn
+	mutex      sync.Mutex // A single connection cannot run concurrent queries
+}
+
+// NewPostgresSessionRepository creates a new Postgres repository of response sessions
+// This function initializes a new PostgreSQL connection and returns a repository instance
+func NewPostgresSessionRepository(cfg config.PostgresConfig) (vote.SessionRepository, error) {
+	conn, err := ConnectToPostgres(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresSessionRepository{config: cfg, connection: conn}, nil
+}
+
+// Insert stores a new session in the PostgreSQL database
+func (p *postgresSessionRepository) Insert(s *vote.Session) error {
+	answers, err := json.Marshal(s.Answers)
+	if err != nil {
+		return err
+	}
+	q := fmt.Sprintf("INSERT INTO %s (id, token_hash, survey, user_id, answers, status, created, updated, expires_at, completed_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)", p.config.Tables.Sessions)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	_, err = p.connection.Exec(context.Background(), q,
+		s.ID,
+		s.TokenHash,
+		s.Survey,
+		nullString(s.UserID),
+		string(answers),
+		string(s.Status),
+		s.CreatedAt,
+		s.UpdatedAt,
+		s.ExpiresAt,
+		nullInt64(s.CompletedAt),
+	)
+	return err
+}
+
+// sessionColumns are the columns of a session read by scanSession
+const sessionColumns = "id, token_hash, survey, COALESCE(user_id, ''), answers, status, created, updated, expires_at, COALESCE(completed_at, 0)"
+
+// LoadByHash retrieves a session by the hash of its token from the PostgreSQL database
+func (p *postgresSessionRepository) LoadByHash(hash string) (*vote.Session, error) {
+	q := fmt.Sprintf("SELECT %s FROM %s WHERE token_hash = $1", sessionColumns, p.config.Tables.Sessions)
+
+	p.mutex.Lock()
+	s, err := scanSession(p.connection.QueryRow(context.Background(), q, hash))
+	p.mutex.Unlock()
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, vote.ErrSessionNotFound
+	}
+	return s, err
+}
+
+// Claim marks a session as finalizing in the PostgreSQL database and returns it as stored
+// The status is checked and changed in one statement, so only one request can claim a session
+func (p *postgresSessionRepository) Claim(id string, now, staleBefore int64) (*vote.Session, error) {
+	q := fmt.Sprintf("UPDATE %s SET status = $2, updated = $3 WHERE id = $1 AND ((status = $4 AND expires_at > $3) OR (status = $2 AND updated <= $5)) RETURNING %s", p.config.Tables.Sessions, sessionColumns)
+
+	p.mutex.Lock()
+	s, err := scanSession(p.connection.QueryRow(context.Background(), q,
+		id,
+		string(vote.SessionFinalizing),
+		now,
+		string(vote.SessionInProgress),
+		staleBefore,
+	))
+	p.mutex.Unlock()
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, vote.ErrSessionConflict
+	}
+	return s, err
+}
+
+// Update stores the answers, status and timestamps of a session still in a status in the PostgreSQL database
+func (p *postgresSessionRepository) Update(s *vote.Session, from vote.SessionStatus) error {
+	answers, err := json.Marshal(s.Answers)
+	if err != nil {
+		return err
+	}
+	q := fmt.Sprintf("UPDATE %s SET answers = $2, status = $3, updated = $4, expires_at = $5, completed_at = $6 WHERE id = $1 AND status = $7", p.config.Tables.Sessions)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	tag, err := p.connection.Exec(context.Background(), q,
+		s.ID,
+		string(answers),
+		string(s.Status),
+		s.UpdatedAt,
+		s.ExpiresAt,
+		nullInt64(s.CompletedAt),
+		string(from),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return vote.ErrSessionConflict
+	}
+	return nil
+}
+
+// Expire marks the sessions in progress that expired before a timestamp as expired
+func (p *postgresSessionRepository) Expire(before int64) (int, error) {
+	q := fmt.Sprintf("UPDATE %s SET status = $1 WHERE status = $2 AND expires_at <= $3", p.config.Tables.Sessions)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	tag, err := p.connection.Exec(context.Background(), q, string(vote.SessionExpired), string(vote.SessionInProgress), before)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// CountByStatus counts the sessions of a survey by status in the PostgreSQL database
+// Sessions in progress past their expiration are counted as expired
+func (p *postgresSessionRepository) CountByStatus(surveyID string, now int64) (map[vote.SessionStatus]int, error) {
+	q := fmt.Sprintf("SELECT CASE WHEN status = $2 AND expires_at <= $3 THEN $4 ELSE status END, COUNT(*) FROM %s WHERE survey = $1 GROUP BY 1", p.config.Tables.Sessions)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	rows, err := p.connection.Query(context.Background(), q, surveyID, string(vote.SessionInProgress), now, string(vote.SessionExpired))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[vote.SessionStatus]int{}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[vote.SessionStatus(status)] = count
+	}
+	return counts, rows.Err()
+}
+
+// scanSession reads a session selected with sessionColumns
+func scanSession(row pgx.Row) (*vote.Session, error) {
+	s := &vote.Session{}
+	var answers []byte
+	var status string
+
+	err := row.Scan(
+		&s.ID,
+		&s.TokenHash,
+		&s.Survey,
+		&s.UserID,
+		&answers,
+		&status,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+		&s.ExpiresAt,
+		&s.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Status = vote.SessionStatus(status)
+	if err := json.Unmarshal(answers, &s.Answers); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// nullString converts an empty string to a NULL column value
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nullInt64 converts a zero timestamp to a NULL column value
+func nullInt64(i int64) *int64 {
+	if i == 0 {
+		return nil
+	}
+	return &i
+}
@@ -1,6 +1,9 @@
 package router
 
 import (
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/auth"
+	"github.com/VitaliySynytskyi/microservices-survey-app/survey-service/ratelimit"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/config"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/handler"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/middleware"
 	"github.com/go-chi/chi"
@@ -9,32 +12,90 @@ import (
 
 // NewRouter creates a new router
 // This function sets up the router with middleware and routes for the vote service
-func NewRouter(h *handler.VoteHTTPHandler) *chi.Mux {
+// Requests of each client are throttled with token buckets kept in the rate limit store, clients are identified by the resolver
+// API keys are verified with the key verifier and limited to their scopes
+func NewRouter(h *handler.VoteHTTPHandler, lh *handler.LiveHTTPHandler, sh *handler.SessionHTTPHandler, fh *handler.FileHTTPHandler, a *auth.Authenticator, v auth.KeyVerifier, cfg config.AuthConfig, s ratelimit.Store, c *ratelimit.ClientResolver, rl config.RateLimitConfig) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Set up CORS middleware
 	// This middleware allows cross-origin requests from any origin
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},                              // Allow all origins
-		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},         // Allow GET, POST, and OPTIONS methods
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"}, // Allow specified headers
-		ExposedHeaders:   []string{"Link"},                           // Expose specified headers
+		AllowedMethods:   []string{"GET", "POST", "PUT", "OPTIONS"},  // Allow GET, POST, PUT, and OPTIONS methods
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", middleware.APIKeyHeader, middleware.FingerprintHeader, middleware.RespondentTokenHeader, middleware.InvitationTokenHeader}, // Allow specified headers
+		ExposedHeaders:   []string{"Link", "Retry-After"},            // Expose specified headers
 		AllowCredentials: true,                                       // Allow credentials
 		MaxAge:           300,                                         // Max age for preflight requests
 	}))
 
+	// Resolve the address of each client before it is throttled
+	r.Use(ratelimit.ResolveClient(c))
+
+	// Votes and results are public unless configured otherwise
+	// API keys need the scope of the endpoints they access
+	votes := chi.Middlewares{middleware.RequireScope(auth.ScopeVotesWrite)}
+	if !cfg.PublicVotes {
+		votes = append(votes, middleware.RequireAuth)
+	}
+	reads := chi.Middlewares{middleware.RequireScope(auth.ScopeResultsRead)}
+	if !cfg.PublicReads {
+		reads = append(reads, middleware.RequireAuth)
+	}
+
+	// Uploaded files are respondent documents, so they are never public, even when results are
+	downloads := chi.Middlewares{middleware.RequireScope(auth.ScopeResultsRead), middleware.RequireAuth}
+
+	// Requests of each client are throttled per route group
+	// The limiters run before API keys are verified, so requests with unknown keys are throttled by IP address before they reach the survey service
+	voteLimit := middleware.RateLimit(s, "vote", ratelimit.Limit{Rate: rl.VoteRate, Burst: rl.VoteBurst})
+	resultsLimit := middleware.RateLimit(s, "results", ratelimit.Limit{Rate: rl.ResultsRate, Burst: rl.ResultsBurst})
+	verifyKey := middleware.AuthenticateAPIKey(v)
+
 	// Set up vote routes
 	// This route group handles all vote-related endpoints
 	r.Route("/vote", func(r chi.Router) {
-		r.Use(middleware.AddSerializer) // Add the serializer middleware
-		r.Post("/", h.Vote)             // POST /vote - casts a vote
+		r.Use(middleware.AddSerializer)         // Add the serializer middleware
+		r.Use(middleware.Authenticate(a))       // Verify bearer tokens when present
+		r.Use(voteLimit)                        // Throttle each client
+		r.Use(middleware.AuthenticateAPIKey(v)) // Verify API keys when present
+		r.With(votes...).Post("/", h.Vote)      // POST /vote - casts a vote
+	})
+
+	// Set up response session routes
+	// This route group saves answers between visits and submits them as votes, throttled like votes
+	r.Route("/sessions", func(r chi.Router) {
+		r.Use(middleware.AddSerializer)                          // Add the serializer middleware
+		r.Use(middleware.Authenticate(a))                        // Verify bearer tokens when present
+		r.Use(voteLimit)                                         // Throttle each client
+		r.Use(middleware.AuthenticateAPIKey(v))                  // Verify API keys when present
+		r.With(votes...).Post("/", sh.Start)                     // POST /sessions - starts a response session
+		r.With(votes...).Get("/{token}", sh.Resume)              // GET /sessions/{token} - resumes a response session
+		r.With(votes...).Put("/{token}/answers", sh.SaveAnswers) // PUT /sessions/{token}/answers - saves answers
+		r.With(votes...).Post("/{token}/finalize", sh.Finalize)  // POST /sessions/{token}/finalize - submits the answers as votes
+	})
+
+	// Set up file routes
+	// This route group uploads files like votes are cast, and only downloads them to the users who can view the results
+	r.Route("/files", func(r chi.Router) {
+		r.Use(middleware.AddSerializer)                                              // Add the serializer middleware
+		r.Use(middleware.Authenticate(a))                                            // Verify bearer tokens when present
+		r.With(voteLimit, verifyKey).With(votes...).Post("/", fh.Upload)             // POST /files - uploads a file for a file question
+		r.With(resultsLimit, verifyKey).With(downloads...).Get("/{id}", fh.Download) // GET /files/{id} - downloads an uploaded file
 	})
 
 	// Set up results routes
 	// This route group handles all results-related endpoints
 	r.Route("/results", func(r chi.Router) {
-		r.Use(middleware.AddSerializer) // Add the serializer middleware
-		r.Get("/{id}", h.GetResults)    // GET /results/{id} - retrieves results for a specific survey
+		r.Use(middleware.AddSerializer)                           // Add the serializer middleware
+		r.Use(middleware.Authenticate(a))                         // Verify bearer tokens when present
+		r.Use(resultsLimit)                                       // Throttle each client
+		r.Use(middleware.AuthenticateAPIKey(v))                   // Verify API keys when present
+		r.With(reads...).Get("/live", lh.Subscribe)               // GET /results/live - streams live results over a WebSocket
+		r.With(reads...).Get("/{id}", h.GetResults)               // GET /results/{id} - retrieves results for a specific survey
+		r.With(reads...).Get("/{id}/sessions", sh.GetReport)      // GET /results/{id}/sessions - reports completed and partial sessions
+		r.With(reads...).Get("/{id}/funnel", h.GetFunnel)         // GET /results/{id}/funnel - reports completions and drop-off per question
+		r.With(reads...).Get("/{id}/timeseries", h.GetTimeSeries) // GET /results/{id}/timeseries - counts votes per hour or day
+		r.With(reads...).Get("/{id}/crosstab", h.GetCrossTab)     // GET /results/{id}/crosstab - slices the answers of one question by another
 	})
 
 	return r
@@ -27,12 +27,48 @@ func (s *voteJSONSerializer) EncodeResults(r *vote.Results) ([]byte, error) {
 	return json.Marshal(r)
 }
 
+// EncodeFunnel encodes a completion funnel into JSON format
+// This method converts a funnel into a byte slice (e.g., JSON)
+func (s *voteJSONSerializer) EncodeFunnel(f *vote.Funnel) ([]byte, error) {
+	return json.Marshal(f)
+}
+
+// EncodeTimeSeries encodes a time series of votes into JSON format
+// This method converts a time series into a byte slice (e.g., JSON)
+func (s *voteJSONSerializer) EncodeTimeSeries(t *vote.TimeSeries) ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// EncodeCrossTab encodes a contingency table into JSON format
+// This method converts a cross-tabulation into a byte slice (e.g., JSON)
+func (s *voteJSONSerializer) EncodeCrossTab(c *vote.CrossTab) ([]byte, error) {
+	return json.Marshal(c)
+}
+
 // EncodeErrorResponse encodes an error response into JSON format
 // This method converts an error response into a byte slice (e.g., JSON)
 func (s *voteJSONSerializer) EncodeErrorResponse(err vote.ErrorResponse) ([]byte, error) {
 	return json.Marshal(err)
 }
 
+// EncodeSession encodes a response session into JSON format
+// This method converts a session into a byte slice (e.g., JSON)
+func (s *voteJSONSerializer) EncodeSession(session *vote.Session) ([]byte, error) {
+	return json.Marshal(session)
+}
+
+// EncodeSessionReport encodes a session completion report into JSON format
+// This method converts a session report into a byte slice (e.g., JSON)
+func (s *voteJSONSerializer) EncodeSessionReport(r *vote.SessionReport) ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// EncodeFile encodes the metadata of an uploaded file into JSON format
+// This method converts a file into a byte slice (e.g., JSON)
+func (s *voteJSONSerializer) EncodeFile(f *vote.File) ([]byte, error) {
+	return json.Marshal(f)
+}
+
 // Decode decodes a vote from JSON format
 // This method converts a byte slice into a vote (e.g., from JSON)
 func (s *voteJSONSerializer) Decode(data []byte) (*vote.Vote, error) {
@@ -49,6 +85,30 @@ func (s *voteJSONSerializer) DecodeResults(data []byte) (*vote.Results, error) {
 	return &r, err
 }
 
+// DecodeSessionRequest decodes a request to start a response session from JSON format
+// This method converts a byte slice into a session request (e.g., from JSON)
+func (s *voteJSONSerializer) DecodeSessionRequest(data []byte) (*vote.SessionRequest, error) {
+	r := vote.SessionRequest{}
+	err := json.Unmarshal(data, &r)
+	return &r, err
+}
+
+// DecodeAnswers decodes the answers of a response session from JSON format
+// This method converts a byte slice holding a list of votes into answers (e.g., from JSON)
+func (s *voteJSONSerializer) DecodeAnswers(data []byte) ([]*vote.Vote, error) {
+	answers := []*vote.Vote{}
+	err := json.Unmarshal(data, &answers)
+	return answers, err
+}
+
+// DecodeSurveyEvent decodes a survey change event from JSON format
+// This method converts a byte slice into a survey event (e.g., from JSON)
+func (s *voteJSONSerializer) DecodeSurveyEvent(data []byte) (*vote.SurveyEvent, error) {
+	e := vote.SurveyEvent{}
+	err := json.Unmarshal(data, &e)
+	return &e, err
+}
+
 // GetContentType returns the content type for JSON
 // This method returns the MIME type of the serialized data (e.g., "application/json")
 func (s *voteJSONSerializer) GetContentType() string {
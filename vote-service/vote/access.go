@@ -0,0 +1,56 @@
+package vote
+
+import protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+
+// Available roles, matching the roles of the survey service
+const (
+	RoleAdmin  = "admin"  // Can view the results of every survey
+	RoleEditor = "editor" // Can view the results of surveys they own or that are shared with them
+	RoleViewer = "viewer" // Can view the results of surveys they own or that are shared with them
+)
+
+// User describes the user reading survey results
+type User struct {
+	ID    string   // Unique identifier of the user
+	Roles []string // Roles granted to the user
+}
+
+// SystemUser returns the user of internal callers such as the live results hub
+// The system user has the admin role
+func SystemUser() *User {
+	return &User{ID: "system", Roles: []string{RoleAdmin}}
+}
+
+// HasRole checks if the user has been granted a role
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CanViewResults checks if the user can view the results of a survey
+// Admins can view every survey, other users the surveys they own or that are shared with them
+func (u *User) CanViewResults(s *protos.SurveyResponse) bool {
+	if u == nil {
+		return false
+	}
+	if u.HasRole(RoleAdmin) {
+		return true
+	}
+	if !u.HasRole(RoleEditor) && !u.HasRole(RoleViewer) {
+		return false
+	}
+
+	if s.GetOwnerId() != "" && s.GetOwnerId() == u.ID {
+		return true
+	}
+	for _, id := range s.GetSharedWith() {
+		if id == u.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+package vote
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+)
+
+// textPatterns caches the compiled patterns of text questions by pattern
+// Patterns come from survey definitions, so the cache grows with the surveys rather than the votes
+var textPatterns sync.Map
+
+// findQuestion returns the question with an ID within the survey questions
+// This function returns nil when the question ID is not found
+func findQuestion(questionID int, questions []*protos.QuestionResponse) *protos.QuestionResponse {
+	for _, q := range questions {
+		if q.GetId() == int32(questionID) {
+			return q
+		}
+	}
+	return nil
+}
+
+// validateAnswer checks the answer of a vote against its survey question
+// Questions with a fixed answer format reject answers of another type or out of range
+func validateAnswer(questions []*protos.QuestionResponse, v *Vote) error {
+	q := findQuestion(v.Question, questions)
+	if q == nil {
+		return ErrInvalidRequest
+	}
+
+	switch q.GetType() {
+	case QuestionTypeSingleChoice, QuestionTypeMultipleChoice:
+		return validateChoiceAnswer(q, v)
+	case QuestionTypeNPS:
+		if v.AnswerType != AnswerTypeNPS || v.NPSValue == nil {
+			return fmt.Errorf("%w: question %d expects an NPS answer", ErrInvalidRequest, v.Question)
+		}
+		if *v.NPSValue < NPSMinValue || *v.NPSValue > NPSMaxValue {
+			return fmt.Errorf("%w: NPS answers must be between %d and %d", ErrInvalidRequest, NPSMinValue, NPSMaxValue)
+		}
+	case QuestionTypeText:
+		return validateTextAnswer(q, v)
+	case QuestionTypeMatrix:
+		return validateMatrixAnswer(q, v)
+	case QuestionTypeRanking:
+		return validateRankingAnswer(q, v)
+	case QuestionTypeDate:
+		return validateDateAnswer(q, v)
+	case QuestionTypeFile:
+		if v.AnswerType != AnswerTypeFile || v.File == nil || v.File.ID == "" {
+			return fmt.Errorf("%w: question %d expects an uploaded file", ErrInvalidRequest, v.Question)
+		}
+	}
+	return nil
+}
+
+// validateChoiceAnswer checks that a choice answer selects known options, within the selection limits
+// The other option needs a text value, which is rejected when the other option is not selected
+func validateChoiceAnswer(q *protos.QuestionResponse, v *Vote) error {
+	selected := v.SelectedOptions()
+	if v.AnswerType != AnswerTypeOption || len(selected) == 0 {
+		return fmt.Errorf("%w: question %d expects an option answer", ErrInvalidRequest, v.Question)
+	}
+	if q.GetType() == QuestionTypeSingleChoice && len(selected) > 1 {
+		return fmt.Errorf("%w: question %d accepts a single option", ErrInvalidRequest, v.Question)
+	}
+	if q.MinSelections != nil && len(selected) < int(q.GetMinSelections()) {
+		return fmt.Errorf("%w: select at least %d options", ErrInvalidRequest, q.GetMinSelections())
+	}
+	if q.MaxSelections != nil && len(selected) > int(q.GetMaxSelections()) {
+		return fmt.Errorf("%w: select at most %d options", ErrInvalidRequest, q.GetMaxSelections())
+	}
+
+	options := map[int]*protos.OptionResponse{}
+	for _, o := range q.GetOptions() {
+		options[int(o.GetId())] = o
+	}
+	seen := map[int]bool{}
+	other := false
+	for _, id := range selected {
+		o, ok := options[id]
+		if !ok {
+			return fmt.Errorf("%w: unknown option %d", ErrInvalidRequest, id)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: option %d is selected more than once", ErrInvalidRequest, id)
+		}
+		seen[id] = true
+		other = other || o.GetOther()
+	}
+
+	if other && (v.OtherText == nil || strings.TrimSpace(*v.OtherText) == "") {
+		return fmt.Errorf("%w: please specify the other option", ErrInvalidRequest)
+	}
+	if !other && v.OtherText != nil {
+		return fmt.Errorf("%w: a text value is only accepted with the other option", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// validateTextAnswer checks a text answer against the constraints of its question
+// Lengths count characters and the pattern must match the whole answer
+func validateTextAnswer(q *protos.QuestionResponse, v *Vote) error {
+	c := q.GetTextConstraints()
+	if c == nil {
+		return nil
+	}
+	if v.AnswerType != AnswerTypeText || v.TextAnswer == nil {
+		return fmt.Errorf("%w: question %d expects a text answer", ErrInvalidRequest, v.Question)
+	}
+
+	answer := *v.TextAnswer
+	length := utf8.RuneCountInString(answer)
+	if c.MinLength != nil && length < int(c.GetMinLength()) {
+		return fmt.Errorf("%w: the answer must be at least %d characters", ErrInvalidRequest, c.GetMinLength())
+	}
+	if c.MaxLength != nil && length > int(c.GetMaxLength()) {
+		return fmt.Errorf("%w: the answer must be at most %d characters", ErrInvalidRequest, c.GetMaxLength())
+	}
+	if c.GetPattern() != "" {
+		pattern, err := compileTextPattern(c.GetPattern())
+		if err != nil {
+			return fmt.Errorf("%w: invalid pattern of question %d: %v", ErrInvalidRequest, v.Question, err)
+		}
+		if !pattern.MatchString(answer) {
+			return fmt.Errorf("%w: the answer does not match the expected pattern", ErrInvalidRequest)
+		}
+	}
+	if !matchesFormat(answer, c.GetFormat()) {
+		return fmt.Errorf("%w: the answer must be a valid %s", ErrInvalidRequest, c.GetFormat())
+	}
+	return nil
+}
+
+// compileTextPattern compiles the pattern of a text question to match whole answers, caching the result
+func compileTextPattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := textPatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	compiled, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+	textPatterns.Store(pattern, compiled)
+	return compiled, nil
+}
+
+// matchesFormat checks if a text answer has a text format, any answer matches an empty format
+func matchesFormat(answer, format string) bool {
+	switch format {
+	case TextFormatEmail:
+		addr, err := mail.ParseAddress(answer)
+		return err == nil && addr.Address == answer
+	case TextFormatNumber:
+		n, err := strconv.ParseFloat(answer, 64)
+		return err == nil && !math.IsNaN(n) && !math.IsInf(n, 0)
+	case TextFormatURL:
+		u, err := url.ParseRequestURI(answer)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	}
+	return true
+}
+
+// validateDateAnswer checks a date answer against the mode and range of its question
+// Questions in date mode, the default, expect a day answered as midnight UTC
+func validateDateAnswer(q *protos.QuestionResponse, v *Vote) error {
+	if v.AnswerType != AnswerTypeDate || v.DateAnswer == nil {
+		return fmt.Errorf("%w: question %d expects a date answer", ErrInvalidRequest, v.Question)
+	}
+	c := q.GetDateConstraints()
+	answer := *v.DateAnswer
+
+	if c.GetMode() != DateModeDateTime && answer%secondsPerDay != 0 {
+		return fmt.Errorf("%w: question %d expects a day without a time", ErrInvalidRequest, v.Question)
+	}
+	if c != nil && c.MinDate != nil && answer < c.GetMinDate() {
+		return fmt.Errorf("%w: dates before %s are not accepted", ErrInvalidRequest, formatDateBound(c.GetMinDate(), c.GetMode()))
+	}
+	if c != nil && c.MaxDate != nil && answer > c.GetMaxDate() {
+		return fmt.Errorf("%w: dates after %s are not accepted", ErrInvalidRequest, formatDateBound(c.GetMaxDate(), c.GetMode()))
+	}
+	return nil
+}
+
+// formatDateBound formats the bound of a date question for error messages
+func formatDateBound(bound int64, mode string) string {
+	t := time.Unix(bound, 0).UTC()
+	if mode == DateModeDateTime {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format(dayLayout)
+}
+
+// validateMatrixAnswer checks that a matrix answer selects a known column for known rows, once per row
+// Required matrix questions need an answer for every row
+func validateMatrixAnswer(q *protos.QuestionResponse, v *Vote) error {
+	if v.AnswerType != AnswerTypeMatrix || len(v.MatrixAnswers) == 0 {
+		return fmt.Errorf("%w: question %d expects a matrix answer", ErrInvalidRequest, v.Question)
+	}
+
+	rows := optionIDs(q.GetRows())
+	columns := optionIDs(q.GetColumns())
+	answered := map[int]bool{}
+	for _, a := range v.MatrixAnswers {
+		if !rows[a.Row] {
+			return fmt.Errorf("%w: unknown row %d", ErrInvalidRequest, a.Row)
+		}
+		if !columns[a.Column] {
+			return fmt.Errorf("%w: unknown column %d", ErrInvalidRequest, a.Column)
+		}
+		if answered[a.Row] {
+			return fmt.Errorf("%w: row %d is answered more than once", ErrInvalidRequest, a.Row)
+		}
+		answered[a.Row] = true
+	}
+
+	if q.GetRequired() && len(answered) < len(rows) {
+		return fmt.Errorf("%w: every row of question %d must be answered", ErrInvalidRequest, v.Question)
+	}
+	return nil
+}
+
+// validateRankingAnswer checks that a ranking orders every option of the question exactly once
+func validateRankingAnswer(q *protos.QuestionResponse, v *Vote) error {
+	if v.AnswerType != AnswerTypeRanking {
+		return fmt.Errorf("%w: question %d expects a ranking answer", ErrInvalidRequest, v.Question)
+	}
+
+	options := optionIDs(q.GetOptions())
+	if len(v.Ranking) != len(options) {
+		return fmt.Errorf("%w: the ranking must order all %d options", ErrInvalidRequest, len(options))
+	}
+	ranked := map[int]bool{}
+	for _, id := range v.Ranking {
+		if !options[id] {
+			return fmt.Errorf("%w: unknown option %d", ErrInvalidRequest, id)
+		}
+		if ranked[id] {
+			return fmt.Errorf("%w: option %d is ranked more than once", ErrInvalidRequest, id)
+		}
+		ranked[id] = true
+	}
+	return nil
+}
+
+// optionIDs returns the set of IDs of a list of options
+func optionIDs(options []*protos.OptionResponse) map[int]bool {
+	ids := make(map[int]bool, len(options))
+	for _, o := range options {
+		ids[int(o.GetId())] = true
+	}
+	return ids
+}
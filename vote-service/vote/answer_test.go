@@ -0,0 +1,106 @@
+package vote
+
+import (
+	"testing"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// newAnswerQuestions creates questions of every type with answer constraints
+func newAnswerQuestions() []*protos.QuestionResponse {
+	minLength, maxLength := int32(2), int32(5)
+	minSelections, maxSelections := int32(1), int32(2)
+	minDate, maxDate := int64(1704067200), int64(1735603200)
+	choices := []*protos.OptionResponse{{Id: 1}, {Id: 2}, {Id: 3, Other: true}}
+	return []*protos.QuestionResponse{
+		{Id: 1, Type: QuestionTypeSingleChoice, Options: choices},
+		{Id: 2, Type: QuestionTypeMultipleChoice, Options: choices, MinSelections: &minSelections, MaxSelections: &maxSelections},
+		{Id: 3, Type: QuestionTypeText, TextConstraints: &protos.TextConstraintsResponse{MinLength: &minLength, MaxLength: &maxLength, Pattern: "[a-z]+"}},
+		{Id: 4, Type: QuestionTypeText, TextConstraints: &protos.TextConstraintsResponse{Format: TextFormatEmail}},
+		{Id: 5, Type: QuestionTypeMatrix, Required: true, Rows: newOptions(1, 2), Columns: newOptions(1, 2)},
+		{Id: 6, Type: QuestionTypeRanking, Options: newOptions(1, 2, 3)},
+		{Id: 7, Type: QuestionTypeDate, DateConstraints: &protos.DateConstraintsResponse{MinDate: &minDate, MaxDate: &maxDate}},
+		{Id: 8, Type: QuestionTypeDate, DateConstraints: &protos.DateConstraintsResponse{Mode: DateModeDateTime}},
+		{Id: 9, Type: QuestionTypeFile},
+		{Id: 10, Type: QuestionTypeNPS},
+		{Id: 11, Type: QuestionTypeText, TextConstraints: &protos.TextConstraintsResponse{Pattern: "("}},
+		{Id: 12, Type: QuestionTypeDate},
+	}
+}
+
+// TestValidateAnswer tests answers against the type and constraints of their question
+func TestValidateAnswer(t *testing.T) {
+	one, two, three, eleven := 1, 2, 3, 11
+	text := func(s string) *string { return &s }
+	date := func(d int64) *int64 { return &d }
+
+	tests := []struct {
+		name  string
+		vote  Vote
+		valid bool
+	}{
+		{"single option", Vote{Question: 1, AnswerType: AnswerTypeOption, OptionID: &one}, true},
+		{"two single options", Vote{Question: 1, AnswerType: AnswerTypeOption, OptionIDs: []int{1, 2}}, false},
+		{"unknown option", Vote{Question: 1, AnswerType: AnswerTypeOption, OptionID: &eleven}, false},
+		{"no option", Vote{Question: 1, AnswerType: AnswerTypeOption}, false},
+		{"other with text", Vote{Question: 1, AnswerType: AnswerTypeOption, OptionID: &three, OtherText: text("Fax")}, true},
+		{"other without text", Vote{Question: 1, AnswerType: AnswerTypeOption, OptionID: &three, OtherText: text(" ")}, false},
+		{"text without other", Vote{Question: 1, AnswerType: AnswerTypeOption, OptionID: &one, OtherText: text("Fax")}, false},
+		{"selections in limits", Vote{Question: 2, AnswerType: AnswerTypeOption, OptionIDs: []int{1, 2}}, true},
+		{"too many selections", Vote{Question: 2, AnswerType: AnswerTypeOption, OptionIDs: []int{1, 2, 3}, OtherText: text("Fax")}, false},
+		{"repeated selection", Vote{Question: 2, AnswerType: AnswerTypeOption, OptionIDs: []int{2, 2}}, false},
+		{"matching text", Vote{Question: 3, AnswerType: AnswerTypeText, TextAnswer: text("abc")}, true},
+		{"short text", Vote{Question: 3, AnswerType: AnswerTypeText, TextAnswer: text("a")}, false},
+		{"long text", Vote{Question: 3, AnswerType: AnswerTypeText, TextAnswer: text("abcdef")}, false},
+		{"partial pattern match", Vote{Question: 3, AnswerType: AnswerTypeText, TextAnswer: text("ab1")}, false},
+		{"missing text", Vote{Question: 3, AnswerType: AnswerTypeOption, OptionID: &one}, false},
+		{"email", Vote{Question: 4, AnswerType: AnswerTypeText, TextAnswer: text("ann@example.com")}, true},
+		{"named email", Vote{Question: 4, AnswerType: AnswerTypeText, TextAnswer: text("Ann <ann@example.com>")}, false},
+		{"every row", Vote{Question: 5, AnswerType: AnswerTypeMatrix, MatrixAnswers: []MatrixAnswer{{Row: 1, Column: 1}, {Row: 2, Column: 2}}}, true},
+		{"missing row", Vote{Question: 5, AnswerType: AnswerTypeMatrix, MatrixAnswers: []MatrixAnswer{{Row: 1, Column: 1}}}, false},
+		{"repeated row", Vote{Question: 5, AnswerType: AnswerTypeMatrix, MatrixAnswers: []MatrixAnswer{{Row: 1, Column: 1}, {Row: 1, Column: 2}}}, false},
+		{"unknown column", Vote{Question: 5, AnswerType: AnswerTypeMatrix, MatrixAnswers: []MatrixAnswer{{Row: 1, Column: 3}, {Row: 2, Column: 1}}}, false},
+		{"full ranking", Vote{Question: 6, AnswerType: AnswerTypeRanking, Ranking: []int{3, 1, 2}}, true},
+		{"partial ranking", Vote{Question: 6, AnswerType: AnswerTypeRanking, Ranking: []int{3, 1}}, false},
+		{"repeated ranking", Vote{Question: 6, AnswerType: AnswerTypeRanking, Ranking: []int{3, 3, 1}}, false},
+		{"day in range", Vote{Question: 7, AnswerType: AnswerTypeDate, DateAnswer: date(1704067200 + secondsPerDay)}, true},
+		{"day before range", Vote{Question: 7, AnswerType: AnswerTypeDate, DateAnswer: date(1704067200 - secondsPerDay)}, false},
+		{"day after range", Vote{Question: 7, AnswerType: AnswerTypeDate, DateAnswer: date(1735603200 + secondsPerDay)}, false},
+		{"day with time", Vote{Question: 7, AnswerType: AnswerTypeDate, DateAnswer: date(1704067200 + 3600)}, false},
+		{"day before 1970", Vote{Question: 12, AnswerType: AnswerTypeDate, DateAnswer: date(-365 * secondsPerDay)}, true},
+		{"time before 1970", Vote{Question: 12, AnswerType: AnswerTypeDate, DateAnswer: date(-3600)}, false},
+		{"date and time", Vote{Question: 8, AnswerType: AnswerTypeDate, DateAnswer: date(1704067200 + 3600)}, true},
+		{"uploaded file", Vote{Question: 9, AnswerType: AnswerTypeFile, File: &FileAnswer{ID: "file-1"}}, true},
+		{"missing file", Vote{Question: 9, AnswerType: AnswerTypeFile, File: &FileAnswer{}}, false},
+		{"NPS score", Vote{Question: 10, AnswerType: AnswerTypeNPS, NPSValue: &two}, true},
+		{"NPS out of range", Vote{Question: 10, AnswerType: AnswerTypeNPS, NPSValue: &eleven}, false},
+		{"invalid pattern", Vote{Question: 11, AnswerType: AnswerTypeText, TextAnswer: text("(")}, false},
+		{"unknown question", Vote{Question: 13, AnswerType: AnswerTypeText, TextAnswer: text("abc")}, false},
+	}
+	questions := newAnswerQuestions()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAnswer(questions, &tt.vote)
+			if tt.valid {
+				assert.NoError(t, err, "Expected the answer to be accepted")
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidRequest, "Expected the answer to be rejected")
+			}
+		})
+	}
+}
+
+// TestCompileTextPattern tests that patterns match whole answers and are compiled once
+func TestCompileTextPattern(t *testing.T) {
+	pattern, err := compileTextPattern("a|b")
+	assert.NoError(t, err, "Expected no error on compile")
+	assert.True(t, pattern.MatchString("b"), "Expected an alternative to match")
+	assert.False(t, pattern.MatchString("ab"), "Expected the pattern to match whole answers")
+
+	cached, _ := compileTextPattern("a|b")
+	assert.Same(t, pattern, cached, "Expected the compiled pattern to be cached")
+
+	_, err = compileTextPattern("(")
+	assert.Error(t, err, "Expected invalid patterns to fail")
+}
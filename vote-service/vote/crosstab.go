@@ -0,0 +1,201 @@
+package vote
+
+import (
+	"fmt"
+	"sort"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+)
+
+// CrossTab describes the answers of one survey question sliced by the answers of another
+// This struct holds a contingency table built from the answers of respondents who answered both questions
+type CrossTab struct {
+	Survey       string           `json:"survey"`       // Survey ID associated with the table
+	Row          int              `json:"row"`          // Question ID whose answers are the rows
+	Column       int              `json:"column"`       // Question ID whose answers are the columns
+	RowValues    []int            `json:"rowValues"`    // Option IDs or values of the row question
+	ColumnValues []int            `json:"columnValues"` // Option IDs or values of the column question
+	Cells        [][]CrossTabCell `json:"cells"`        // Cells of the table, indexed by row then column
+	RowTotals    []int            `json:"rowTotals"`    // Total count of each row
+	ColumnTotals []int            `json:"columnTotals"` // Total count of each column
+	Total        int              `json:"total"`        // Total count of the table
+	Respondents  int              `json:"respondents"`  // Number of respondents who answered both questions
+}
+
+// CrossTabCell describes the respondents sharing a row and a column answer
+type CrossTabCell struct {
+	Count            int     `json:"count"`            // Number of answer pairs in the cell
+	RowPercentage    float64 `json:"rowPercentage"`    // Count as a percentage of the row total
+	ColumnPercentage float64 `json:"columnPercentage"` // Count as a percentage of the column total
+	TotalPercentage  float64 `json:"totalPercentage"`  // Count as a percentage of the table total
+}
+
+// computeCrossTab computes the contingency table of two questions of a survey
+// Multiple choice answers add a pair for each selected option, so totals count pairs rather than respondents
+func computeCrossTab(surv *protos.SurveyResponse, votes []*Vote, row, column int) (CrossTab, error) {
+	rq, err := crossTabQuestion(surv, row)
+	if err != nil {
+		return CrossTab{}, err
+	}
+	cq, err := crossTabQuestion(surv, column)
+	if err != nil {
+		return CrossTab{}, err
+	}
+	if row == column {
+		return CrossTab{}, fmt.Errorf("%w: row and column must be different questions", ErrInvalidRequest)
+	}
+
+	answers := groupByRespondent(votes)
+	rowValues := categoryValues(rq, answers)
+	columnValues := categoryValues(cq, answers)
+	ri := indexOf(rowValues)
+	ci := indexOf(columnValues)
+
+	table := CrossTab{
+		Survey:       surv.GetId(),
+		Row:          row,
+		Column:       column,
+		RowValues:    rowValues,
+		ColumnValues: columnValues,
+		Cells:        make([][]CrossTabCell, len(rowValues)),
+		RowTotals:    make([]int, len(rowValues)),
+		ColumnTotals: make([]int, len(columnValues)),
+	}
+	for i := range table.Cells {
+		table.Cells[i] = make([]CrossTabCell, len(columnValues))
+	}
+
+	for _, questions := range answers {
+		rv, cv := questions[row], questions[column]
+		if rv == nil || cv == nil {
+			continue
+		}
+		rs, cs := answerValues(rq, rv), answerValues(cq, cv)
+		if len(rs) == 0 || len(cs) == 0 {
+			continue
+		}
+
+		table.Respondents++
+		for _, r := range rs {
+			for _, c := range cs {
+				table.Cells[ri[r]][ci[c]].Count++
+				table.RowTotals[ri[r]]++
+				table.ColumnTotals[ci[c]]++
+				table.Total++
+			}
+		}
+	}
+
+	for i := range table.Cells {
+		for j := range table.Cells[i] {
+			cell := &table.Cells[i][j]
+			cell.RowPercentage = percentage(cell.Count, table.RowTotals[i])
+			cell.ColumnPercentage = percentage(cell.Count, table.ColumnTotals[j])
+			cell.TotalPercentage = percentage(cell.Count, table.Total)
+		}
+	}
+	return table, nil
+}
+
+// crossTabQuestion finds a question of a survey that can be cross-tabulated
+// Only choice, rating and scale questions have categorical answers
+func crossTabQuestion(surv *protos.SurveyResponse, id int) (*protos.QuestionResponse, error) {
+	for _, q := range surv.GetQuestions() {
+		if int(q.GetId()) != id {
+			continue
+		}
+		switch q.GetType() {
+		case QuestionTypeSingleChoice, QuestionTypeMultipleChoice, QuestionTypeRating, QuestionTypeScale, QuestionTypeNPS:
+			return q, nil
+		}
+		return nil, fmt.Errorf("%w: question %d of type %s cannot be cross-tabulated", ErrInvalidRequest, id, q.GetType())
+	}
+	return nil, fmt.Errorf("%w: unknown question %d", ErrInvalidRequest, id)
+}
+
+// groupByRespondent groups the votes of a survey by respondent and question
+// Votes that cannot be attributed to a respondent are left out, later votes replace earlier ones
+func groupByRespondent(votes []*Vote) map[string]map[int]*Vote {
+	answers := map[string]map[int]*Vote{}
+	for _, v := range votes {
+		if v.Respondent == "" {
+			continue
+		}
+		questions, ok := answers[v.Respondent]
+		if !ok {
+			questions = map[int]*Vote{}
+			answers[v.Respondent] = questions
+		}
+		if prev, ok := questions[v.Question]; !ok || prev.Timestamp <= v.Timestamp {
+			questions[v.Question] = v
+		}
+	}
+	return answers
+}
+
+// answerValues returns the categorical values of an answer to a question
+// This function returns the selected option IDs of choice questions and the value of rating and scale questions
+func answerValues(q *protos.QuestionResponse, v *Vote) []int {
+	switch q.GetType() {
+	case QuestionTypeRating:
+		if v.RatingValue != nil {
+			return []int{*v.RatingValue}
+		}
+	case QuestionTypeScale:
+		if v.ScaleValue != nil {
+			return []int{*v.ScaleValue}
+		}
+	case QuestionTypeNPS:
+		if v.NPSValue != nil {
+			return []int{*v.NPSValue}
+		}
+	default:
+		return v.SelectedOptions()
+	}
+	return nil
+}
+
+// categoryValues returns the categories of a question in display order
+// Options come in survey order and values span the configured range, answers outside of them are appended
+func categoryValues(q *protos.QuestionResponse, answers map[string]map[int]*Vote) []int {
+	values := []int{}
+	seen := map[int]bool{}
+	add := func(value int) {
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+
+	for _, o := range q.GetOptions() {
+		add(int(o.GetId()))
+	}
+	if q.MinValue != nil && q.MaxValue != nil && q.GetMaxValue()-q.GetMinValue() <= 100 {
+		for value := q.GetMinValue(); value <= q.GetMaxValue(); value++ {
+			add(int(value))
+		}
+	}
+
+	var extra []int
+	for _, questions := range answers {
+		if v := questions[int(q.GetId())]; v != nil {
+			for _, value := range answerValues(q, v) {
+				if !seen[value] {
+					seen[value] = true
+					extra = append(extra, value)
+				}
+			}
+		}
+	}
+	sort.Ints(extra)
+	return append(values, extra...)
+}
+
+// indexOf maps each value of a list to its position
+func indexOf(values []int) map[int]int {
+	index := make(map[int]int, len(values))
+	for i, value := range values {
+		index[value] = i
+	}
+	return index
+}
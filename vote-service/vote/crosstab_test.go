@@ -0,0 +1,77 @@
+package vote
+
+import (
+	"testing"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// newOptions creates the options of a question with the given IDs
+func newOptions(ids ...int32) []*protos.OptionResponse {
+	options := make([]*protos.OptionResponse, len(ids))
+	for i, id := range ids {
+		options[i] = &protos.OptionResponse{Id: id}
+	}
+	return options
+}
+
+// newCrossTabSurvey creates a survey with a choice question, a rating question and a text question
+func newCrossTabSurvey() *protos.SurveyResponse {
+	minValue, maxValue := int32(1), int32(3)
+	return &protos.SurveyResponse{Id: "survey-1", Questions: []*protos.QuestionResponse{
+		{Id: 1, Type: QuestionTypeMultipleChoice, Options: newOptions(1, 2)},
+		{Id: 2, Type: QuestionTypeRating, MinValue: &minValue, MaxValue: &maxValue},
+		{Id: 3, Type: QuestionTypeText},
+	}}
+}
+
+// newCrossTabVote creates a vote of a respondent selecting options or giving a rating
+func newCrossTabVote(respondent string, question int, timestamp int64, options []int, rating int) *Vote {
+	v := &Vote{Respondent: respondent, Question: question, Timestamp: timestamp, OptionIDs: options}
+	if rating > 0 {
+		v.RatingValue = &rating
+	}
+	return v
+}
+
+// TestComputeCrossTab tests the contingency table of a choice question by a rating question
+func TestComputeCrossTab(t *testing.T) {
+	votes := []*Vote{
+		newCrossTabVote("ann", 1, 10, []int{1, 2}, 0),
+		newCrossTabVote("ann", 2, 11, nil, 3),
+		newCrossTabVote("bob", 1, 20, []int{1}, 0),
+		newCrossTabVote("bob", 2, 21, nil, 1),
+		// A later answer replaces the earlier one
+		newCrossTabVote("bob", 2, 22, nil, 3),
+		// Respondents answering one of the questions are left out
+		newCrossTabVote("cid", 1, 30, []int{2}, 0),
+		newCrossTabVote("", 2, 40, nil, 2),
+	}
+
+	table, err := computeCrossTab(newCrossTabSurvey(), votes, 1, 2)
+	assert.NoError(t, err, "Expected no error on cross-tabulation")
+	assert.Equal(t, []int{1, 2}, table.RowValues, "Expected the options as rows")
+	assert.Equal(t, []int{1, 2, 3}, table.ColumnValues, "Expected the rating range as columns")
+	assert.Equal(t, 2, table.Respondents, "Expected respondents answering both questions")
+	assert.Equal(t, 3, table.Total, "Expected a pair for each selected option")
+	assert.Equal(t, []int{2, 1}, table.RowTotals, "Expected the row totals")
+	assert.Equal(t, []int{0, 0, 3}, table.ColumnTotals, "Expected the column totals")
+	assert.Equal(t, CrossTabCell{Count: 2, RowPercentage: 100, ColumnPercentage: 200.0 / 3, TotalPercentage: 200.0 / 3}, table.Cells[0][2], "Expected the percentages of the cell")
+	assert.Equal(t, CrossTabCell{}, table.Cells[1][0], "Expected empty cells")
+
+	tests := []struct {
+		name        string
+		row, column int
+	}{
+		{"same question", 1, 1},
+		{"text question", 1, 3},
+		{"unknown question", 1, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := computeCrossTab(newCrossTabSurvey(), votes, tt.row, tt.column)
+			assert.ErrorIs(t, err, ErrInvalidRequest, "Expected the questions to be rejected")
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package vote
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateGrouping defines the width of the buckets of the distribution of date answers
+type DateGrouping string
+
+// Available date groupings
+const (
+	DateGroupingDay   DateGrouping = "day"   // Date answers are counted per day, keyed as 2006-01-02
+	DateGroupingWeek  DateGrouping = "week"  // Date answers are counted per ISO week, keyed as 2006-W01
+	DateGroupingMonth DateGrouping = "month" // Date answers are counted per month, keyed as 2006-01
+)
+
+// Available date modes of date questions
+const (
+	DateModeDate     = "date"     // Calendar day, answered as midnight UTC
+	DateModeDateTime = "datetime" // Date and time of day
+)
+
+// secondsPerDay is the number of seconds in a day, days start at multiples of it in Unix time
+// Days before 1970 are negative multiples, whose remainder is zero too
+const secondsPerDay = 24 * 60 * 60
+
+// dayLayout is the layout of the keys of the distribution of date answers grouped by day
+const dayLayout = "2006-01-02"
+
+// IsValid checks if the date grouping is supported
+func (g DateGrouping) IsValid() bool {
+	return g == DateGroupingDay || g == DateGroupingWeek || g == DateGroupingMonth
+}
+
+// key returns the bucket of a date answer, dates are grouped in UTC
+func (g DateGrouping) key(t time.Time) string {
+	t = t.UTC()
+	switch g {
+	case DateGroupingWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case DateGroupingMonth:
+		return t.Format("2006-01")
+	default:
+		return t.Format(dayLayout)
+	}
+}
+
+// GroupDates regroups the distributions of date answers of results, which are computed per day
+// The results are returned with a copy of their question results, so the given results are not changed
+// This function returns ErrInvalidRequest for unknown groupings and distributions not grouped by day
+func GroupDates(results Results, g DateGrouping) (Results, error) {
+	if !g.IsValid() {
+		return results, fmt.Errorf("%w: unknown date grouping %q", ErrInvalidRequest, g)
+	}
+
+	grouped := copyResults(results)
+	for i, qr := range grouped.Results {
+		if qr.DateDistribution == nil || qr.DateGrouping == g {
+			continue
+		}
+		distribution := map[string]int{}
+		for day, count := range qr.DateDistribution {
+			t, err := time.Parse(dayLayout, day)
+			if err != nil {
+				return results, fmt.Errorf("%w: date distribution of question %d is not grouped by day", ErrInvalidRequest, qr.Question)
+			}
+			distribution[g.key(t)] += count
+		}
+		grouped.Results[i].DateDistribution = distribution
+		grouped.Results[i].DateGrouping = g
+	}
+	return grouped, nil
+}
@@ -0,0 +1,54 @@
+package vote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGroupDates tests regrouping daily date distributions by ISO week and month across year boundaries
+func TestGroupDates(t *testing.T) {
+	days := map[string]int{
+		"1969-12-31": 1,
+		"1970-01-01": 1,
+		"2020-12-31": 1,
+		"2021-01-03": 2,
+		"2021-01-04": 1,
+		"2024-12-30": 1,
+		"2025-01-01": 1,
+	}
+
+	tests := []struct {
+		grouping DateGrouping
+		want     map[string]int
+	}{
+		{DateGroupingDay, days},
+		{DateGroupingWeek, map[string]int{"1970-W01": 2, "2020-W53": 3, "2021-W01": 1, "2025-W01": 2}},
+		{DateGroupingMonth, map[string]int{"1969-12": 1, "1970-01": 1, "2020-12": 1, "2021-01": 3, "2024-12": 1, "2025-01": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.grouping), func(t *testing.T) {
+			results := Results{Survey: "survey-1", Results: []QuestionResults{
+				{Question: 1, TotalVotes: 8, DateDistribution: days, DateGrouping: DateGroupingDay},
+				{Question: 2, TotalVotes: 1},
+			}}
+
+			grouped, err := GroupDates(results, tt.grouping)
+			assert.NoError(t, err, "Expected no error on grouping")
+			assert.Equal(t, tt.want, grouped.Results[0].DateDistribution, "Expected the dates to be counted per bucket")
+			assert.Equal(t, tt.grouping, grouped.Results[0].DateGrouping, "Expected the grouping to be reported")
+			assert.Equal(t, QuestionResults{Question: 2, TotalVotes: 1}, grouped.Results[1], "Expected other questions to be unchanged")
+			assert.Equal(t, DateGroupingDay, results.Results[0].DateGrouping, "Expected the given results not to change")
+		})
+	}
+}
+
+// TestGroupDatesInvalid tests that unknown groupings and distributions not grouped by day are rejected
+func TestGroupDatesInvalid(t *testing.T) {
+	_, err := GroupDates(Results{}, "year")
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected unknown groupings to be rejected")
+
+	weekly := Results{Results: []QuestionResults{{Question: 1, DateDistribution: map[string]int{"2021-W01": 1}, DateGrouping: DateGroupingWeek}}}
+	_, err = GroupDates(weekly, DateGroupingMonth)
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected distributions not grouped by day to be rejected")
+}
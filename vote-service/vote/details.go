@@ -0,0 +1,72 @@
+package vote
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxCachedDetails is the number of surveys whose detailed results are cached
+const maxCachedDetails = 1000
+
+// detailsCache caches the detailed results of surveys until their stored counts change
+// Computing the details loads every vote of a survey, so they are only recomputed after new votes
+type detailsCache struct {
+	entries map[string]detailsEntry
+	mutex   sync.Mutex
+}
+
+// detailsEntry is the detailed results of a survey with the state they were computed from
+type detailsEntry struct {
+	key     string                 // Update time and vote counts of the stored results
+	survey  *protos.SurveyResponse // Survey definition the details were computed with
+	results Results                // Detailed results
+}
+
+// newDetailsCache creates an empty details cache
+func newDetailsCache() *detailsCache {
+	return &detailsCache{entries: make(map[string]detailsEntry)}
+}
+
+// get returns a copy of the cached details of stored results, when they were computed from the same counts and survey
+func (c *detailsCache) get(results Results, surv *protos.SurveyResponse) (Results, bool) {
+	c.mutex.Lock()
+	entry, ok := c.entries[results.Survey]
+	c.mutex.Unlock()
+	if !ok || entry.key != detailsKey(results) || !proto.Equal(entry.survey, surv) {
+		return Results{}, false
+	}
+	return copyResults(entry.results), true
+}
+
+// put caches the details computed from stored results and a survey
+// The key must be computed from the stored results before the details are added
+func (c *detailsCache) put(key string, surv *protos.SurveyResponse, detailed Results) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.entries[detailed.Survey]; !ok && len(c.entries) >= maxCachedDetails {
+		c.entries = make(map[string]detailsEntry)
+	}
+	c.entries[detailed.Survey] = detailsEntry{key: key, survey: surv, results: copyResults(detailed)}
+}
+
+// detailsKey identifies the state of stored results by their update time and vote counts
+func detailsKey(results Results) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d", results.UpdatedAt)
+	for _, qr := range results.Results {
+		fmt.Fprintf(&b, ";%d:%d", qr.Question, qr.TotalVotes)
+	}
+	return b.String()
+}
+
+// copyResults copies the list of question results, so callers can change it without changing the cache
+func copyResults(results Results) Results {
+	if results.Results != nil {
+		results.Results = append(make([]QuestionResults, 0, len(results.Results)), results.Results...)
+	}
+	return results
+}
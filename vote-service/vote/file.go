@@ -0,0 +1,107 @@
+package vote
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	// ErrFileNotFound indicates that no uploaded file exists for an ID
+	ErrFileNotFound = errors.New("File not found")
+
+	// ErrFileTooLarge indicates that an uploaded file exceeds the size limit of its question
+	ErrFileTooLarge = errors.New("File too large")
+
+	// ErrFileAttached indicates that an uploaded file already answers a vote
+	ErrFileAttached = errors.New("File already attached to an answer")
+)
+
+// File describes a file uploaded to answer a file question
+// This struct holds the metadata of the file, its content is kept in the blob storage
+type File struct {
+	ID          string `json:"id"`          // Unique identifier for the file, also its key in the blob storage
+	Survey      string `json:"survey"`      // Survey ID the file was uploaded for
+	Question    int    `json:"question"`    // Question ID within the survey
+	Name        string `json:"name"`        // Original name of the file
+	ContentType string `json:"contentType"` // MIME type of the file
+	Size        int64  `json:"size"`        // Size of the file in bytes
+	UserID      string `json:"-"`           // ID of the user who uploaded the file, empty for anonymous uploads
+	CreatedAt   int64  `json:"createdAt"`   // Timestamp when the file was uploaded
+	AttachedAt  int64  `json:"-"`           // Timestamp when a vote referenced the file, zero while it answers no vote
+}
+
+// FileUpload describes a file being uploaded
+type FileUpload struct {
+	Survey      string    // Survey ID the file is uploaded for
+	Question    int       // Question ID within the survey
+	Name        string    // Original name of the file
+	ContentType string    // MIME type declared by the client, checked like the type detected from the content
+	Content     io.Reader // Content of the file
+}
+
+// FileAnswer references the uploaded file answering a file question
+// Votes only need the ID, the other fields are set from the uploaded file when the vote is cast
+type FileAnswer struct {
+	ID          string `json:"id"`                    // ID of the uploaded file
+	Name        string `json:"name,omitempty"`        // Original name of the file
+	ContentType string `json:"contentType,omitempty"` // MIME type of the file
+	Size        int64  `json:"size,omitempty"`        // Size of the file in bytes
+}
+
+// FileURL returns the path to download an uploaded file
+func FileURL(id string) string {
+	return "/files/" + id
+}
+
+// FileService contains functions to upload and download the files answering file questions
+// This interface defines the methods required for handling uploaded files
+type FileService interface {
+	// Upload stores a file uploaded for a file question
+	// This method checks the question accepts the type and size of the file
+	Upload(user *User, upload *FileUpload) (*File, error)
+
+	// Download opens an uploaded file
+	// This method checks the user can view the results of the survey the file was uploaded for
+	Download(user *User, id string) (*File, io.ReadCloser, error)
+
+	// DeleteOrphans deletes the files no vote referenced within the retention period
+	// This method returns the number of deleted files
+	DeleteOrphans() (int, error)
+}
+
+// FileRepository contains functions to store the metadata of uploaded files
+// This interface defines the methods required for reading and writing file metadata
+type FileRepository interface {
+	// Insert stores the metadata of a new file
+	Insert(f *File) error
+
+	// Load gets the metadata of a file by ID
+	// This method returns ErrFileNotFound for unknown files
+	Load(id string) (*File, error)
+
+	// Attach marks a file as answering a vote, unless another vote already references it
+	// This method returns ErrFileAttached for attached files and ErrFileNotFound for unknown files
+	Attach(id string, at int64) error
+
+	// Detach marks a file as answering no vote, when the vote referencing it could not be stored
+	Detach(id string) error
+
+	// DeleteUnattached deletes the metadata of the files uploaded before a time and answering no vote
+	// This method returns the IDs of the deleted files, so their content can be removed
+	DeleteUnattached(before int64) ([]string, error)
+}
+
+// BlobStorage contains functions to store the content of uploaded files
+// This interface lets the content live on the local filesystem or in an object store
+type BlobStorage interface {
+	// Put stores content under a key, reading at most limit bytes
+	// This method returns ErrFileTooLarge when the content is longer than the limit
+	Put(key string, content io.Reader, limit int64) (int64, error)
+
+	// Open opens the content stored under a key
+	// This method returns ErrFileNotFound for unknown keys
+	Open(key string) (io.ReadCloser, error)
+
+	// Delete removes the content stored under a key
+	Delete(key string) error
+}
@@ -0,0 +1,217 @@
+package vote
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	uuid "github.com/satori/go.uuid"
+)
+
+// sniffLength is the number of bytes used to detect the type of uploaded files
+const sniffLength = 512
+
+// maxFileNameLength is the maximum length of the names of uploaded files
+const maxFileNameLength = 255
+
+// refinedTypes lists the declared types that narrow a generic type detected from the content
+// Formats such as CSV files or office documents are detected as plain text or zip archives
+var refinedTypes = map[string][]string{
+	"text/plain": {"text/csv", "text/markdown", "text/tab-separated-values"},
+	"application/zip": {
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		"application/vnd.oasis.opendocument.text",
+		"application/vnd.oasis.opendocument.spreadsheet",
+		"application/vnd.oasis.opendocument.presentation",
+	},
+}
+
+// fileService implements the FileService interface for managing uploaded files
+type fileService struct {
+	files     FileRepository
+	blobs     BlobStorage
+	surveys   SurveyRepository
+	maxSize   int64
+	retention time.Duration
+}
+
+// NewFileService creates a new service of uploaded files
+// This function initializes a service storing file metadata in the repository and content in the blob storage
+// Files are limited to maxSize bytes unless their question sets a lower limit
+// Files no vote referenced within the retention period are deleted as orphans
+// Files can only be downloaded by the users who can view the results of their survey, even when results are public
+func NewFileService(files FileRepository, blobs BlobStorage, surveys SurveyRepository, maxSize int64, retention time.Duration) FileService {
+	return &fileService{
+		files:     files,
+		blobs:     blobs,
+		surveys:   surveys,
+		maxSize:   maxSize,
+		retention: retention,
+	}
+}
+
+// Upload stores a file uploaded for a file question
+// The content type is detected from the content, so the declared type cannot disguise a file of another type
+func (s *fileService) Upload(user *User, upload *FileUpload) (*File, error) {
+	surv, err := s.surveys.GetSurvey(upload.Survey)
+	if err != nil {
+		if errors.Is(err, ErrSurveyNotFound) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+		}
+		return nil, err
+	}
+	if user == nil && !surv.GetAllowAnonymous() && !surv.GetInviteOnly() {
+		return nil, ErrAuthenticationRequired
+	}
+
+	q := findQuestion(upload.Question, surv.GetQuestions())
+	if q == nil || q.GetType() != QuestionTypeFile {
+		return nil, fmt.Errorf("%w: question %d does not accept files", ErrInvalidRequest, upload.Question)
+	}
+
+	// Detect the type from the start of the content, which is then stored with the rest
+	// The declared type is only kept when it narrows the detected type, and is checked like it
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+	contentType := fileContentType(upload.ContentType, head)
+	if !isAllowedType(contentType, q.GetFileConstraints().GetAllowedTypes()) {
+		return nil, fmt.Errorf("%w: files of type %s are not accepted", ErrInvalidRequest, contentType)
+	}
+
+	f := &File{
+		ID:          uuid.NewV4().String(),
+		Survey:      upload.Survey,
+		Question:    upload.Question,
+		Name:        fileName(upload.Name),
+		ContentType: contentType,
+		CreatedAt:   time.Now().UTC().Unix(),
+	}
+	if user != nil {
+		f.UserID = user.ID
+	}
+
+	f.Size, err = s.blobs.Put(f.ID, io.MultiReader(bytes.NewReader(head), upload.Content), s.sizeLimit(q))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.files.Insert(f); err != nil {
+		s.blobs.Delete(f.ID)
+		return nil, err
+	}
+	return f, nil
+}
+
+// Download opens an uploaded file
+// Files are only available to the users who own their survey or have it shared, as respondent documents are never public
+func (s *fileService) Download(user *User, id string) (*File, io.ReadCloser, error) {
+	f, err := s.files.Load(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := authorizeResults(s.surveys, user, f.Survey); err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Open(f.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, content, nil
+}
+
+// DeleteOrphans deletes the files no vote referenced within the retention period
+// The metadata is deleted first, so votes can no longer reference a file whose content is being removed
+func (s *fileService) DeleteOrphans() (int, error) {
+	before := time.Now().UTC().Add(-s.retention).Unix()
+	ids, err := s.files.DeleteUnattached(before)
+	if err != nil {
+		return 0, err
+	}
+
+	var failed []string
+	for _, id := range ids {
+		if err := s.blobs.Delete(id); err != nil {
+			failed = append(failed, id)
+		}
+	}
+	if len(failed) > 0 {
+		return len(ids) - len(failed), fmt.Errorf("unable to delete the content of files %s", strings.Join(failed, ", "))
+	}
+	return len(ids), nil
+}
+
+// sizeLimit returns the maximum size of the files uploaded to a question
+func (s *fileService) sizeLimit(q *protos.QuestionResponse) int64 {
+	if max := q.GetFileConstraints().GetMaxSize(); max > 0 && max < s.maxSize {
+		return max
+	}
+	return s.maxSize
+}
+
+// fileContentType returns the media type of an uploaded file, without parameters
+// The type is detected from the start of the content, and only replaced by a declared type narrowing it
+func fileContentType(declared string, head []byte) string {
+	detected := mediaType(http.DetectContentType(head))
+	declared = mediaType(declared)
+	for _, refined := range refinedTypes[detected] {
+		if declared == refined {
+			return declared
+		}
+	}
+	return detected
+}
+
+// mediaType returns a content type without parameters, unknown types being binary content
+func mediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
+
+// isAllowedType checks if a media type matches the allowed types of a question, any type matches an empty list
+// Allowed types can be wildcards such as image/*
+func isAllowedType(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if a == contentType || (strings.HasSuffix(a, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(a, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileName returns the base name of an uploaded file, shortened to the maximum length in bytes
+// Names are only cut between runes, so a shortened name stays valid UTF-8
+func fileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		return "file"
+	}
+	if len(name) > maxFileNameLength {
+		end := maxFileNameLength
+		for end > 0 && !utf8.RuneStart(name[end]) {
+			end--
+		}
+		name = name[:end]
+	}
+	return name
+}
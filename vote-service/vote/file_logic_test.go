@@ -0,0 +1,249 @@
+package vote
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// pngHeader is the signature starting PNG images
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+// testFiles is a file repository storing file metadata in memory
+type testFiles struct {
+	files map[string]File
+}
+
+// Insert stores a copy of the file
+func (r *testFiles) Insert(f *File) error {
+	r.files[f.ID] = *f
+	return nil
+}
+
+// Load returns a copy of the file
+func (r *testFiles) Load(id string) (*File, error) {
+	f, ok := r.files[id]
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+	return &f, nil
+}
+
+// Attach marks the file as attached unless it already is
+func (r *testFiles) Attach(id string, at int64) error {
+	f, ok := r.files[id]
+	if !ok {
+		return ErrFileNotFound
+	}
+	if f.AttachedAt != 0 {
+		return ErrFileAttached
+	}
+	f.AttachedAt = at
+	r.files[id] = f
+	return nil
+}
+
+// Detach marks the file as not attached
+func (r *testFiles) Detach(id string) error {
+	f := r.files[id]
+	f.AttachedAt = 0
+	r.files[id] = f
+	return nil
+}
+
+// DeleteUnattached deletes the files uploaded before a time and not attached
+func (r *testFiles) DeleteUnattached(before int64) ([]string, error) {
+	var ids []string
+	for id, f := range r.files {
+		if f.AttachedAt == 0 && f.CreatedAt < before {
+			delete(r.files, id)
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// testBlobs is a blob storage keeping contents in memory
+type testBlobs struct {
+	contents map[string][]byte
+}
+
+// Put stores the content, failing when it is longer than the limit
+func (b *testBlobs) Put(key string, content io.Reader, limit int64) (int64, error) {
+	data, err := io.ReadAll(io.LimitReader(content, limit+1))
+	if err != nil {
+		return 0, err
+	}
+	if int64(len(data)) > limit {
+		return 0, ErrFileTooLarge
+	}
+	b.contents[key] = data
+	return int64(len(data)), nil
+}
+
+// Open returns a reader of the content
+func (b *testBlobs) Open(key string) (io.ReadCloser, error) {
+	data, ok := b.contents[key]
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Delete removes the content
+func (b *testBlobs) Delete(key string) error {
+	delete(b.contents, key)
+	return nil
+}
+
+// fileSurvey is an anonymous survey with a question accepting images and CSV files
+var fileSurvey = &protos.SurveyResponse{Id: "survey-1", AllowAnonymous: true, Questions: []*protos.QuestionResponse{
+	{Id: 1, Type: QuestionTypeFile, FileConstraints: &protos.FileConstraintsResponse{AllowedTypes: []string{"image/*", "text/csv"}}},
+}}
+
+// newUpload creates an upload to the file question of the test survey
+func newUpload(contentType string, content []byte) *FileUpload {
+	return &FileUpload{Survey: "survey-1", Question: 1, Name: "upload", ContentType: contentType, Content: bytes.NewReader(content)}
+}
+
+// TestUploadDetectsType tests that uploads are checked and stored with the type detected from their content
+func TestUploadDetectsType(t *testing.T) {
+	files := &testFiles{files: make(map[string]File)}
+	blobs := &testBlobs{contents: make(map[string][]byte)}
+	s := NewFileService(files, blobs, &testSurveys{fileSurvey}, 1<<20, time.Hour)
+
+	f, err := s.Upload(nil, newUpload("", append(pngHeader, 0, 0, 0)))
+	assert.NoError(t, err, "Expected the image to be accepted")
+	assert.Equal(t, "image/png", f.ContentType, "Expected the detected type")
+	assert.Len(t, blobs.contents[f.ID], len(pngHeader)+3, "Expected the whole content to be stored")
+
+	_, err = s.Upload(nil, newUpload("image/png", []byte("<html><script>alert(1)</script></html>")))
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected a page declared as an image to be rejected")
+
+	f, err = s.Upload(nil, newUpload("text/csv", []byte("name,score\nann,4\n")))
+	assert.NoError(t, err, "Expected a CSV file to be accepted")
+	assert.Equal(t, "text/csv", f.ContentType, "Expected the declared type to narrow plain text")
+
+	_, err = s.Upload(nil, newUpload("text/plain", []byte("name,score\n")))
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected plain text to be rejected")
+	assert.Len(t, files.files, 2, "Expected rejected files not to be stored")
+}
+
+// TestDeleteOrphans tests that files no vote references are deleted after the retention period
+func TestDeleteOrphans(t *testing.T) {
+	files := &testFiles{files: make(map[string]File)}
+	blobs := &testBlobs{contents: make(map[string][]byte)}
+	s := NewFileService(files, blobs, &testSurveys{fileSurvey}, 1<<20, time.Hour)
+	old := time.Now().Add(-2 * time.Hour).Unix()
+	for _, f := range []File{
+		{ID: "orphan", CreatedAt: old},
+		{ID: "attached", CreatedAt: old, AttachedAt: old},
+		{ID: "recent", CreatedAt: time.Now().Unix()},
+	} {
+		files.files[f.ID] = f
+		blobs.contents[f.ID] = pngHeader
+	}
+
+	deleted, err := s.DeleteOrphans()
+	assert.NoError(t, err, "Expected no error on delete")
+	assert.Equal(t, 1, deleted, "Expected the orphaned file to be deleted")
+	assert.NotContains(t, files.files, "orphan", "Expected the metadata of the orphan to be deleted")
+	assert.NotContains(t, blobs.contents, "orphan", "Expected the content of the orphan to be deleted")
+	assert.Contains(t, blobs.contents, "attached", "Expected attached files to be kept")
+	assert.Contains(t, blobs.contents, "recent", "Expected recent files to be kept")
+}
+
+// TestDownloadRequiresAccess tests that files can only be downloaded by the users who can view the results of their survey
+func TestDownloadRequiresAccess(t *testing.T) {
+	files := &testFiles{files: map[string]File{"file-1": {ID: "file-1", Survey: "survey-1", Question: 1}}}
+	blobs := &testBlobs{contents: map[string][]byte{"file-1": pngHeader}}
+	surv := &protos.SurveyResponse{Id: "survey-1", OwnerId: "owner-1", SharedWith: []string{"viewer-1"}}
+	s := NewFileService(files, blobs, &testSurveys{surv}, 1<<20, time.Hour)
+
+	for _, user := range []*User{{ID: "owner-1", Roles: []string{RoleEditor}}, {ID: "viewer-1", Roles: []string{RoleViewer}}} {
+		f, content, err := s.Download(user, "file-1")
+		assert.NoError(t, err, "Expected %s to download the file", user.ID)
+		assert.Equal(t, "file-1", f.ID, "Expected the downloaded file")
+		content.Close()
+	}
+
+	_, _, err := s.Download(nil, "file-1")
+	assert.ErrorIs(t, err, ErrForbidden, "Expected anonymous users not to download files")
+	_, _, err = s.Download(&User{ID: "user-2", Roles: []string{RoleViewer}}, "file-1")
+	assert.ErrorIs(t, err, ErrForbidden, "Expected other users not to download files")
+}
+
+// TestFileContentType tests that declared types only narrow the type detected from the content
+func TestFileContentType(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n")
+	text := []byte("a,b\n1,2\n")
+	zip := []byte("PK\x03\x04")
+	docx := "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+	tests := []struct {
+		name     string
+		declared string
+		head     []byte
+		want     string
+	}{
+		{"detected", "", pdf, "application/pdf"},
+		{"declared other type", "image/png", pdf, "application/pdf"},
+		{"declared generic type", "application/octet-stream", pngHeader, "image/png"},
+		{"declared text format", "text/csv; charset=utf-8", text, "text/csv"},
+		{"declared page as text", "text/html", text, "text/plain"},
+		{"declared office document", docx, zip, docx},
+		{"invalid declared type", "not a type", text, "text/plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fileContentType(tt.declared, tt.head), "Expected the content type")
+		})
+	}
+}
+
+// TestIsAllowedType tests matching media types with the allowed types of a question
+func TestIsAllowedType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		allowed     []string
+		want        bool
+	}{
+		{"application/pdf", nil, true},
+		{"application/pdf", []string{"application/pdf"}, true},
+		{"image/png", []string{"image/*", "application/pdf"}, true},
+		{"image/png", []string{"IMAGE/*"}, true},
+		{"text/plain", []string{"image/*", "application/pdf"}, false},
+		{"imagery/png", []string{"image/*"}, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isAllowedType(tt.contentType, tt.allowed), "Expected %s to match %v", tt.contentType, tt.allowed)
+	}
+}
+
+// TestFileName tests that uploaded file names are reduced to a bounded base name
+func TestFileName(t *testing.T) {
+	long := make([]byte, maxFileNameLength+10)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\ann\photo.png`, "photo.png"},
+		{"", "file"},
+		{"/", "file"},
+		{string(long), string(long[:maxFileNameLength])},
+		{string(long[:maxFileNameLength-1]) + "é", string(long[:maxFileNameLength-1])},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, fileName(tt.name), "Expected the base name of %q", tt.name)
+	}
+}
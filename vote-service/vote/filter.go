@@ -0,0 +1,176 @@
+package vote
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FilterField defines the answer value a filter compares
+type FilterField string
+
+// Available filter fields, named after the answer fields of a vote
+const (
+	FilterOptionID    FilterField = "optionId"    // Option selected on a choice question
+	FilterTextAnswer  FilterField = "textAnswer"  // Text answer of a text question
+	FilterRatingValue FilterField = "ratingValue" // Rating value of a rating question
+	FilterScaleValue  FilterField = "scaleValue"  // Scale value of a scale question
+	FilterDateAnswer  FilterField = "dateAnswer"  // Date answer as Unix timestamp
+	FilterNPSValue    FilterField = "npsValue"    // Score of an NPS question
+)
+
+// FilterOperator defines how a filter compares an answer value
+type FilterOperator string
+
+// Available filter operators, longest first so they are parsed correctly
+var filterOperators = []FilterOperator{">=", "<=", "!=", "=", ">", "<"}
+
+// filterOperatorChars are the characters operators start with
+const filterOperatorChars = "=!<>"
+
+// Filter describes a condition on the answer of a respondent to a survey question
+// Results filtered by a segment only count the respondents matching every filter
+type Filter struct {
+	Question int            // Question ID within the survey
+	Field    FilterField    // Answer value compared
+	Operator FilterOperator // Comparison applied to the answer value
+	Value    string         // Value the answer is compared to
+}
+
+// ParseFilter parses a filter written as question.field, an operator and a value
+// For example "1.optionId=2" or "3.ratingValue>=4"
+// The operator is the first one after question.field, so values can contain operators, as in "1.textAnswer=a!=b"
+func ParseFilter(s string) (Filter, error) {
+	invalid := fmt.Errorf("%w: invalid filter %q", ErrInvalidRequest, s)
+
+	i := strings.IndexAny(s, filterOperatorChars)
+	if i < 0 {
+		return Filter{}, invalid
+	}
+	var op FilterOperator
+	for _, candidate := range filterOperators {
+		if strings.HasPrefix(s[i:], string(candidate)) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return Filter{}, invalid
+	}
+
+	parts := strings.SplitN(s[:i], ".", 2)
+	if len(parts) != 2 {
+		return Filter{}, invalid
+	}
+	question, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Filter{}, invalid
+	}
+
+	f := Filter{Question: question, Field: FilterField(parts[1]), Operator: op, Value: s[i+len(op):]}
+	if err := f.validate(); err != nil {
+		return Filter{}, err
+	}
+	return f, nil
+}
+
+// validate checks that the filter compares a known field with a value of the right type
+func (f Filter) validate() error {
+	switch f.Field {
+	case FilterTextAnswer:
+		if f.Operator != "=" && f.Operator != "!=" {
+			return fmt.Errorf("%w: text answers can only be compared with = and !=", ErrInvalidRequest)
+		}
+		return nil
+	case FilterOptionID:
+		if f.Operator != "=" && f.Operator != "!=" {
+			return fmt.Errorf("%w: options can only be compared with = and !=", ErrInvalidRequest)
+		}
+	case FilterRatingValue, FilterScaleValue, FilterDateAnswer, FilterNPSValue:
+	default:
+		return fmt.Errorf("%w: unknown filter field %q", ErrInvalidRequest, f.Field)
+	}
+
+	if _, err := strconv.ParseInt(f.Value, 10, 64); err != nil {
+		return fmt.Errorf("%w: filter value of %s must be a number", ErrInvalidRequest, f.Field)
+	}
+	return nil
+}
+
+// Matches checks if the answer of a vote satisfies the filter
+// Votes without the compared value never match
+func (f Filter) Matches(v *Vote) bool {
+	switch f.Field {
+	case FilterTextAnswer:
+		if v.TextAnswer == nil {
+			return false
+		}
+		return (*v.TextAnswer == f.Value) == (f.Operator == "=")
+	case FilterOptionID:
+		want, _ := strconv.Atoi(f.Value)
+		selected := false
+		for _, id := range v.SelectedOptions() {
+			if id == want {
+				selected = true
+			}
+		}
+		return selected == (f.Operator == "=")
+	}
+
+	var value int64
+	switch {
+	case f.Field == FilterRatingValue && v.RatingValue != nil:
+		value = int64(*v.RatingValue)
+	case f.Field == FilterScaleValue && v.ScaleValue != nil:
+		value = int64(*v.ScaleValue)
+	case f.Field == FilterDateAnswer && v.DateAnswer != nil:
+		value = *v.DateAnswer
+	case f.Field == FilterNPSValue && v.NPSValue != nil:
+		value = int64(*v.NPSValue)
+	default:
+		return false
+	}
+
+	want, _ := strconv.ParseInt(f.Value, 10, 64)
+	switch f.Operator {
+	case "=":
+		return value == want
+	case "!=":
+		return value != want
+	case ">":
+		return value > want
+	case ">=":
+		return value >= want
+	case "<":
+		return value < want
+	case "<=":
+		return value <= want
+	}
+	return false
+}
+
+// filterVotes keeps the votes of the respondents matching every filter
+// Votes that cannot be attributed to a respondent are left out, since they cannot be segmented
+func filterVotes(votes []*Vote, filters []Filter) []*Vote {
+	answers := groupByRespondent(votes)
+	matching := map[string]bool{}
+	for respondent, questions := range answers {
+		matches := true
+		for _, f := range filters {
+			v := questions[f.Question]
+			if v == nil || !f.Matches(v) {
+				matches = false
+				break
+			}
+		}
+		matching[respondent] = matches
+	}
+
+	var segment []*Vote
+	for _, v := range votes {
+		if matching[v.Respondent] {
+			segment = append(segment, v)
+		}
+	}
+	return segment
+}
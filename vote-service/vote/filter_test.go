@@ -0,0 +1,97 @@
+package vote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseFilter tests parsing filters, splitting at the first operator after question.field
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Filter
+	}{
+		{"1.optionId=2", Filter{Question: 1, Field: FilterOptionID, Operator: "=", Value: "2"}},
+		{"3.ratingValue>=4", Filter{Question: 3, Field: FilterRatingValue, Operator: ">=", Value: "4"}},
+		{"3.scaleValue<2", Filter{Question: 3, Field: FilterScaleValue, Operator: "<", Value: "2"}},
+		{"2.npsValue!=10", Filter{Question: 2, Field: FilterNPSValue, Operator: "!=", Value: "10"}},
+		{"1.textAnswer=a!=b", Filter{Question: 1, Field: FilterTextAnswer, Operator: "=", Value: "a!=b"}},
+		{"1.textAnswer=x>=y", Filter{Question: 1, Field: FilterTextAnswer, Operator: "=", Value: "x>=y"}},
+		{"1.textAnswer!==", Filter{Question: 1, Field: FilterTextAnswer, Operator: "!=", Value: "="}},
+		{"1.textAnswer=", Filter{Question: 1, Field: FilterTextAnswer, Operator: "=", Value: ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			f, err := ParseFilter(tt.in)
+			assert.NoError(t, err, "Expected no error on parse")
+			assert.Equal(t, tt.want, f, "Expected the parsed filter")
+		})
+	}
+
+	for _, in := range []string{"", "1.optionId", "optionId=2", "x.optionId=2", "1.unknown=2", "1.textAnswer>a", "1.optionId>=2", "1.ratingValue=high", "1!.ratingValue=2"} {
+		t.Run(in, func(t *testing.T) {
+			_, err := ParseFilter(in)
+			assert.ErrorIs(t, err, ErrInvalidRequest, "Expected the filter to be rejected")
+		})
+	}
+}
+
+// TestFilterMatches tests comparing the answers of votes with filters
+func TestFilterMatches(t *testing.T) {
+	rating, text, option := 4, "yes", 2
+	v := &Vote{RatingValue: &rating, TextAnswer: &text, OptionID: &option, AnswerType: AnswerTypeOption}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"1.ratingValue=4", true},
+		{"1.ratingValue!=4", false},
+		{"1.ratingValue>3", true},
+		{"1.ratingValue>=5", false},
+		{"1.ratingValue<5", true},
+		{"1.ratingValue<=3", false},
+		{"1.textAnswer=yes", true},
+		{"1.textAnswer!=yes", false},
+		{"1.optionId=2", true},
+		{"1.optionId!=2", false},
+		{"1.npsValue>=0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			assert.NoError(t, err, "Expected no error on parse")
+			assert.Equal(t, tt.want, f.Matches(v), "Expected the vote to match the filter")
+		})
+	}
+}
+
+// TestFilterVotes tests that the votes of the respondents matching every filter are kept
+func TestFilterVotes(t *testing.T) {
+	votes := []*Vote{
+		newCrossTabVote("ann", 1, 10, []int{1}, 0),
+		newCrossTabVote("ann", 2, 11, nil, 3),
+		newCrossTabVote("bob", 1, 20, []int{1}, 0),
+		newCrossTabVote("bob", 2, 21, nil, 1),
+		newCrossTabVote("cid", 2, 30, nil, 3),
+		newCrossTabVote("", 1, 40, []int{1}, 0),
+	}
+	option, _ := ParseFilter("1.optionId=1")
+	rating, _ := ParseFilter("2.ratingValue>=2")
+
+	tests := []struct {
+		name    string
+		filters []Filter
+		want    []*Vote
+	}{
+		{"option", []Filter{option}, votes[:4]},
+		{"rating", []Filter{rating}, []*Vote{votes[0], votes[1], votes[4]}},
+		{"option and rating", []Filter{option, rating}, votes[:2]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filterVotes(votes, tt.filters), "Expected the votes of the matching respondents")
+		})
+	}
+}
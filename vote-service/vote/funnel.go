@@ -0,0 +1,119 @@
+package vote
+
+import (
+	"sort"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+)
+
+// Funnel describes how far the respondents of a survey got
+// This struct reports starts vs completions and where respondents dropped off, computed from stored votes
+type Funnel struct {
+	Survey         string           `json:"survey"`         // Survey ID associated with the funnel
+	Started        int              `json:"started"`        // Number of respondents who answered at least one question
+	Completed      int              `json:"completed"`      // Number of respondents who answered every required question
+	CompletionRate float64          `json:"completionRate"` // Percentage of started respondents who completed the survey
+	Unattributed   int              `json:"unattributed"`   // Number of votes that cannot be grouped by respondent and are left out
+	Questions      []QuestionFunnel `json:"questions"`      // Funnel of each question, in survey order
+}
+
+// QuestionFunnel describes the respondents reaching, answering and leaving a survey question
+type QuestionFunnel struct {
+	Question      int      `json:"question"`                // Question ID within the survey
+	Reached       int      `json:"reached"`                 // Number of respondents who answered this or a later question
+	Answered      int      `json:"answered"`                // Number of respondents who answered this question
+	DroppedOff    int      `json:"droppedOff"`              // Number of respondents who did not complete and answered nothing later
+	DropOffRate   float64  `json:"dropOffRate"`             // Percentage of respondents reaching the question who dropped off there
+	MedianSeconds *float64 `json:"medianSeconds,omitempty"` // Median time between the previous answer of a respondent and this one
+}
+
+// respondentVotes holds the votes of one respondent, ordered by time
+type respondentVotes []*Vote
+
+// computeFunnel computes the funnel of a survey from its votes
+// Votes are grouped by respondent, a respondent completes the survey by answering every required
+// question, or every question when none is required
+func computeFunnel(surv *protos.SurveyResponse, votes []*Vote) Funnel {
+	questions := surv.GetQuestions()
+	funnel := Funnel{Survey: surv.GetId(), Questions: make([]QuestionFunnel, len(questions))}
+
+	// Position of each question in the survey, and the questions needed to complete it
+	index := map[int]int{}
+	var required []int
+	for i, q := range questions {
+		index[int(q.GetId())] = i
+		funnel.Questions[i].Question = int(q.GetId())
+		if q.GetRequired() {
+			required = append(required, int(q.GetId()))
+		}
+	}
+	if len(required) == 0 {
+		for _, q := range questions {
+			required = append(required, int(q.GetId()))
+		}
+	}
+
+	respondents := map[string]respondentVotes{}
+	for _, v := range votes {
+		if _, ok := index[v.Question]; !ok {
+			continue
+		}
+		if v.Respondent == "" {
+			funnel.Unattributed++
+			continue
+		}
+		respondents[v.Respondent] = append(respondents[v.Respondent], v)
+	}
+
+	durations := make([][]float64, len(questions))
+	for _, rv := range respondents {
+		sort.SliceStable(rv, func(i, j int) bool { return rv[i].Timestamp < rv[j].Timestamp })
+
+		answered := map[int]bool{}
+		last := -1
+		for k, v := range rv {
+			i := index[v.Question]
+			if answered[v.Question] {
+				continue
+			}
+			answered[v.Question] = true
+			funnel.Questions[i].Answered++
+			if i > last {
+				last = i
+			}
+			if k > 0 {
+				durations[i] = append(durations[i], float64(v.Timestamp-rv[k-1].Timestamp))
+			}
+		}
+
+		completed := true
+		for _, q := range required {
+			if !answered[q] {
+				completed = false
+				break
+			}
+		}
+
+		funnel.Started++
+		if completed {
+			funnel.Completed++
+		} else {
+			funnel.Questions[last].DroppedOff++
+		}
+		for i := 0; i <= last; i++ {
+			funnel.Questions[i].Reached++
+		}
+	}
+
+	funnel.CompletionRate = percentage(funnel.Completed, funnel.Started)
+	for i := range funnel.Questions {
+		q := &funnel.Questions[i]
+		q.DropOffRate = percentage(q.DroppedOff, q.Reached)
+		if len(durations[i]) > 0 {
+			sort.Float64s(durations[i])
+			m := percentile(durations[i], 50)
+			q.MedianSeconds = &m
+		}
+	}
+	return funnel
+}
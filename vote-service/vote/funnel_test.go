@@ -0,0 +1,51 @@
+package vote
+
+import (
+	"testing"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFunnelVote creates a vote of a respondent on a question at a timestamp
+func newFunnelVote(respondent string, question int, timestamp int64) *Vote {
+	return &Vote{Respondent: respondent, Question: question, Timestamp: timestamp}
+}
+
+// TestComputeFunnel tests completions and drop-offs of respondents over the survey questions
+func TestComputeFunnel(t *testing.T) {
+	surv := &protos.SurveyResponse{Id: "survey-1", Questions: []*protos.QuestionResponse{
+		{Id: 1, Required: true},
+		{Id: 2},
+		{Id: 3, Required: true},
+	}}
+	votes := []*Vote{
+		// Completed, skipping the optional question
+		newFunnelVote("ann", 1, 100),
+		newFunnelVote("ann", 3, 130),
+		// Dropped off after the second question
+		newFunnelVote("bob", 1, 200),
+		newFunnelVote("bob", 2, 210),
+		// Completed every question, answering the first one twice
+		newFunnelVote("cid", 1, 300),
+		newFunnelVote("cid", 1, 305),
+		newFunnelVote("cid", 2, 320),
+		newFunnelVote("cid", 3, 350),
+		// Not attributed to a respondent, or on an unknown question
+		newFunnelVote("", 1, 400),
+		newFunnelVote("dan", 9, 500),
+	}
+
+	funnel := computeFunnel(surv, votes)
+	assert.Equal(t, 3, funnel.Started, "Expected respondents with known questions to start")
+	assert.Equal(t, 2, funnel.Completed, "Expected respondents answering the required questions to complete")
+	assert.InDelta(t, 200.0/3, funnel.CompletionRate, 0.0001, "Expected the completion rate")
+	assert.Equal(t, 1, funnel.Unattributed, "Expected votes without respondent to be left out")
+
+	m2, m3 := 12.5, 30.0
+	assert.Equal(t, []QuestionFunnel{
+		{Question: 1, Reached: 3, Answered: 3},
+		{Question: 2, Reached: 3, Answered: 2, DroppedOff: 1, DropOffRate: 100.0 / 3, MedianSeconds: &m2},
+		{Question: 3, Reached: 2, Answered: 2, MedianSeconds: &m3},
+	}, funnel.Questions, "Expected the funnel of each question")
+}
@@ -0,0 +1,76 @@
+package vote
+
+import "errors"
+
+var (
+	// ErrVoteRejected indicates that an anonymous vote failed the duplicate submission checks
+	ErrVoteRejected = errors.New("Vote rejected")
+
+	// ErrRateLimited indicates that too many anonymous votes were cast from the same client
+	ErrRateLimited = errors.New("Too many votes")
+)
+
+// RejectionReason defines why an anonymous vote was rejected
+type RejectionReason string
+
+// Available rejection reasons
+const (
+	RejectionMissingToken           RejectionReason = "missing_token"            // No respondent token was sent with the vote
+	RejectionInvalidToken           RejectionReason = "invalid_token"            // The respondent token is malformed, expired or wrongly signed
+	RejectionTokenSurveyMismatch    RejectionReason = "token_survey_mismatch"    // The respondent token was issued for another survey
+	RejectionReplay                 RejectionReason = "replay"                   // The respondent token was already used to answer the question
+	RejectionIPRateLimited          RejectionReason = "ip_rate_limited"          // Too many votes on the survey from the client IP
+	RejectionFingerprintRateLimited RejectionReason = "fingerprint_rate_limited" // Too many votes on the survey from the client fingerprint
+)
+
+// Rejection describes an anonymous vote rejected by a guard
+// This error unwraps to ErrRateLimited for rate limits and to ErrVoteRejected otherwise
+type Rejection struct {
+	Reason RejectionReason
+}
+
+// Error returns the message of the rejection
+func (r *Rejection) Error() string {
+	return r.Unwrap().Error() + ": " + string(r.Reason)
+}
+
+// Unwrap returns the error class of the rejection
+func (r *Rejection) Unwrap() error {
+	if r.Reason == RejectionIPRateLimited || r.Reason == RejectionFingerprintRateLimited {
+		return ErrRateLimited
+	}
+	return ErrVoteRejected
+}
+
+// Guard contains functions to protect anonymous surveys from ballot stuffing
+// This interface defines the checks anonymous votes must pass before they are stored
+type Guard interface {
+	// Check verifies an anonymous vote
+	// This method returns a *Rejection when the vote is a replay, lacks a valid respondent
+	// token or exceeds the rate limits of its client
+	Check(v *Vote) error
+
+	// Release releases the respondent token an anonymous vote passed the checks with
+	// This method lets the token answer the question again when the vote could not be stored
+	Release(v *Vote) error
+}
+
+// AuditRepository contains functions to record rejected votes
+// This interface defines the methods required for auditing ballot stuffing attempts
+type AuditRepository interface {
+	// Record stores the rejection of a vote
+	// This method saves the survey, question, reason and client of the rejected vote
+	Record(v *Vote, reason RejectionReason) error
+}
+
+// ReplayRepository contains functions to remember the respondent tokens used to answer questions
+// This interface lets every instance of the service reject replays, also across restarts
+type ReplayRepository interface {
+	// Use marks a key as used until it expires
+	// This method returns false when the key was already used
+	Use(key string, expiresAt int64) (bool, error)
+
+	// Release marks a key as not used
+	// This method has no effect on keys that are not used
+	Release(key string) error
+}
@@ -1,9 +1,11 @@
 package vote
 
 import (
-	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
@@ -17,6 +19,30 @@ var (
 
 	// ErrResultsNotFound indicates that results could not be found for a given survey
 	ErrResultsNotFound = errors.New("Results not found")
+
+	// ErrSurveyNotFound indicates that the survey a vote was cast on does not exist
+	ErrSurveyNotFound = errors.New("Survey not found")
+
+	// ErrSurveyUnavailable indicates that the survey service could not be reached
+	ErrSurveyUnavailable = errors.New("Survey service unavailable")
+
+	// ErrForbidden indicates that the user is not allowed to view the results of a survey
+	ErrForbidden = errors.New("Access to results forbidden")
+
+	// ErrAuthenticationRequired indicates that the survey does not accept anonymous votes
+	ErrAuthenticationRequired = errors.New("Survey requires an authenticated user")
+
+	// ErrAlreadyVoted indicates that the user already answered the survey question
+	ErrAlreadyVoted = errors.New("Question already answered")
+
+	// ErrInvitationRequired indicates that the survey only accepts votes with an invitation token
+	ErrInvitationRequired = errors.New("Survey requires an invitation")
+
+	// ErrInvalidInvitation indicates that the invitation token is unknown for the survey
+	ErrInvalidInvitation = errors.New("Invalid invitation")
+
+	// ErrInvitationUsed indicates that the question was already answered with the invitation token
+	ErrInvitationUsed = errors.New("Invitation already used for this question")
 )
 
 // ErrorResponse provides a structure for error responses
@@ -26,65 +52,264 @@ type ErrorResponse struct {
 
 // voteService implements the Service interface for managing votes
 type voteService struct {
-	writer    WriterRepository
-	results   ResultsRepository
-	validator *validator.Validate
-	surveys   protos.SurveyClient
+	writer        WriterRepository
+	results       ResultsRepository
+	validator     *validator.Validate
+	surveys       SurveyRepository
+	guard         Guard
+	audit         AuditRepository
+	invitations   InvitationRepository
+	files         FileRepository
+	details       *detailsCache
+	publicResults bool
 }
 
 // NewService creates a new vote service
 // This function initializes and returns a new voteService instance
-func NewService(w WriterRepository, r ResultsRepository, cli protos.SurveyClient) Service {
+// Anonymous votes are checked by the guard, and rejections recorded in the audit repository
+// Votes on invite-only surveys consume an invitation from the invitation repository
+// Votes on file questions reference a file found in the file repository
+// Results of every survey can be viewed by anyone when publicResults is set
+func NewService(w WriterRepository, r ResultsRepository, surveys SurveyRepository, g Guard, a AuditRepository, i InvitationRepository, f FileRepository, publicResults bool) Service {
 	return &voteService{
-		writer:    w,
-		results:   r,
-		validator: validator.New(),
-		surveys:   cli,
+		writer:        w,
+		results:       r,
+		validator:     validator.New(),
+		surveys:       surveys,
+		guard:         g,
+		audit:         a,
+		invitations:   i,
+		files:         f,
+		details:       newDetailsCache(),
+		publicResults: publicResults,
 	}
 }
 
 // Insert validates and inserts a new vote
 // This method fetches the survey to validate the vote, generates an ID, and stores the vote
-func (s *voteService) Insert(v *Vote) error {
+// The user ID of the vote is always taken from the authenticated user, never from the request
+func (s *voteService) Insert(user *User, v *Vote) error {
 	// Validate the vote structure
 	if err := s.validator.Struct(v); err != nil {
 		return fmt.Errorf("%w: %v", ErrInvalidRequest, err)
 	}
 
 	// Fetch and validate the survey and question ID
-	if err := s.validateSurveyAndQuestion(v); err != nil {
+	surv, err := s.validateSurveyAndQuestion(v)
+	if err != nil {
+		return err
+	}
+
+	// Check who is voting and whether they already answered
+	if err := s.validateRespondent(user, surv, v); err != nil {
+		return err
+	}
+
+	// Reference the uploaded file answering a file question
+	if err := s.attachFile(v); err != nil {
+		return err
+	}
+
+	// Reject replayed and rate limited anonymous votes
+	guarded, err := s.verifyAnonymous(v)
+	if err != nil {
 		return err
 	}
 
+	// Consume the invitation of invite-only surveys last, so rejected votes keep it valid
+	used, err := s.useInvitation(surv, v)
+	if err != nil {
+		return s.release(v, guarded, false, false, err)
+	}
+
+	// Claim the uploaded file for this vote, so no other vote can reference it
+	if err := s.claimFile(v); err != nil {
+		return s.release(v, guarded, used, false, err)
+	}
+
 	// Generate a unique ID and set the timestamp
+	// Answers saved in a session keep the time they were saved, so analytics see when they were given
 	v.ID = uuid.NewV4().String()
-	v.Timestamp = time.Now().UTC().Unix()
+	if v.Session == "" || v.Timestamp == 0 {
+		v.Timestamp = time.Now().UTC().Unix()
+	}
+	v.Respondent = respondentKey(user, v)
+
+	// Insert the vote into the repository, releasing the respondent token, the invitation and the file when the vote is lost
+	if err := s.writer.Insert(v); err != nil {
+		return s.release(v, guarded, used, v.File != nil, err)
+	}
+	return nil
+}
 
-	// Insert the vote into the repository
-	return s.writer.Insert(v)
+// release releases the respondent token, the invitation and the file consumed by a vote that could not be stored
+// This method returns the error of the vote, with the errors of the releases that failed
+func (s *voteService) release(v *Vote, token, invitation, file bool, err error) error {
+	var failures []string
+	if token {
+		if releaseErr := s.guard.Release(v); releaseErr != nil {
+			failures = append(failures, fmt.Sprintf("respondent token release failed: %v", releaseErr))
+		}
+	}
+	if invitation {
+		if releaseErr := s.invitations.Release(v.Survey, v.InvitationToken, v.Question); releaseErr != nil {
+			failures = append(failures, fmt.Sprintf("invitation release failed: %v", releaseErr))
+		}
+	}
+	if file {
+		if detachErr := s.files.Detach(v.File.ID); detachErr != nil {
+			failures = append(failures, fmt.Sprintf("file release failed: %v", detachErr))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%w (%s)", err, strings.Join(failures, ", "))
+	}
+	return err
 }
 
-// validateSurveyAndQuestion validates the survey and question ID
-// This method fetches the survey and checks if the question ID is valid
-func (s *voteService) validateSurveyAndQuestion(v *Vote) error {
-	// Fetch the survey
-	req := &protos.SurveyRequest{Id: v.Survey}
-	surv, err := s.surveys.GetSurvey(context.Background(), req)
+// validateSurveyAndQuestion validates the survey, question ID and answer
+// This method fetches the survey and checks the answer against the question
+func (s *voteService) validateSurveyAndQuestion(v *Vote) (*protos.SurveyResponse, error) {
+	// Fetch the survey, only an unknown survey makes the vote itself invalid
+	surv, err := s.surveys.GetSurvey(v.Survey)
 	if err != nil {
-		return ErrInvalidRequest
+		if errors.Is(err, ErrSurveyNotFound) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+		}
+		return nil, err
 	}
 
-	// Validate the question ID
-	if !s.isValidQuestionID(v.Question, surv.GetQuestions()) {
-		return ErrInvalidRequest
+	// Validate the question ID and the answer
+	if err := validateAnswer(surv.GetQuestions(), v); err != nil {
+		return nil, err
 	}
 
+	return surv, nil
+}
+
+// validateRespondent sets the user of a vote and prevents duplicate answers
+// Surveys that do not allow anonymous votes require a user, and each user answers a question once
+// unless the survey allows edits, in which case the vote replaces the previous answer
+func (s *voteService) validateRespondent(user *User, surv *protos.SurveyResponse, v *Vote) error {
+	v.UserID = ""
+	v.Replace = false
+
+	if user == nil {
+		// Invitations identify the respondents of invite-only surveys
+		if !surv.GetAllowAnonymous() && !surv.GetInviteOnly() {
+			return ErrAuthenticationRequired
+		}
+		return nil
+	}
+	v.UserID = user.ID
+
+	voted, err := s.results.HasVoted(v.Survey, v.Question, user.ID)
+	if err != nil {
+		return err
+	}
+	if voted {
+		if !surv.GetAllowEdits() {
+			return ErrAlreadyVoted
+		}
+		v.Replace = true
+	}
+
+	return nil
+}
+
+// attachFile sets the details of the uploaded file answering a vote
+// The file must have been uploaded for the same question, by the same user when one uploaded it, and answer no other vote
+func (s *voteService) attachFile(v *Vote) error {
+	if v.File == nil {
+		return nil
+	}
+
+	f, err := s.files.Load(v.File.ID)
+	if errors.Is(err, ErrFileNotFound) {
+		return fmt.Errorf("%w: unknown file %s", ErrInvalidRequest, v.File.ID)
+	}
+	if err != nil {
+		return err
+	}
+	if f.Survey != v.Survey || f.Question != v.Question || (f.UserID != "" && f.UserID != v.UserID) {
+		return fmt.Errorf("%w: file %s was not uploaded for this answer", ErrInvalidRequest, f.ID)
+	}
+
+	if f.AttachedAt != 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, ErrFileAttached)
+	}
+
+	v.File = &FileAnswer{ID: f.ID, Name: f.Name, ContentType: f.ContentType, Size: f.Size}
 	return nil
 }
 
+// claimFile marks the uploaded file answering a vote as attached
+// Files are claimed atomically, so concurrent votes cannot both reference the same file
+func (s *voteService) claimFile(v *Vote) error {
+	if v.File == nil {
+		return nil
+	}
+
+	err := s.files.Attach(v.File.ID, time.Now().UTC().Unix())
+	if errors.Is(err, ErrFileAttached) || errors.Is(err, ErrFileNotFound) {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	return err
+}
+
+// verifyAnonymous checks an anonymous vote with the guard and reports whether it did
+// This method records the reason of every rejected vote in the audit repository
+func (s *voteService) verifyAnonymous(v *Vote) (bool, error) {
+	if v.UserID != "" {
+		return false, nil
+	}
+
+	err := s.guard.Check(v)
+	var rejection *Rejection
+	if errors.As(err, &rejection) {
+		if auditErr := s.audit.Record(v, rejection.Reason); auditErr != nil {
+			return false, fmt.Errorf("%w (audit failed: %v)", err, auditErr)
+		}
+	}
+	return err == nil, err
+}
+
+// useInvitation consumes the invitation token of a vote on an invite-only survey and reports whether it did
+// The token is not stored with the vote, so answers cannot be traced back to the recipient
+// Users replacing their own answer send a token already used for the question, which lets the vote through without consuming it
+func (s *voteService) useInvitation(surv *protos.SurveyResponse, v *Vote) (bool, error) {
+	if !surv.GetInviteOnly() {
+		return false, nil
+	}
+	if v.InvitationToken == "" {
+		return false, ErrInvitationRequired
+	}
+
+	err := s.invitations.Use(v.Survey, v.InvitationToken, v.Question)
+	if errors.Is(err, ErrInvitationUsed) && v.Replace {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// respondentKey identifies the respondent of a vote to group their answers in analytics
+// Respondent tokens are hashed, so the stored key cannot be used to cast votes
+func respondentKey(user *User, v *Vote) string {
+	switch {
+	case user != nil:
+		return "user:" + user.ID
+	case v.Session != "":
+		return "session:" + v.Session
+	case v.RespondentToken != "":
+		sum := sha256.Sum256([]byte(v.RespondentToken))
+		return "token:" + hex.EncodeToString(sum[:16])
+	}
+	return ""
+}
+
 // isValidQuestionID checks if the question ID is valid within the survey questions
 // This method returns true if the question ID is found in the survey questions
-func (s *voteService) isValidQuestionID(questionID int, questions []*protos.QuestionResponse) bool {
+func isValidQuestionID(questionID int, questions []*protos.QuestionResponse) bool {
 	for _, q := range questions {
 		if q.GetId() == int32(questionID) {
 			return true
@@ -94,7 +319,165 @@ func (s *voteService) isValidQuestionID(questionID int, questions []*protos.Ques
 }
 
 // GetResults retrieves the results for a given survey ID
-// This method fetches the results from the results repository
-func (s *voteService) GetResults(surveyID string) (Results, error) {
-	return s.results.GetResults(surveyID)
+// This method checks the user can view the results and fetches them from the results repository,
+// adding the answer breakdowns and statistics computed from the stored votes until the counts change
+func (s *voteService) GetResults(user *User, surveyID string) (Results, error) {
+	files, err := s.resultsAccess(user, surveyID)
+	if err != nil {
+		return Results{}, err
+	}
+
+	results, err := s.results.GetResults(surveyID)
+	if err != nil {
+		return results, err
+	}
+
+	// Results of deleted surveys only have the stored counts
+	surv, err := s.surveys.GetSurvey(surveyID)
+	if errors.Is(err, ErrSurveyNotFound) {
+		return results, nil
+	}
+	if err != nil {
+		return Results{}, err
+	}
+	detailed, ok := s.details.get(results, surv)
+	if !ok {
+		key := detailsKey(results)
+		votes, err := s.results.LoadVotes(surveyID)
+		if err != nil {
+			return Results{}, err
+		}
+		detailed = addDetails(results, surv, votes)
+		s.details.put(key, surv, detailed)
+	}
+	if !files {
+		return withoutFiles(detailed), nil
+	}
+	return detailed, nil
+}
+
+// GetFilteredResults retrieves the results of a given survey ID over a segment of its respondents
+// This method checks the user can view the results and recomputes them from the votes of the
+// respondents matching every filter
+func (s *voteService) GetFilteredResults(user *User, surveyID string, filters []Filter) (Results, error) {
+	files, err := s.resultsAccess(user, surveyID)
+	if err != nil {
+		return Results{}, err
+	}
+	surv, votes, err := s.loadVotes(surveyID)
+	if err != nil {
+		return Results{}, err
+	}
+
+	for _, f := range filters {
+		if !isValidQuestionID(f.Question, surv.GetQuestions()) {
+			return Results{}, fmt.Errorf("%w: unknown filter question %d", ErrInvalidRequest, f.Question)
+		}
+	}
+	results := computeResults(surv, filterVotes(votes, filters))
+	if !files {
+		return withoutFiles(results), nil
+	}
+	return results, nil
+}
+
+// GetFunnel retrieves the completion funnel of a given survey ID
+// This method checks the user can view the results and computes the funnel from the stored votes
+func (s *voteService) GetFunnel(user *User, surveyID string) (Funnel, error) {
+	if _, err := s.resultsAccess(user, surveyID); err != nil {
+		return Funnel{}, err
+	}
+	surv, votes, err := s.loadVotes(surveyID)
+	if err != nil {
+		return Funnel{}, err
+	}
+	return computeFunnel(surv, votes), nil
+}
+
+// GetTimeSeries retrieves the votes of a given survey ID cast from a start until an end bucketed over time
+// This method checks the user can view the results and computes the time series from the stored votes
+func (s *voteService) GetTimeSeries(user *User, surveyID string, interval Interval, from, to int64) (TimeSeries, error) {
+	if !interval.IsValid() {
+		return TimeSeries{}, fmt.Errorf("%w: unknown interval %q", ErrInvalidRequest, interval)
+	}
+	if to != 0 && from >= to {
+		return TimeSeries{}, fmt.Errorf("%w: from must be before to", ErrInvalidRequest)
+	}
+
+	if _, err := s.resultsAccess(user, surveyID); err != nil {
+		return TimeSeries{}, err
+	}
+	surv, votes, err := s.loadVotes(surveyID)
+	if err != nil {
+		return TimeSeries{}, err
+	}
+	return computeTimeSeries(surv, votes, interval, from, to)
+}
+
+// GetCrossTab retrieves the contingency table of two questions of a given survey ID
+// This method checks the user can view the results and builds the table from the answers of each respondent
+func (s *voteService) GetCrossTab(user *User, surveyID string, row, column int) (CrossTab, error) {
+	if _, err := s.resultsAccess(user, surveyID); err != nil {
+		return CrossTab{}, err
+	}
+	surv, votes, err := s.loadVotes(surveyID)
+	if err != nil {
+		return CrossTab{}, err
+	}
+	return computeCrossTab(surv, votes, row, column)
+}
+
+// resultsAccess checks that the user can view the results of a survey
+// This method reports whether the files uploaded to the survey can be listed, which are never public,
+// so users only viewing public results get the results without the files
+func (s *voteService) resultsAccess(user *User, surveyID string) (bool, error) {
+	err := authorizeResults(s.surveys, user, surveyID)
+	if err == nil {
+		return true, nil
+	}
+	if s.publicResults && (errors.Is(err, ErrForbidden) || errors.Is(err, ErrResultsNotFound)) {
+		return false, nil
+	}
+	return false, err
+}
+
+// loadVotes loads a survey and its votes for analytics
+func (s *voteService) loadVotes(surveyID string) (*protos.SurveyResponse, []*Vote, error) {
+	surv, err := s.surveys.GetSurvey(surveyID)
+	if errors.Is(err, ErrSurveyNotFound) {
+		return nil, nil, ErrResultsNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	votes, err := s.results.LoadVotes(surveyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return surv, votes, nil
+}
+
+// authorizeResults checks that the user owns the survey or has it shared
+// This function is shared by the results and the session reports of a survey
+func authorizeResults(surveys SurveyRepository, user *User, surveyID string) error {
+	if user == nil {
+		return ErrForbidden
+	}
+	if user.HasRole(RoleAdmin) {
+		return nil
+	}
+
+	surv, err := surveys.GetSurvey(surveyID)
+	if errors.Is(err, ErrSurveyNotFound) {
+		return ErrResultsNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if !user.CanViewResults(surv) {
+		return ErrForbidden
+	}
+	return nil
 }
@@ -0,0 +1,298 @@
+package vote
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// testWriter is a writer repository storing votes in memory, or failing with an error
+type testWriter struct {
+	votes []*Vote
+	err   error
+}
+
+// Insert stores the vote unless the writer fails
+func (w *testWriter) Insert(v *Vote) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.votes = append(w.votes, v)
+	return nil
+}
+
+// testResults is a results repository answering from the votes of a test writer
+type testResults struct {
+	writer *testWriter
+	loads  int // Number of times the votes were loaded
+}
+
+// GetResults counts the votes of the writer by question, updated at the time of the last vote
+func (r *testResults) GetResults(surveyID string) (Results, error) {
+	results := Results{Survey: surveyID, Results: []QuestionResults{}}
+	for _, v := range r.writer.votes {
+		if v.Timestamp > results.UpdatedAt {
+			results.UpdatedAt = v.Timestamp
+		}
+		i := 0
+		for i < len(results.Results) && results.Results[i].Question != v.Question {
+			i++
+		}
+		if i == len(results.Results) {
+			results.Results = append(results.Results, QuestionResults{Question: v.Question})
+		}
+		results.Results[i].TotalVotes++
+	}
+	return results, nil
+}
+
+// HasVoted checks the votes of the writer
+func (r *testResults) HasVoted(surveyID string, question int, userID string) (bool, error) {
+	for _, v := range r.writer.votes {
+		if v.Survey == surveyID && v.Question == question && v.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// LoadVotes returns the votes of the writer
+func (r *testResults) LoadVotes(surveyID string) ([]*Vote, error) {
+	r.loads++
+	return r.writer.votes, nil
+}
+
+// testSurveys is a survey repository serving a single survey
+type testSurveys struct {
+	survey *protos.SurveyResponse
+}
+
+// GetSurvey returns the survey
+func (r *testSurveys) GetSurvey(id string) (*protos.SurveyResponse, error) {
+	if id != r.survey.GetId() {
+		return nil, ErrSurveyNotFound
+	}
+	return r.survey, nil
+}
+
+// testInvitations is an invitation repository tracking the questions answered with each token
+type testInvitations struct {
+	used map[string]bool
+}
+
+// Use marks the question as answered with the token
+func (r *testInvitations) Use(surveyID, token string, question int) error {
+	if token != "inv_ann" {
+		return ErrInvalidInvitation
+	}
+	if r.used[surveyID+token] {
+		return ErrInvitationUsed
+	}
+	r.used[surveyID+token] = true
+	return nil
+}
+
+// Release marks the question as not answered with the token
+func (r *testInvitations) Release(surveyID, token string, question int) error {
+	delete(r.used, surveyID+token)
+	return nil
+}
+
+// testGuard accepts every anonymous vote
+type testGuard struct{}
+
+// Check accepts the vote
+func (testGuard) Check(v *Vote) error { return nil }
+
+// Release has nothing to release
+func (testGuard) Release(v *Vote) error { return nil }
+
+// testTokenGuard lets each respondent token answer a question once
+type testTokenGuard struct {
+	used map[string]bool
+}
+
+// Check marks the question as answered with the token, rejecting replays
+func (g *testTokenGuard) Check(v *Vote) error {
+	key := v.RespondentToken + ":" + strconv.Itoa(v.Question)
+	if g.used[key] {
+		return &Rejection{Reason: RejectionReplay}
+	}
+	g.used[key] = true
+	return nil
+}
+
+// Release marks the question as not answered with the token
+func (g *testTokenGuard) Release(v *Vote) error {
+	delete(g.used, v.RespondentToken+":"+strconv.Itoa(v.Question))
+	return nil
+}
+
+// testAudit records the reasons of rejected votes
+type testAudit struct {
+	reasons []RejectionReason
+}
+
+// Record records the reason
+func (a *testAudit) Record(v *Vote, reason RejectionReason) error {
+	a.reasons = append(a.reasons, reason)
+	return nil
+}
+
+// inviteOnlySurvey is an invite-only survey with one text question
+var inviteOnlySurvey = &protos.SurveyResponse{
+	Id:         "survey-1",
+	InviteOnly: true,
+	Questions:  []*protos.QuestionResponse{{Id: 1, Type: QuestionTypeText}},
+}
+
+// newTextVote creates a vote answering the text question of the test survey with an invitation token
+func newTextVote(token string) *Vote {
+	text := "Fine"
+	return &Vote{Survey: "survey-1", Question: 1, AnswerType: AnswerTypeText, TextAnswer: &text, InvitationToken: token}
+}
+
+// TestInsertReleasesInvitation tests that invitations are released when the vote cannot be stored
+func TestInsertReleasesInvitation(t *testing.T) {
+	writer := &testWriter{}
+	invitations := &testInvitations{used: make(map[string]bool)}
+	s := NewService(writer, &testResults{writer: writer}, &testSurveys{inviteOnlySurvey}, testGuard{}, nil, invitations, nil, true)
+
+	writer.err = errors.New("queue unavailable")
+	assert.Error(t, s.Insert(nil, newTextVote("inv_ann")), "Expected the write error to be returned")
+	assert.Empty(t, invitations.used, "Expected the invitation to be released")
+
+	writer.err = nil
+	assert.NoError(t, s.Insert(nil, newTextVote("inv_ann")), "Expected the released invitation to be used again")
+	assert.ErrorIs(t, s.Insert(nil, newTextVote("inv_ann")), ErrInvitationUsed, "Expected the invitation to answer the question once")
+	assert.ErrorIs(t, s.Insert(nil, newTextVote("inv_bob")), ErrInvalidInvitation, "Expected unknown invitations to be rejected")
+}
+
+// TestInsertReleasesRespondentToken tests that respondent tokens can answer again when the vote cannot be stored
+func TestInsertReleasesRespondentToken(t *testing.T) {
+	writer := &testWriter{err: errors.New("queue unavailable")}
+	guard := &testTokenGuard{used: make(map[string]bool)}
+	audit := &testAudit{}
+	invitations := &testInvitations{used: make(map[string]bool)}
+	s := NewService(writer, &testResults{writer: writer}, &testSurveys{inviteOnlySurvey}, guard, audit, invitations, nil, true)
+
+	vote := func(invitation string) *Vote {
+		v := newTextVote(invitation)
+		v.RespondentToken = "token-1"
+		return v
+	}
+
+	assert.Error(t, s.Insert(nil, vote("inv_ann")), "Expected the write error to be returned")
+	assert.Empty(t, guard.used, "Expected the token to be released when the vote is lost")
+	writer.err = nil
+	assert.ErrorIs(t, s.Insert(nil, vote("inv_bob")), ErrInvalidInvitation, "Expected unknown invitations to be rejected")
+	assert.Empty(t, guard.used, "Expected the token to be released when the invitation is rejected")
+
+	assert.NoError(t, s.Insert(nil, vote("inv_ann")), "Expected the released token to answer the question")
+	var rejection *Rejection
+	assert.ErrorAs(t, s.Insert(nil, vote("inv_ann")), &rejection, "Expected the token to answer the question once")
+	assert.Equal(t, []RejectionReason{RejectionReplay}, audit.reasons, "Expected the replay to be audited")
+}
+
+// TestInsertReplacesInvitedAnswer tests that invited users can replace their answer when edits are allowed
+func TestInsertReplacesInvitedAnswer(t *testing.T) {
+	writer := &testWriter{}
+	invitations := &testInvitations{used: make(map[string]bool)}
+	surv := &protos.SurveyResponse{Id: "survey-1", InviteOnly: true, AllowEdits: true, Questions: inviteOnlySurvey.Questions}
+	s := NewService(writer, &testResults{writer: writer}, &testSurveys{surv}, testGuard{}, nil, invitations, nil, true)
+	user := &User{ID: "user-1"}
+
+	assert.NoError(t, s.Insert(user, newTextVote("inv_ann")), "Expected the first answer to be stored")
+	assert.NoError(t, s.Insert(user, newTextVote("inv_ann")), "Expected the answer to be replaced")
+	assert.True(t, writer.votes[1].Replace, "Expected the second vote to replace the first")
+
+	assert.ErrorIs(t, s.Insert(&User{ID: "user-2"}, newTextVote("inv_ann")), ErrInvitationUsed, "Expected other users not to reuse the invitation")
+
+	writer.err = errors.New("queue unavailable")
+	assert.Error(t, s.Insert(user, newTextVote("inv_ann")), "Expected the write error to be returned")
+	writer.err = nil
+	assert.ErrorIs(t, s.Insert(&User{ID: "user-2"}, newTextVote("inv_ann")), ErrInvitationUsed, "Expected failed replacements not to release the invitation")
+}
+
+// TestGetResultsCachesDetails tests that the details of results are only recomputed after new votes
+func TestGetResultsCachesDetails(t *testing.T) {
+	writer := &testWriter{}
+	results := &testResults{writer: writer}
+	surv := &protos.SurveyResponse{Id: "survey-1", AllowAnonymous: true, Questions: []*protos.QuestionResponse{{Id: 1, Type: QuestionTypeText}}}
+	s := NewService(writer, results, &testSurveys{surv}, testGuard{}, nil, nil, nil, true)
+
+	assert.NoError(t, s.Insert(nil, newTextVote("")), "Expected the vote to be stored")
+	first, err := s.GetResults(nil, "survey-1")
+	assert.NoError(t, err, "Expected no error on results")
+	assert.Equal(t, []TextAnswerResult{{Answer: "Fine", Count: 1}}, first.Results[0].TextAnswers, "Expected the text answers")
+
+	// Callers changing the results do not change the cache
+	first.Results[0] = QuestionResults{}
+	second, err := s.GetResults(nil, "survey-1")
+	assert.NoError(t, err, "Expected no error on cached results")
+	assert.Equal(t, 1, results.loads, "Expected unchanged results not to load the votes again")
+	assert.Equal(t, 1, second.Results[0].TotalVotes, "Expected the cached details")
+
+	assert.NoError(t, s.Insert(nil, newTextVote("")), "Expected the vote to be stored")
+	third, err := s.GetResults(nil, "survey-1")
+	assert.NoError(t, err, "Expected no error on results")
+	assert.Equal(t, 2, results.loads, "Expected new votes to load the votes again")
+	assert.Equal(t, []TextAnswerResult{{Answer: "Fine", Count: 2}}, third.Results[0].TextAnswers, "Expected the new vote in the details")
+}
+
+// TestInsertClaimsFile tests that an uploaded file answers a single vote, and is released when the vote is lost
+func TestInsertClaimsFile(t *testing.T) {
+	writer := &testWriter{}
+	files := &testFiles{files: map[string]File{"file-1": {ID: "file-1", Survey: "survey-1", Question: 1}}}
+	surv := &protos.SurveyResponse{Id: "survey-1", AllowAnonymous: true, Questions: []*protos.QuestionResponse{{Id: 1, Type: QuestionTypeFile}}}
+	s := NewService(writer, &testResults{writer: writer}, &testSurveys{surv}, testGuard{}, nil, nil, files, true)
+	newFileVote := func() *Vote {
+		return &Vote{Survey: "survey-1", Question: 1, AnswerType: AnswerTypeFile, File: &FileAnswer{ID: "file-1"}}
+	}
+
+	writer.err = errors.New("queue unavailable")
+	assert.Error(t, s.Insert(nil, newFileVote()), "Expected the write error to be returned")
+	assert.Zero(t, files.files["file-1"].AttachedAt, "Expected the file to be released")
+
+	writer.err = nil
+	assert.NoError(t, s.Insert(nil, newFileVote()), "Expected the released file to be attached")
+	assert.NotZero(t, files.files["file-1"].AttachedAt, "Expected the file to be attached")
+	assert.Equal(t, "file-1", writer.votes[0].File.ID, "Expected the vote to reference the file")
+
+	err := s.Insert(nil, newFileVote())
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected the attached file to be rejected")
+	assert.ErrorIs(t, err, ErrFileAttached, "Expected the file to be reported as attached")
+	assert.Len(t, writer.votes, 1, "Expected a single vote to reference the file")
+}
+
+// TestGetResultsListsFilesToViewers tests that public results only list uploaded files to the users who can view the results
+func TestGetResultsListsFilesToViewers(t *testing.T) {
+	writer := &testWriter{}
+	files := &testFiles{files: map[string]File{"file-1": {ID: "file-1", Survey: "survey-1", Question: 1}}}
+	surv := &protos.SurveyResponse{Id: "survey-1", OwnerId: "owner-1", AllowAnonymous: true, Questions: []*protos.QuestionResponse{{Id: 1, Type: QuestionTypeFile}}}
+	s := NewService(writer, &testResults{writer: writer}, &testSurveys{surv}, testGuard{}, nil, nil, files, true)
+	assert.NoError(t, s.Insert(&User{ID: "user-3"}, &Vote{Survey: "survey-1", Question: 1, AnswerType: AnswerTypeFile, File: &FileAnswer{ID: "file-1"}}), "Expected the vote to be stored")
+
+	owner := &User{ID: "owner-1", Roles: []string{RoleEditor}}
+	results, err := s.GetResults(owner, "survey-1")
+	assert.NoError(t, err, "Expected no error on results")
+	assert.Len(t, results.Results[0].Files, 1, "Expected the owner to get the uploaded files")
+
+	for _, user := range []*User{nil, {ID: "user-2", Roles: []string{RoleViewer}}} {
+		results, err = s.GetResults(user, "survey-1")
+		assert.NoError(t, err, "Expected public results to be viewed")
+		assert.Equal(t, 1, results.Results[0].TotalVotes, "Expected the counts of public results")
+		assert.Nil(t, results.Results[0].Files, "Expected uploaded files not to be listed")
+
+		results, err = s.GetFilteredResults(user, "survey-1", nil)
+		assert.NoError(t, err, "Expected public results to be filtered")
+		assert.Nil(t, results.Results[0].Files, "Expected uploaded files not to be listed in filtered results")
+	}
+
+	results, err = s.GetResults(owner, "survey-1")
+	assert.NoError(t, err, "Expected no error on cached results")
+	assert.Len(t, results.Results[0].Files, 1, "Expected the cached files to be kept for the owner")
+}
@@ -5,28 +5,135 @@ type AnswerType string
 
 // Available answer types
 const (
-	AnswerTypeOption AnswerType = "option" // Option selection
-	AnswerTypeText   AnswerType = "text"   // Text answer
-	AnswerTypeRating AnswerType = "rating" // Rating value
-	AnswerTypeScale  AnswerType = "scale"  // Scale value
-	AnswerTypeDate   AnswerType = "date"   // Date answer
+	AnswerTypeOption  AnswerType = "option"  // Option selection
+	AnswerTypeText    AnswerType = "text"    // Text answer
+	AnswerTypeRating  AnswerType = "rating"  // Rating value
+	AnswerTypeScale   AnswerType = "scale"   // Scale value
+	AnswerTypeDate    AnswerType = "date"    // Date answer
+	AnswerTypeNPS     AnswerType = "nps"     // Net Promoter Score from 0 to 10
+	AnswerTypeMatrix  AnswerType = "matrix"  // Column selected for each matrix row
+	AnswerTypeRanking AnswerType = "ranking" // Options ordered by preference
+	AnswerTypeFile    AnswerType = "file"    // Uploaded file
+)
+
+// Text formats defined by the survey service, checked on the answers to text questions
+const (
+	TextFormatEmail  = "email"  // Email address
+	TextFormatNumber = "number" // Decimal number
+	TextFormatURL    = "url"    // Absolute HTTP or HTTPS URL
+)
+
+// Question types defined by the survey service, used to interpret the answers of a question
+const (
+	QuestionTypeSingleChoice   = "single_choice"   // Single choice from multiple options
+	QuestionTypeMultipleChoice = "multiple_choice" // Multiple choices from options
+	QuestionTypeText           = "text"            // Open text answer
+	QuestionTypeRating         = "rating"          // Rating on a scale
+	QuestionTypeScale          = "scale"           // Likert scale or other numeric scale
+	QuestionTypeDate           = "date"            // Date selection
+	QuestionTypeNPS            = "nps"             // Net Promoter Score from 0 to 10
+	QuestionTypeMatrix         = "matrix"          // Row statements rated on a shared column scale
+	QuestionTypeRanking        = "ranking"         // Options ordered by preference
+	QuestionTypeFile           = "file"            // Uploaded file such as a screenshot or document
 )
 
 // Vote describes a vote
 // This struct represents a vote with an ID, survey ID, question ID, and timestamp
 type Vote struct {
-	ID          string     `json:"id"`                                 // Unique identifier for the vote
-	Survey      string     `json:"survey" validate:"required"`         // Survey ID associated with the vote, required field
-	Question    int        `json:"question" validate:"required,min=1"` // Question ID within the survey, required field with minimum value of 1
-	Timestamp   int64      `json:"timestamp"`                          // Timestamp when the vote was created
-	AnswerType  AnswerType `json:"answerType" validate:"required"`     // Type of the answer
-	OptionID    *int       `json:"optionId,omitempty"`                 // Option ID for single/multiple choice questions
-	OptionIDs   []int      `json:"optionIds,omitempty"`                // Option IDs for multiple choice questions
-	TextAnswer  *string    `json:"textAnswer,omitempty"`               // Text answer for text questions
-	RatingValue *int       `json:"ratingValue,omitempty"`              // Rating value for rating questions
-	ScaleValue  *int       `json:"scaleValue,omitempty"`               // Scale value for scale questions
-	DateAnswer  *int64     `json:"dateAnswer,omitempty"`               // Date answer as Unix timestamp
-	UserID      string     `json:"userId,omitempty"`                   // Optional user ID for non-anonymous votes
+	ID            string         `json:"id"`                                 // Unique identifier for the vote
+	Survey        string         `json:"survey" validate:"required"`         // Survey ID associated with the vote, required field
+	Question      int            `json:"question" validate:"required,min=1"` // Question ID within the survey, required field with minimum value of 1
+	Timestamp     int64          `json:"timestamp"`                          // Timestamp when the vote was created
+	AnswerType    AnswerType     `json:"answerType" validate:"required"`     // Type of the answer
+	OptionID      *int           `json:"optionId,omitempty"`                 // Option ID for single/multiple choice questions
+	OptionIDs     []int          `json:"optionIds,omitempty"`                // Option IDs for multiple choice questions
+	TextAnswer    *string        `json:"textAnswer,omitempty"`               // Text answer for text questions
+	OtherText     *string        `json:"otherText,omitempty"`                // Text specified with the other option of choice questions
+	RatingValue   *int           `json:"ratingValue,omitempty"`              // Rating value for rating questions
+	ScaleValue    *int           `json:"scaleValue,omitempty"`               // Scale value for scale questions
+	DateAnswer    *int64         `json:"dateAnswer,omitempty"`               // Date answer as Unix timestamp
+	NPSValue      *int           `json:"npsValue,omitempty"`                 // Score from 0 to 10 for NPS questions
+	MatrixAnswers []MatrixAnswer `json:"matrixAnswers,omitempty"`            // Column selected for each row of matrix questions
+	Ranking       []int          `json:"ranking,omitempty"`                  // Option IDs of ranking questions, from most to least preferred
+	File          *FileAnswer    `json:"file,omitempty"`                     // Uploaded file answering file questions
+	UserID        string         `json:"userId,omitempty"`                   // ID of the authenticated user who cast the vote, empty for anonymous votes
+	Replace       bool           `json:"replace,omitempty"`                  // Whether the vote replaces the previous answer of the same user
+	Respondent    string         `json:"respondent,omitempty"`               // Key grouping the answers of one respondent for analytics, empty when unknown
+
+	// Client details checked by the guard of anonymous votes, never serialized
+	ClientIP        string `json:"-"` // IP address of the client that cast the vote
+	Fingerprint     string `json:"-"` // Fingerprint of the client that cast the vote
+	RespondentToken string `json:"-"` // Respondent token issued when the client fetched the survey
+	InvitationToken string `json:"-"` // Invitation token of the recipient answering an invite-only survey
+	Session         string `json:"-"` // ID of the response session the vote was saved in, empty for direct votes
+}
+
+// Answer holds the answer values of a vote
+// This struct is stored with each vote so results can be recomputed from individual answers
+type Answer struct {
+	AnswerType    AnswerType     `json:"answerType"`              // Type of the answer
+	OptionID      *int           `json:"optionId,omitempty"`      // Option ID for single/multiple choice questions
+	OptionIDs     []int          `json:"optionIds,omitempty"`     // Option IDs for multiple choice questions
+	TextAnswer    *string        `json:"textAnswer,omitempty"`    // Text answer for text questions
+	OtherText     *string        `json:"otherText,omitempty"`     // Text specified with the other option of choice questions
+	RatingValue   *int           `json:"ratingValue,omitempty"`   // Rating value for rating questions
+	ScaleValue    *int           `json:"scaleValue,omitempty"`    // Scale value for scale questions
+	DateAnswer    *int64         `json:"dateAnswer,omitempty"`    // Date answer as Unix timestamp
+	NPSValue      *int           `json:"npsValue,omitempty"`      // Score from 0 to 10 for NPS questions
+	MatrixAnswers []MatrixAnswer `json:"matrixAnswers,omitempty"` // Column selected for each row of matrix questions
+	Ranking       []int          `json:"ranking,omitempty"`       // Option IDs of ranking questions, from most to least preferred
+	File          *FileAnswer    `json:"file,omitempty"`          // Uploaded file answering file questions
+}
+
+// MatrixAnswer holds the column selected for a row of a matrix question
+type MatrixAnswer struct {
+	Row    int `json:"row"`    // Row ID within the question
+	Column int `json:"column"` // Column ID within the question
+}
+
+// GetAnswer returns the answer values of the vote
+func (v *Vote) GetAnswer() Answer {
+	return Answer{
+		AnswerType:    v.AnswerType,
+		OptionID:      v.OptionID,
+		OptionIDs:     v.OptionIDs,
+		TextAnswer:    v.TextAnswer,
+		OtherText:     v.OtherText,
+		RatingValue:   v.RatingValue,
+		ScaleValue:    v.ScaleValue,
+		DateAnswer:    v.DateAnswer,
+		NPSValue:      v.NPSValue,
+		MatrixAnswers: v.MatrixAnswers,
+		Ranking:       v.Ranking,
+		File:          v.File,
+	}
+}
+
+// SetAnswer sets the answer values of the vote
+func (v *Vote) SetAnswer(a Answer) {
+	v.AnswerType = a.AnswerType
+	v.OptionID = a.OptionID
+	v.OptionIDs = a.OptionIDs
+	v.TextAnswer = a.TextAnswer
+	v.OtherText = a.OtherText
+	v.RatingValue = a.RatingValue
+	v.ScaleValue = a.ScaleValue
+	v.DateAnswer = a.DateAnswer
+	v.NPSValue = a.NPSValue
+	v.MatrixAnswers = a.MatrixAnswers
+	v.Ranking = a.Ranking
+	v.File = a.File
+}
+
+// SelectedOptions returns the IDs of the options selected by the vote
+func (v *Vote) SelectedOptions() []int {
+	if len(v.OptionIDs) > 0 {
+		return v.OptionIDs
+	}
+	if v.OptionID != nil {
+		return []int{*v.OptionID}
+	}
+	return nil
 }
 
 // Results describes the results of a survey
@@ -44,11 +151,42 @@ type QuestionResults struct {
 	TotalVotes       int                `json:"totalVotes"`                 // Total number of votes for the question
 	OptionResults    []OptionResult     `json:"optionResults,omitempty"`    // Results for each option for choice questions
 	TextAnswers      []TextAnswerResult `json:"textAnswers,omitempty"`      // Text answer results for text questions
+	OtherAnswers     []TextAnswerResult `json:"otherAnswers,omitempty"`     // Text values specified with the other option of choice questions
 	AverageRating    *float64           `json:"averageRating,omitempty"`    // Average rating for rating questions
 	RatingCounts     map[int]int        `json:"ratingCounts,omitempty"`     // Count of each rating value
 	AverageScale     *float64           `json:"averageScale,omitempty"`     // Average scale for scale questions
 	ScaleCounts      map[int]int        `json:"scaleCounts,omitempty"`      // Count of each scale value
-	DateDistribution map[string]int     `json:"dateDistribution,omitempty"` // Distribution of date answers, grouped by day
+	DateDistribution map[string]int     `json:"dateDistribution,omitempty"` // Distribution of date answers, grouped by day unless regrouped
+	DateGrouping     DateGrouping       `json:"dateGrouping,omitempty"`     // Width of the buckets of the date distribution
+	RatingStats      *NumericStats      `json:"ratingStats,omitempty"`      // Distribution statistics of rating answers
+	ScaleStats       *NumericStats      `json:"scaleStats,omitempty"`       // Distribution statistics of scale answers
+	NPS              *NPSResult         `json:"nps,omitempty"`              // Net Promoter Score of NPS questions and scale questions tagged as NPS
+	NPSCounts        map[int]int        `json:"npsCounts,omitempty"`        // Count of each score of NPS questions
+	MatrixResults    []MatrixRowResult  `json:"matrixResults,omitempty"`    // Distribution of the columns selected for each row of matrix questions
+	RankingResults   []RankingResult    `json:"rankingResults,omitempty"`   // Mean rank and Borda score of each option of ranking questions
+	Files            []FileResult       `json:"files,omitempty"`            // Files uploaded to file questions, with their download links
+}
+
+// FileResult describes a file uploaded to a file question
+type FileResult struct {
+	FileAnswer
+	URL string `json:"url"` // Path to download the file, restricted to the users who can view the results
+}
+
+// RankingResult describes how an option of a ranking question was ranked
+// The Borda score awards each option one point per option ranked below it
+type RankingResult struct {
+	OptionID   int     `json:"optionId"`   // Option ID
+	MeanRank   float64 `json:"meanRank"`   // Average position of the option, 1 being the most preferred
+	BordaScore int     `json:"bordaScore"` // Total Borda points of the option
+	FirstPlace int     `json:"firstPlace"` // Number of votes ranking the option first
+}
+
+// MatrixRowResult describes the results of a row of a matrix question
+type MatrixRowResult struct {
+	Row           int            `json:"row"`           // Row ID within the question
+	TotalVotes    int            `json:"totalVotes"`    // Number of votes answering the row
+	ColumnResults []OptionResult `json:"columnResults"` // Results for each column, identified by option ID
 }
 
 // OptionResult describes the result for a specific option
@@ -70,3 +208,34 @@ type Result struct {
 	Question int `json:"question"` // Question ID within the survey
 	Votes    int `json:"votes"`    // Number of votes for the question
 }
+
+// ResultsDelta describes an incremental change to the results of a survey
+// This struct carries only the question results that changed since the previous update
+type ResultsDelta struct {
+	Survey    string            `json:"survey"`    // Survey ID associated with the results
+	Results   []QuestionResults `json:"results"`   // Results of the questions that changed
+	UpdatedAt int64             `json:"updatedAt"` // Timestamp when the results were last updated
+}
+
+// SurveyEventType defines the type of a survey change event
+type SurveyEventType string
+
+// Available survey event types
+const (
+	SurveyEventCreated     SurveyEventType = "survey.created"     // Survey was created
+	SurveyEventUpdated     SurveyEventType = "survey.updated"     // Survey definition was updated
+	SurveyEventActivated   SurveyEventType = "survey.activated"   // Survey was activated
+	SurveyEventDeactivated SurveyEventType = "survey.deactivated" // Survey was deactivated
+	SurveyEventExpired     SurveyEventType = "survey.expired"     // Survey expiration date was set
+	SurveyEventDeleted     SurveyEventType = "survey.deleted"     // Survey was deleted
+	SurveyEventArchived    SurveyEventType = "survey.archived"    // Survey was archived
+	SurveyEventRestored    SurveyEventType = "survey.restored"    // Archived survey was restored
+)
+
+// SurveyEvent describes a change to a survey published by the survey service
+// This struct represents the survey change events used to invalidate cached surveys
+type SurveyEvent struct {
+	Type      SurveyEventType `json:"type"`      // Type of the change
+	Survey    string          `json:"survey"`    // ID of the changed survey
+	Timestamp int64           `json:"timestamp"` // Timestamp when the change happened
+}
@@ -1,5 +1,7 @@
 package vote
 
+import protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+
 // WriterRepository contains functions to write votes to a repository
 // This interface defines the methods required for writing votes to a repository
 type WriterRepository interface {
@@ -14,4 +16,44 @@ type ResultsRepository interface {
 	// GetResults gets the results for a given survey
 	// This method retrieves the results for the specified survey ID
 	GetResults(surveyID string) (Results, error)
+
+	// HasVoted checks if a user already answered a survey question
+	// This method only considers votes that are not archived
+	HasVoted(surveyID string, question int, userID string) (bool, error)
+
+	// LoadVotes gets the votes of a survey with their answers, ordered by time
+	// This method only returns votes that are not archived
+	LoadVotes(surveyID string) ([]*Vote, error)
+}
+
+// SurveyRepository contains functions to fetch survey definitions
+// This interface defines the methods required for reading the surveys votes are cast on
+type SurveyRepository interface {
+	// GetSurvey gets a survey by ID
+	// This method returns ErrSurveyNotFound for unknown surveys and ErrSurveyUnavailable
+	// when the survey service cannot be reached
+	GetSurvey(id string) (*protos.SurveyResponse, error)
+}
+
+// InvitationRepository contains functions to consume the invitations of invite-only surveys
+// This interface defines the methods required for checking that respondents were invited
+type InvitationRepository interface {
+	// Use consumes an invitation token to answer a question of a survey
+	// This method returns ErrInvalidInvitation for unknown tokens and ErrInvitationUsed when
+	// the question was already answered with the token
+	Use(surveyID, token string, question int) error
+
+	// Release releases an invitation token used to answer a question of a survey
+	// This method lets the token answer the question again when the vote could not be stored
+	Release(surveyID, token string, question int) error
+}
+
+// SurveyCache contains functions to cache survey definitions
+// This interface extends SurveyRepository with the ability to drop stale surveys
+type SurveyCache interface {
+	SurveyRepository
+
+	// Invalidate removes a survey from the cache
+	// This method forces the next lookup of the survey to reach the survey service
+	Invalidate(id string)
 }
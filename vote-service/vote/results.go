@@ -0,0 +1,259 @@
+package vote
+
+import (
+	"sort"
+	"time"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+)
+
+// computeResults computes the results of a survey from individual votes
+// Questions are reported in survey order and only when they received votes, like the stored results
+func computeResults(surv *protos.SurveyResponse, votes []*Vote) Results {
+	results := Results{Survey: surv.GetId(), Results: []QuestionResults{}}
+
+	byQuestion := map[int][]*Vote{}
+	for _, v := range votes {
+		byQuestion[v.Question] = append(byQuestion[v.Question], v)
+		if v.Timestamp > results.UpdatedAt {
+			results.UpdatedAt = v.Timestamp
+		}
+	}
+
+	for _, q := range surv.GetQuestions() {
+		qv := byQuestion[int(q.GetId())]
+		if len(qv) == 0 {
+			continue
+		}
+		results.Results = append(results.Results, computeQuestionResults(q, qv))
+	}
+	return results
+}
+
+// computeQuestionResults computes the results of a question from its votes
+func computeQuestionResults(q *protos.QuestionResponse, votes []*Vote) QuestionResults {
+	qr := QuestionResults{Question: int(q.GetId()), TotalVotes: len(votes)}
+
+	options := map[int]int{}
+	texts := map[string]int{}
+	others := map[string]int{}
+	var ratings, scales, npsValues []int
+	matrix := map[int]map[int]int{}
+	var rankings [][]int
+	dates := map[string]int{}
+	for _, v := range votes {
+		for _, id := range v.SelectedOptions() {
+			options[id]++
+		}
+		if v.TextAnswer != nil {
+			texts[*v.TextAnswer]++
+		}
+		if v.OtherText != nil {
+			others[*v.OtherText]++
+		}
+		if v.RatingValue != nil {
+			ratings = append(ratings, *v.RatingValue)
+		}
+		if v.ScaleValue != nil {
+			scales = append(scales, *v.ScaleValue)
+		}
+		if v.NPSValue != nil {
+			npsValues = append(npsValues, *v.NPSValue)
+		}
+		for _, a := range v.MatrixAnswers {
+			if matrix[a.Row] == nil {
+				matrix[a.Row] = map[int]int{}
+			}
+			matrix[a.Row][a.Column]++
+		}
+		if len(v.Ranking) > 0 {
+			rankings = append(rankings, v.Ranking)
+		}
+		if v.DateAnswer != nil {
+			dates[DateGroupingDay.key(time.Unix(*v.DateAnswer, 0))]++
+		}
+		if v.File != nil {
+			qr.Files = append(qr.Files, FileResult{FileAnswer: *v.File, URL: FileURL(v.File.ID)})
+		}
+	}
+
+	if len(options) > 0 {
+		// Options of the survey come first, in survey order, so empty options are reported too
+		seen := map[int]bool{}
+		for _, o := range q.GetOptions() {
+			id := int(o.GetId())
+			seen[id] = true
+			qr.OptionResults = append(qr.OptionResults, OptionResult{
+				OptionID:   id,
+				Count:      options[id],
+				Percentage: percentage(options[id], qr.TotalVotes),
+			})
+		}
+		var extra []int
+		for id := range options {
+			if !seen[id] {
+				extra = append(extra, id)
+			}
+		}
+		sort.Ints(extra)
+		for _, id := range extra {
+			qr.OptionResults = append(qr.OptionResults, OptionResult{
+				OptionID:   id,
+				Count:      options[id],
+				Percentage: percentage(options[id], qr.TotalVotes),
+			})
+		}
+	}
+
+	qr.TextAnswers = textAnswerResults(texts)
+	qr.OtherAnswers = textAnswerResults(others)
+
+	if len(ratings) > 0 {
+		avg, counts := averageAndCounts(ratings)
+		qr.AverageRating = &avg
+		qr.RatingCounts = counts
+		qr.RatingStats = computeNumericStats(ratings)
+	}
+	if len(scales) > 0 {
+		avg, counts := averageAndCounts(scales)
+		qr.AverageScale = &avg
+		qr.ScaleCounts = counts
+		qr.ScaleStats = computeNumericStats(scales)
+		if hasTag(q.GetTags(), QuestionTagNPS) {
+			qr.NPS = computeNPS(scales)
+		}
+	}
+	if len(npsValues) > 0 {
+		_, qr.NPSCounts = averageAndCounts(npsValues)
+		qr.NPS = computeNPS(npsValues)
+	}
+	if len(matrix) > 0 {
+		qr.MatrixResults = computeMatrixResults(q, matrix)
+	}
+	if len(rankings) > 0 {
+		qr.RankingResults = computeRankingResults(q, rankings)
+	}
+	if len(dates) > 0 {
+		qr.DateDistribution = dates
+		qr.DateGrouping = DateGroupingDay
+	}
+	return qr
+}
+
+// computeRankingResults computes the mean rank and Borda score of each option of a ranking question
+// Options are reported from the highest to the lowest Borda score, ties keep survey order
+func computeRankingResults(q *protos.QuestionResponse, rankings [][]int) []RankingResult {
+	n := len(q.GetOptions())
+	byOption := map[int]*RankingResult{}
+	ranks := map[int]int{}
+	counts := map[int]int{}
+	results := make([]RankingResult, 0, n)
+	for _, o := range q.GetOptions() {
+		results = append(results, RankingResult{OptionID: int(o.GetId())})
+	}
+	for i := range results {
+		byOption[results[i].OptionID] = &results[i]
+	}
+
+	for _, ranking := range rankings {
+		for position, id := range ranking {
+			r, ok := byOption[id]
+			if !ok {
+				continue
+			}
+			ranks[id] += position + 1
+			counts[id]++
+			r.BordaScore += n - 1 - position
+			if position == 0 {
+				r.FirstPlace++
+			}
+		}
+	}
+
+	for i := range results {
+		id := results[i].OptionID
+		if counts[id] > 0 {
+			results[i].MeanRank = float64(ranks[id]) / float64(counts[id])
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].BordaScore > results[j].BordaScore })
+	return results
+}
+
+// computeMatrixResults computes the column distribution of each row of a matrix question
+// Rows and columns are reported in survey order, so empty rows and columns are reported too
+func computeMatrixResults(q *protos.QuestionResponse, counts map[int]map[int]int) []MatrixRowResult {
+	var results []MatrixRowResult
+	for _, row := range q.GetRows() {
+		columns := counts[int(row.GetId())]
+		rr := MatrixRowResult{Row: int(row.GetId()), ColumnResults: []OptionResult{}}
+		for _, count := range columns {
+			rr.TotalVotes += count
+		}
+		for _, column := range q.GetColumns() {
+			id := int(column.GetId())
+			rr.ColumnResults = append(rr.ColumnResults, OptionResult{
+				OptionID:   id,
+				Count:      columns[id],
+				Percentage: percentage(columns[id], rr.TotalVotes),
+			})
+		}
+		results = append(results, rr)
+	}
+	return results
+}
+
+// addDetails adds the answer breakdowns and statistics computed from votes to stored results
+// The stored vote counts are kept, since votes cast before answers were stored have no details
+func addDetails(results Results, surv *protos.SurveyResponse, votes []*Vote) Results {
+	detailed := map[int]QuestionResults{}
+	for _, qr := range computeResults(surv, votes).Results {
+		detailed[qr.Question] = qr
+	}
+
+	for i, qr := range results.Results {
+		d, ok := detailed[qr.Question]
+		if !ok {
+			continue
+		}
+		d.TotalVotes = qr.TotalVotes
+		results.Results[i] = d
+	}
+	return results
+}
+
+// textAnswerResults lists how often each text was answered, the most frequent first
+func textAnswerResults(counts map[string]int) []TextAnswerResult {
+	var results []TextAnswerResult
+	for answer, count := range counts {
+		results = append(results, TextAnswerResult{Answer: answer, Count: count})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+		return results[i].Answer < results[j].Answer
+	})
+	return results
+}
+
+// averageAndCounts returns the average of a list of values and how often each value occurs
+func averageAndCounts(values []int) (float64, map[int]int) {
+	counts := map[int]int{}
+	sum := 0
+	for _, value := range values {
+		counts[value]++
+		sum += value
+	}
+	return float64(sum) / float64(len(values)), counts
+}
+
+// withoutFiles returns a copy of results without the files uploaded to file questions
+// Results can be public, while uploaded files are only listed to the users allowed to download them
+func withoutFiles(results Results) Results {
+	results = copyResults(results)
+	for i := range results.Results {
+		results.Results[i].Files = nil
+	}
+	return results
+}
@@ -0,0 +1,105 @@
+package vote
+
+import (
+	"testing"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// newResultsSurvey creates a survey with a choice, a matrix, a ranking, a text and an unanswered question
+func newResultsSurvey() *protos.SurveyResponse {
+	return &protos.SurveyResponse{Id: "survey-1", Questions: []*protos.QuestionResponse{
+		{Id: 1, Type: QuestionTypeSingleChoice, Options: newOptions(1, 2)},
+		{Id: 2, Type: QuestionTypeMatrix, Rows: newOptions(1, 2), Columns: newOptions(1, 2)},
+		{Id: 3, Type: QuestionTypeRanking, Options: newOptions(1, 2, 3)},
+		{Id: 4, Type: QuestionTypeText},
+		{Id: 5, Type: QuestionTypeText},
+	}}
+}
+
+// newResultsVotes creates votes answering the questions of the results survey
+func newResultsVotes() []*Vote {
+	one, three := 1, 3
+	yes, no := "Yes", "No"
+	return []*Vote{
+		{Question: 1, Timestamp: 10, OptionID: &one},
+		{Question: 1, Timestamp: 11, OptionID: &one},
+		{Question: 1, Timestamp: 12, OptionID: &three},
+		{Question: 2, Timestamp: 13, MatrixAnswers: []MatrixAnswer{{Row: 1, Column: 1}, {Row: 2, Column: 2}}},
+		{Question: 2, Timestamp: 14, MatrixAnswers: []MatrixAnswer{{Row: 1, Column: 2}}},
+		{Question: 3, Timestamp: 15, Ranking: []int{2, 1, 3}},
+		{Question: 3, Timestamp: 16, Ranking: []int{2, 3, 1}},
+		{Question: 4, Timestamp: 17, TextAnswer: &yes},
+		{Question: 4, Timestamp: 18, TextAnswer: &no},
+		{Question: 4, Timestamp: 19, TextAnswer: &yes},
+	}
+}
+
+// TestComputeResults tests the breakdown of choice, matrix, ranking and text answers
+func TestComputeResults(t *testing.T) {
+	results := computeResults(newResultsSurvey(), newResultsVotes())
+	assert.Equal(t, "survey-1", results.Survey, "Expected the survey ID")
+	assert.Equal(t, int64(19), results.UpdatedAt, "Expected the time of the last vote")
+	assert.Len(t, results.Results, 4, "Expected unanswered questions to be left out")
+
+	choice := results.Results[0]
+	assert.Equal(t, 3, choice.TotalVotes, "Expected the votes of the choice question")
+	assert.Equal(t, []OptionResult{
+		{OptionID: 1, Count: 2, Percentage: 200.0 / 3},
+		{OptionID: 2, Count: 0, Percentage: 0},
+		{OptionID: 3, Count: 1, Percentage: 100.0 / 3},
+	}, choice.OptionResults, "Expected survey options first, then unknown options")
+
+	matrix := results.Results[1]
+	assert.Equal(t, []MatrixRowResult{
+		{Row: 1, TotalVotes: 2, ColumnResults: []OptionResult{{OptionID: 1, Count: 1, Percentage: 50}, {OptionID: 2, Count: 1, Percentage: 50}}},
+		{Row: 2, TotalVotes: 1, ColumnResults: []OptionResult{{OptionID: 1, Count: 0, Percentage: 0}, {OptionID: 2, Count: 1, Percentage: 100}}},
+	}, matrix.MatrixResults, "Expected the column distribution of each row")
+
+	ranking := results.Results[2]
+	assert.Equal(t, []RankingResult{
+		{OptionID: 2, MeanRank: 1, BordaScore: 4, FirstPlace: 2},
+		{OptionID: 1, MeanRank: 2.5, BordaScore: 1},
+		{OptionID: 3, MeanRank: 2.5, BordaScore: 1},
+	}, ranking.RankingResults, "Expected options by Borda score, ties in survey order")
+
+	text := results.Results[3]
+	assert.Equal(t, []TextAnswerResult{{Answer: "Yes", Count: 2}, {Answer: "No", Count: 1}}, text.TextAnswers, "Expected the most frequent answers first")
+}
+
+// TestComputeResultsNPS tests that NPS questions and scale questions tagged as NPS report a score
+func TestComputeResultsNPS(t *testing.T) {
+	surv := &protos.SurveyResponse{Id: "survey-1", Questions: []*protos.QuestionResponse{
+		{Id: 1, Type: QuestionTypeNPS},
+		{Id: 2, Type: QuestionTypeScale, Tags: []string{QuestionTagNPS}},
+		{Id: 3, Type: QuestionTypeScale},
+	}}
+	ten, zero := 10, 0
+	votes := []*Vote{
+		{Question: 1, NPSValue: &ten},
+		{Question: 1, NPSValue: &zero},
+		{Question: 2, ScaleValue: &ten},
+		{Question: 3, ScaleValue: &ten},
+	}
+
+	results := computeResults(surv, votes)
+	assert.Equal(t, map[int]int{0: 1, 10: 1}, results.Results[0].NPSCounts, "Expected the count of each score")
+	assert.Equal(t, 0.0, results.Results[0].NPS.Score, "Expected the score of the NPS question")
+	assert.Equal(t, 100.0, results.Results[1].NPS.Score, "Expected the score of the tagged scale question")
+	assert.NotNil(t, results.Results[1].ScaleStats, "Expected the statistics of the tagged scale question")
+	assert.Nil(t, results.Results[2].NPS, "Expected no score for untagged scale questions")
+}
+
+// TestAddDetails tests that details are added to stored results, keeping the stored counts
+func TestAddDetails(t *testing.T) {
+	stored := Results{Survey: "survey-1", UpdatedAt: 19, Results: []QuestionResults{
+		{Question: 4, TotalVotes: 5},
+		{Question: 5, TotalVotes: 2},
+	}}
+
+	results := addDetails(stored, newResultsSurvey(), newResultsVotes())
+	assert.Equal(t, 5, results.Results[0].TotalVotes, "Expected the stored count to be kept")
+	assert.Len(t, results.Results[0].TextAnswers, 2, "Expected the text answers to be added")
+	assert.Equal(t, QuestionResults{Question: 5, TotalVotes: 2}, results.Results[1], "Expected questions without answers to keep the stored results")
+}
@@ -11,10 +11,34 @@ type Serializer interface {
 	// This method converts vote results into a byte slice (e.g., JSON)
 	EncodeResults(r *Results) ([]byte, error)
 
+	// EncodeFunnel encodes a completion funnel
+	// This method converts a funnel into a byte slice (e.g., JSON)
+	EncodeFunnel(f *Funnel) ([]byte, error)
+
+	// EncodeTimeSeries encodes a time series of votes
+	// This method converts a time series into a byte slice (e.g., JSON)
+	EncodeTimeSeries(t *TimeSeries) ([]byte, error)
+
+	// EncodeCrossTab encodes a contingency table
+	// This method converts a cross-tabulation into a byte slice (e.g., JSON)
+	EncodeCrossTab(c *CrossTab) ([]byte, error)
+
 	// EncodeErrorResponse encodes an error response
 	// This method converts an error response into a byte slice (e.g., JSON)
 	EncodeErrorResponse(err ErrorResponse) ([]byte, error)
 
+	// EncodeSession encodes a response session
+	// This method converts a session into a byte slice (e.g., JSON)
+	EncodeSession(s *Session) ([]byte, error)
+
+	// EncodeSessionReport encodes a session completion report
+	// This method converts a session report into a byte slice (e.g., JSON)
+	EncodeSessionReport(r *SessionReport) ([]byte, error)
+
+	// EncodeFile encodes the metadata of an uploaded file
+	// This method converts a file into a byte slice (e.g., JSON)
+	EncodeFile(f *File) ([]byte, error)
+
 	// Decode decodes a vote
 	// This method converts a byte slice into a vote (e.g., from JSON)
 	Decode(data []byte) (*Vote, error)
@@ -23,6 +47,18 @@ type Serializer interface {
 	// This method converts a byte slice into vote results (e.g., from JSON)
 	DecodeResults(data []byte) (*Results, error)
 
+	// DecodeSessionRequest decodes a request to start a response session
+	// This method converts a byte slice into a session request (e.g., from JSON)
+	DecodeSessionRequest(data []byte) (*SessionRequest, error)
+
+	// DecodeAnswers decodes the answers saved in a response session
+	// This method converts a byte slice into a list of answers (e.g., from JSON)
+	DecodeAnswers(data []byte) ([]*Vote, error)
+
+	// DecodeSurveyEvent decodes a survey change event
+	// This method converts a byte slice into a survey event (e.g., from JSON)
+	DecodeSurveyEvent(data []byte) (*SurveyEvent, error)
+
 	// GetContentType returns the content-type
 	// This method returns the MIME type of the serialized data (e.g., "application/json")
 	GetContentType() string
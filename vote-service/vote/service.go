@@ -3,11 +3,31 @@ package vote
 // Service contains functions to create and load votes
 // This interface defines the methods required for managing votes in the application
 type Service interface {
-	// Insert stores a new vote
+	// Insert stores a new vote cast by the user, nil for anonymous votes
 	// This method saves a new vote to the repository
-	Insert(vote *Vote) error
+	Insert(user *User, vote *Vote) error
 
-	// GetResults gets the results for a given survey
-	// This method retrieves the results for the specified survey ID
-	GetResults(surveyID string) (Results, error)
+	// GetResults gets the results for a given survey the user can view
+	// This method retrieves the results for the specified survey ID, returning ErrForbidden
+	// when results are not public and the user neither owns the survey nor has it shared
+	GetResults(user *User, surveyID string) (Results, error)
+
+	// GetFilteredResults gets the results for a given survey the user can view over a segment of respondents
+	// This method only counts the respondents whose answers match every filter, returning
+	// ErrInvalidRequest when a filter refers to an unknown question
+	GetFilteredResults(user *User, surveyID string, filters []Filter) (Results, error)
+
+	// GetFunnel gets the completion funnel of a survey the user can view
+	// This method groups the stored votes by respondent to report starts, completions, per-question
+	// drop-off and the median time spent on each question
+	GetFunnel(user *User, surveyID string) (Funnel, error)
+
+	// GetTimeSeries gets the votes of a survey the user can view cast from a start until an end bucketed over time
+	// This method counts the votes of each question and option per hour or day, zero leaves the start or end open,
+	// and returns ErrInvalidRequest when the range needs more than MaxSeriesBuckets buckets
+	GetTimeSeries(user *User, surveyID string, interval Interval, from, to int64) (TimeSeries, error)
+
+	// GetCrossTab gets the answers of a question sliced by the answers of another for a survey the user can view
+	// This method returns ErrInvalidRequest unless both questions are choice, rating or scale questions
+	GetCrossTab(user *User, surveyID string, row, column int) (CrossTab, error)
 }
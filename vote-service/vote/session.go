@@ -0,0 +1,125 @@
+package vote
+
+import "errors"
+
+var (
+	// ErrSessionNotFound indicates that no response session exists for a token
+	ErrSessionNotFound = errors.New("Session not found")
+
+	// ErrSessionExpired indicates that the response session was not finished in time
+	ErrSessionExpired = errors.New("Session expired")
+
+	// ErrSessionCompleted indicates that the response session was already finalized
+	ErrSessionCompleted = errors.New("Session already completed")
+
+	// ErrSessionConflict indicates that another request changed the response session, such as finalizing it at the same time
+	ErrSessionConflict = errors.New("Session is being changed by another request")
+)
+
+// SessionStatus defines the status of a response session
+type SessionStatus string
+
+// Available session statuses
+const (
+	SessionInProgress SessionStatus = "in_progress" // Answers can still be saved
+	SessionFinalizing SessionStatus = "finalizing"  // Answers are being submitted as votes by one request
+	SessionCompleted  SessionStatus = "completed"   // Answers were submitted as votes
+	SessionExpired    SessionStatus = "expired"     // Session was abandoned, counted as a partial completion
+)
+
+// Session describes a response session
+// This struct holds the answers a respondent saved so far, until they are submitted as votes
+type Session struct {
+	ID          string        `json:"id"`                    // Unique identifier for the session
+	Token       string        `json:"token,omitempty"`       // Token to resume the session, only returned when the session is started
+	TokenHash   string        `json:"-"`                     // Hash of the token, the token itself is never stored
+	Survey      string        `json:"survey"`                // Survey ID associated with the session
+	UserID      string        `json:"-"`                     // ID of the user who started the session, empty for anonymous sessions
+	Answers     []*Vote       `json:"answers"`               // Answers saved so far, one per question
+	Status      SessionStatus `json:"status"`                // Current status of the session
+	CreatedAt   int64         `json:"createdAt"`             // Timestamp when the session was started
+	UpdatedAt   int64         `json:"updatedAt"`             // Timestamp when answers were last saved
+	ExpiresAt   int64         `json:"expiresAt"`             // Timestamp when the session expires unless answers are saved
+	CompletedAt int64         `json:"completedAt,omitempty"` // Timestamp when the session was finalized
+}
+
+// SessionRequest describes a request to start a response session
+type SessionRequest struct {
+	Survey string `json:"survey"` // Survey ID to respond to
+}
+
+// SessionReport describes the completion of the response sessions of a survey
+// Expired sessions were abandoned before being finalized and are reported as partial completions
+type SessionReport struct {
+	Survey     string `json:"survey"`     // Survey ID associated with the report
+	Started    int    `json:"started"`    // Number of sessions started
+	InProgress int    `json:"inProgress"` // Number of sessions that can still be finished
+	Completed  int    `json:"completed"`  // Number of sessions finalized
+	Partial    int    `json:"partial"`    // Number of sessions that expired before being finalized
+}
+
+// Client describes the client finalizing a response session
+// Its details are attached to every submitted vote and checked for anonymous votes
+type Client struct {
+	IP              string // IP address of the client
+	Fingerprint     string // Fingerprint of the client
+	RespondentToken string // Respondent token issued when the client fetched the survey
+	InvitationToken string // Invitation token of the recipient answering an invite-only survey
+}
+
+// SessionService contains functions to save answers and submit them later
+// This interface defines the methods required for the save-and-resume flow of respondents
+type SessionService interface {
+	// StartSession starts a response session on a survey for the user, nil for anonymous sessions
+	// This method returns the session with the token needed to resume it
+	StartSession(user *User, surveyID string) (*Session, error)
+
+	// ResumeSession loads a response session by token
+	// This method returns ErrSessionExpired for sessions not finished in time
+	ResumeSession(user *User, token string) (*Session, error)
+
+	// SaveAnswers stores answers in a response session
+	// This method replaces earlier answers to the same questions and extends the expiration
+	SaveAnswers(user *User, token string, answers []*Vote) (*Session, error)
+
+	// FinalizeSession submits the answers of a response session as votes
+	// This method applies every rule of casting a vote, and can be retried after a failure
+	// without submitting answers twice
+	FinalizeSession(user *User, token string, c Client) (*Session, error)
+
+	// GetSessionReport gets the completion report of the sessions of a survey the user can view
+	// This method authorizes the user like the results of the survey
+	GetSessionReport(user *User, surveyID string) (SessionReport, error)
+
+	// ExpireSessions expires the sessions that were not finished in time
+	// This method returns the number of sessions expired
+	ExpireSessions() (int, error)
+}
+
+// SessionRepository contains functions to store response sessions
+// This interface defines the methods required for persisting sessions between requests
+type SessionRepository interface {
+	// Insert stores a new session
+	Insert(s *Session) error
+
+	// LoadByHash gets a session by the hash of its token
+	// This method returns ErrSessionNotFound for unknown hashes
+	LoadByHash(hash string) (*Session, error)
+
+	// Update stores the answers, status and timestamps of a session that is still in a status
+	// This method returns ErrSessionConflict when another request changed the status of the session
+	Update(s *Session, from SessionStatus) error
+
+	// Claim marks a session in progress that has not expired at a timestamp as finalizing and returns it as stored
+	// Sessions left finalizing since before a timestamp by a failed request can be claimed again, any other session
+	// is reported with ErrSessionConflict, so only one request submits the answers of a session
+	Claim(id string, now, staleBefore int64) (*Session, error)
+
+	// Expire marks the sessions in progress that expired before a timestamp as expired
+	// This method returns the number of sessions expired
+	Expire(before int64) (int, error)
+
+	// CountByStatus counts the sessions of a survey by status
+	// Sessions in progress that expired before a timestamp are counted as expired, even before they are marked as such
+	CountByStatus(surveyID string, now int64) (map[SessionStatus]int, error)
+}
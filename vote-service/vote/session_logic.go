@@ -0,0 +1,281 @@
+package vote
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	uuid "github.com/satori/go.uuid"
+)
+
+// sessionTokenPrefix identifies response session tokens
+const sessionTokenPrefix = "rs_"
+
+// finalizeTimeout is how long a session can stay finalizing before another request may finalize it again
+// This lets respondents retry sessions whose finalizing request failed without releasing them
+const finalizeTimeout = time.Minute
+
+// sessionService implements the SessionService interface on top of the vote service
+type sessionService struct {
+	votes         Service
+	surveys       SurveyRepository
+	repository    SessionRepository
+	validator     *validator.Validate
+	ttl           time.Duration
+	publicResults bool
+}
+
+// NewSessionService creates a new response session service
+// This function initializes a service that submits finalized sessions through the vote service
+// Sessions expire when no answers are saved for the ttl
+func NewSessionService(votes Service, surveys SurveyRepository, repo SessionRepository, ttl time.Duration, publicResults bool) SessionService {
+	return &sessionService{
+		votes:         votes,
+		surveys:       surveys,
+		repository:    repo,
+		validator:     validator.New(),
+		ttl:           ttl,
+		publicResults: publicResults,
+	}
+}
+
+// StartSession starts a response session on a survey
+// This method generates the token of the session, only its hash is stored
+func (s *sessionService) StartSession(user *User, surveyID string) (*Session, error) {
+	surv, err := s.surveys.GetSurvey(surveyID)
+	if err != nil {
+		if errors.Is(err, ErrSurveyNotFound) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+		}
+		return nil, err
+	}
+	if user == nil && !surv.GetAllowAnonymous() && !surv.GetInviteOnly() {
+		return nil, ErrAuthenticationRequired
+	}
+
+	token, err := generateSessionToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	session := &Session{
+		ID:        uuid.NewV4().String(),
+		TokenHash: hashSessionToken(token),
+		Survey:    surveyID,
+		Answers:   []*Vote{},
+		Status:    SessionInProgress,
+		CreatedAt: now.Unix(),
+		UpdatedAt: now.Unix(),
+		ExpiresAt: now.Add(s.ttl).Unix(),
+	}
+	if user != nil {
+		session.UserID = user.ID
+	}
+
+	if err := s.repository.Insert(session); err != nil {
+		return nil, err
+	}
+
+	session.Token = token
+	return session, nil
+}
+
+// ResumeSession loads a response session by token
+func (s *sessionService) ResumeSession(user *User, token string) (*Session, error) {
+	return s.load(user, token)
+}
+
+// SaveAnswers stores answers in a response session
+// Answers are validated against the survey, but respondent checks only run when finalizing
+func (s *sessionService) SaveAnswers(user *User, token string, answers []*Vote) (*Session, error) {
+	session, err := s.load(user, token)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == SessionCompleted {
+		return nil, ErrSessionCompleted
+	}
+	if session.Status == SessionFinalizing {
+		return nil, ErrSessionConflict
+	}
+
+	surv, err := s.surveys.GetSurvey(session.Survey)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range answers {
+		a.Survey = session.Survey
+		if err := s.validator.Struct(a); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+		}
+		if err := validateAnswer(surv.GetQuestions(), a); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, a := range answers {
+		// Submission details are only set once the answer is cast as a vote
+		a.ID = ""
+		a.Timestamp = now.Unix()
+		a.UserID = ""
+		a.Replace = false
+		a.Respondent = ""
+		session.setAnswer(a)
+	}
+
+	session.UpdatedAt = now.Unix()
+	session.ExpiresAt = now.Add(s.ttl).Unix()
+	if err := s.repository.Update(session, SessionInProgress); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// FinalizeSession submits the answers of a response session as votes
+// The session is claimed first, so concurrent requests cannot submit its answers twice
+// Answers already submitted by an earlier attempt keep their vote ID and are skipped
+func (s *sessionService) FinalizeSession(user *User, token string, c Client) (*Session, error) {
+	session, err := s.load(user, token)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == SessionCompleted {
+		return nil, ErrSessionCompleted
+	}
+	if len(session.Answers) == 0 {
+		return nil, fmt.Errorf("%w: no answers saved", ErrInvalidRequest)
+	}
+
+	now := time.Now().UTC()
+	session, err = s.repository.Claim(session.ID, now.Unix(), now.Add(-finalizeTimeout).Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range session.Answers {
+		if a.ID != "" {
+			continue
+		}
+
+		v := *a
+		v.ClientIP = c.IP
+		v.Fingerprint = c.Fingerprint
+		v.RespondentToken = c.RespondentToken
+		v.InvitationToken = c.InvitationToken
+		v.Session = session.ID
+		if err := s.votes.Insert(user, &v); err != nil {
+			// Keep the answers submitted so far, so a retry does not cast them twice
+			session.Status = SessionInProgress
+			if updateErr := s.repository.Update(session, SessionFinalizing); updateErr != nil {
+				return nil, fmt.Errorf("%w (session update failed: %v)", err, updateErr)
+			}
+			return nil, err
+		}
+		a.ID = v.ID
+		a.Timestamp = v.Timestamp
+		a.UserID = v.UserID
+		a.Respondent = v.Respondent
+	}
+
+	completed := time.Now().UTC().Unix()
+	session.Status = SessionCompleted
+	session.UpdatedAt = completed
+	session.CompletedAt = completed
+	if err := s.repository.Update(session, SessionFinalizing); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSessionReport gets the completion report of the sessions of a survey
+func (s *sessionService) GetSessionReport(user *User, surveyID string) (SessionReport, error) {
+	if !s.publicResults {
+		if err := authorizeResults(s.surveys, user, surveyID); err != nil {
+			return SessionReport{}, err
+		}
+	}
+
+	// Abandoned sessions are counted as expired when reading, so the report does not depend on the sweep interval
+	counts, err := s.repository.CountByStatus(surveyID, time.Now().UTC().Unix())
+	if err != nil {
+		return SessionReport{}, err
+	}
+
+	report := SessionReport{
+		Survey:     surveyID,
+		InProgress: counts[SessionInProgress] + counts[SessionFinalizing],
+		Completed:  counts[SessionCompleted],
+		Partial:    counts[SessionExpired],
+	}
+	report.Started = report.InProgress + report.Completed + report.Partial
+	return report, nil
+}
+
+// ExpireSessions expires the sessions that were not finished in time
+func (s *sessionService) ExpireSessions() (int, error) {
+	return s.repository.Expire(time.Now().UTC().Unix())
+}
+
+// load gets a session by token and checks it belongs to the user
+// Sessions of other users are reported as not found, so their tokens cannot be probed
+func (s *sessionService) load(user *User, token string) (*Session, error) {
+	if token == "" {
+		return nil, ErrSessionNotFound
+	}
+
+	session, err := s.repository.LoadByHash(hashSessionToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != "" && (user == nil || user.ID != session.UserID) {
+		return nil, ErrSessionNotFound
+	}
+
+	if session.Status == SessionExpired {
+		return nil, ErrSessionExpired
+	}
+	if session.Status == SessionInProgress && session.ExpiresAt <= time.Now().UTC().Unix() {
+		session.Status = SessionExpired
+		if err := s.repository.Update(session, SessionInProgress); err != nil && !errors.Is(err, ErrSessionConflict) {
+			return nil, err
+		}
+		return nil, ErrSessionExpired
+	}
+	return session, nil
+}
+
+// setAnswer adds an answer to the session, replacing the answer to the same question
+func (s *Session) setAnswer(a *Vote) {
+	for i, existing := range s.Answers {
+		if existing.Question == a.Question {
+			// Answers already cast as votes cannot be changed
+			if existing.ID == "" {
+				s.Answers[i] = a
+			}
+			return
+		}
+	}
+	s.Answers = append(s.Answers, a)
+}
+
+// generateSessionToken generates a new random session token
+func generateSessionToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return sessionTokenPrefix + hex.EncodeToString(b), nil
+}
+
+// hashSessionToken hashes a session token for storage and lookup
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
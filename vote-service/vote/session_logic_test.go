@@ -0,0 +1,142 @@
+package vote
+
+import (
+	"testing"
+	"time"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSessions is a session repository storing copies of sessions in memory
+type testSessions struct {
+	sessions map[string]Session
+}
+
+// Insert stores a copy of the session
+func (r *testSessions) Insert(s *Session) error {
+	r.sessions[s.ID] = *s
+	return nil
+}
+
+// LoadByHash returns a copy of the session with the token hash
+func (r *testSessions) LoadByHash(hash string) (*Session, error) {
+	for _, s := range r.sessions {
+		if s.TokenHash == hash {
+			return &s, nil
+		}
+	}
+	return nil, ErrSessionNotFound
+}
+
+// Update stores a copy of the session when it is still in the status
+func (r *testSessions) Update(s *Session, from SessionStatus) error {
+	if r.sessions[s.ID].Status != from {
+		return ErrSessionConflict
+	}
+	r.sessions[s.ID] = *s
+	return nil
+}
+
+// Claim marks the session as finalizing when it is in progress and not expired
+func (r *testSessions) Claim(id string, now, staleBefore int64) (*Session, error) {
+	s := r.sessions[id]
+	inProgress := s.Status == SessionInProgress && s.ExpiresAt > now
+	stale := s.Status == SessionFinalizing && s.UpdatedAt <= staleBefore
+	if !inProgress && !stale {
+		return nil, ErrSessionConflict
+	}
+	s.Status = SessionFinalizing
+	s.UpdatedAt = now
+	r.sessions[id] = s
+	return &s, nil
+}
+
+// Expire marks the expired sessions in progress as expired
+func (r *testSessions) Expire(before int64) (int, error) {
+	n := 0
+	for id, s := range r.sessions {
+		if s.Status == SessionInProgress && s.ExpiresAt <= before {
+			s.Status = SessionExpired
+			r.sessions[id] = s
+			n++
+		}
+	}
+	return n, nil
+}
+
+// CountByStatus counts the sessions by status, counting expired sessions in progress as expired
+func (r *testSessions) CountByStatus(surveyID string, now int64) (map[SessionStatus]int, error) {
+	counts := map[SessionStatus]int{}
+	for _, s := range r.sessions {
+		if s.Status == SessionInProgress && s.ExpiresAt <= now {
+			counts[SessionExpired]++
+		} else {
+			counts[s.Status]++
+		}
+	}
+	return counts, nil
+}
+
+// anonymousSurvey is an anonymous survey with one text question
+var anonymousSurvey = &protos.SurveyResponse{
+	Id:             "survey-1",
+	AllowAnonymous: true,
+	Questions:      []*protos.QuestionResponse{{Id: 1, Type: QuestionTypeText}},
+}
+
+// TestFinalizeSessionClaims tests that a session being finalized is not finalized again
+func TestFinalizeSessionClaims(t *testing.T) {
+	writer := &testWriter{}
+	surveys := &testSurveys{anonymousSurvey}
+	votes := NewService(writer, &testResults{writer: writer}, surveys, testGuard{}, nil, nil, nil, true)
+	repo := &testSessions{sessions: make(map[string]Session)}
+	s := NewSessionService(votes, surveys, repo, time.Hour, true)
+
+	session, err := s.StartSession(nil, "survey-1")
+	assert.NoError(t, err, "Expected no error on start")
+	_, err = s.SaveAnswers(nil, session.Token, []*Vote{newTextVote("")})
+	assert.NoError(t, err, "Expected no error on save")
+
+	// Another request is finalizing the session
+	_, err = repo.Claim(session.ID, time.Now().Unix(), 0)
+	assert.NoError(t, err, "Expected the session to be claimed")
+	_, err = s.FinalizeSession(nil, session.Token, Client{})
+	assert.ErrorIs(t, err, ErrSessionConflict, "Expected the claimed session not to be finalized twice")
+	_, err = s.SaveAnswers(nil, session.Token, []*Vote{newTextVote("")})
+	assert.ErrorIs(t, err, ErrSessionConflict, "Expected answers not to change while finalizing")
+	assert.Empty(t, writer.votes, "Expected no votes to be cast")
+
+	// The other request failed and left the session finalizing
+	stale := repo.sessions[session.ID]
+	stale.UpdatedAt -= int64(finalizeTimeout / time.Second)
+	repo.sessions[session.ID] = stale
+	finalized, err := s.FinalizeSession(nil, session.Token, Client{})
+	assert.NoError(t, err, "Expected the stale session to be finalized")
+	assert.Equal(t, SessionCompleted, finalized.Status, "Expected the session to be completed")
+	assert.Len(t, writer.votes, 1, "Expected the answer to be cast once")
+
+	_, err = s.FinalizeSession(nil, session.Token, Client{})
+	assert.ErrorIs(t, err, ErrSessionCompleted, "Expected completed sessions not to be finalized again")
+}
+
+// TestGetSessionReportExpired tests that abandoned sessions are reported as partial before they are swept
+func TestGetSessionReportExpired(t *testing.T) {
+	writer := &testWriter{}
+	surveys := &testSurveys{anonymousSurvey}
+	votes := NewService(writer, &testResults{writer: writer}, surveys, testGuard{}, nil, nil, nil, true)
+	repo := &testSessions{sessions: make(map[string]Session)}
+	s := NewSessionService(votes, surveys, repo, time.Hour, true)
+
+	active, _ := s.StartSession(nil, "survey-1")
+	abandoned, _ := s.StartSession(nil, "survey-1")
+	expired := repo.sessions[abandoned.ID]
+	expired.ExpiresAt = time.Now().Unix() - 1
+	repo.sessions[abandoned.ID] = expired
+
+	report, err := s.GetSessionReport(nil, "survey-1")
+	assert.NoError(t, err, "Expected no error on report")
+	assert.Equal(t, SessionReport{Survey: "survey-1", Started: 2, InProgress: 1, Partial: 1}, report, "Expected the abandoned session to be partial")
+	assert.Equal(t, SessionInProgress, repo.sessions[abandoned.ID].Status, "Expected reports not to change sessions")
+	assert.Equal(t, SessionInProgress, repo.sessions[active.ID].Status, "Expected reports not to change sessions")
+}
@@ -0,0 +1,167 @@
+package vote
+
+import (
+	"math"
+	"sort"
+)
+
+// QuestionTagNPS tags a 0-10 scale question as a Net Promoter Score question, matching the survey service
+const QuestionTagNPS = "nps"
+
+// Fixed bounds of the answers to NPS questions, matching the survey service
+const (
+	NPSMinValue = 0  // Lowest NPS answer
+	NPSMaxValue = 10 // Highest NPS answer
+)
+
+// confidenceLevel is the confidence level of the reported confidence intervals
+const confidenceLevel = 0.95
+
+// reportedPercentiles are the percentiles included in numeric statistics
+var reportedPercentiles = []int{10, 25, 50, 75, 90}
+
+// tCritical holds the two-sided 95% critical values of Student's t distribution by degrees of freedom
+// Larger samples use the normal approximation
+var tCritical = []float64{
+	0, 12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// NumericStats describes the distribution of the answers to a rating or scale question
+type NumericStats struct {
+	Count              int                 `json:"count"`                        // Number of answers
+	Mean               float64             `json:"mean"`                         // Average answer
+	Median             float64             `json:"median"`                       // Median answer
+	StdDev             float64             `json:"stdDev"`                       // Sample standard deviation, zero for a single answer
+	Min                int                 `json:"min"`                          // Lowest answer
+	Max                int                 `json:"max"`                          // Highest answer
+	Percentiles        map[int]float64     `json:"percentiles"`                  // Answer at the 10th, 25th, 50th, 75th and 90th percentiles
+	ConfidenceInterval *ConfidenceInterval `json:"confidenceInterval,omitempty"` // Confidence interval of the mean, omitted for a single answer
+}
+
+// ConfidenceInterval describes the confidence interval of a mean
+type ConfidenceInterval struct {
+	Level float64 `json:"level"` // Confidence level, such as 0.95
+	Lower float64 `json:"lower"` // Lower bound of the interval
+	Upper float64 `json:"upper"` // Upper bound of the interval
+}
+
+// NPSResult describes the Net Promoter Score of a 0-10 question
+// Promoters answered 9 or 10, passives 7 or 8 and detractors 0 to 6
+type NPSResult struct {
+	Promoters           int     `json:"promoters"`           // Number of promoters
+	Passives            int     `json:"passives"`            // Number of passives
+	Detractors          int     `json:"detractors"`          // Number of detractors
+	PromoterPercentage  float64 `json:"promoterPercentage"`  // Percentage of answers from promoters
+	PassivePercentage   float64 `json:"passivePercentage"`   // Percentage of answers from passives
+	DetractorPercentage float64 `json:"detractorPercentage"` // Percentage of answers from detractors
+	Score               float64 `json:"score"`               // Percentage of promoters minus percentage of detractors, from -100 to 100
+}
+
+// computeNumericStats computes the statistics of a list of answers
+// This function returns nil when there are no answers
+func computeNumericStats(values []int) *NumericStats {
+	n := len(values)
+	if n == 0 {
+		return nil
+	}
+
+	sorted := make([]float64, n)
+	sum := 0.0
+	for i, v := range values {
+		sorted[i] = float64(v)
+		sum += float64(v)
+	}
+	sort.Float64s(sorted)
+
+	stats := &NumericStats{
+		Count:       n,
+		Mean:        sum / float64(n),
+		Min:         int(sorted[0]),
+		Max:         int(sorted[n-1]),
+		Percentiles: map[int]float64{},
+	}
+	stats.Median = percentile(sorted, 50)
+	for _, p := range reportedPercentiles {
+		stats.Percentiles[p] = percentile(sorted, p)
+	}
+
+	if n > 1 {
+		squares := 0.0
+		for _, v := range sorted {
+			squares += (v - stats.Mean) * (v - stats.Mean)
+		}
+		stats.StdDev = math.Sqrt(squares / float64(n-1))
+
+		margin := criticalValue(n-1) * stats.StdDev / math.Sqrt(float64(n))
+		stats.ConfidenceInterval = &ConfidenceInterval{
+			Level: confidenceLevel,
+			Lower: stats.Mean - margin,
+			Upper: stats.Mean + margin,
+		}
+	}
+	return stats
+}
+
+// computeNPS computes the Net Promoter Score of a list of 0-10 answers
+// This function returns nil when there are no answers
+func computeNPS(values []int) *NPSResult {
+	if len(values) == 0 {
+		return nil
+	}
+
+	nps := &NPSResult{}
+	for _, v := range values {
+		switch {
+		case v >= 9:
+			nps.Promoters++
+		case v >= 7:
+			nps.Passives++
+		default:
+			nps.Detractors++
+		}
+	}
+	nps.PromoterPercentage = percentage(nps.Promoters, len(values))
+	nps.PassivePercentage = percentage(nps.Passives, len(values))
+	nps.DetractorPercentage = percentage(nps.Detractors, len(values))
+	nps.Score = nps.PromoterPercentage - nps.DetractorPercentage
+	return nps
+}
+
+// percentage returns part as a percentage of total, zero when total is zero
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+// percentile returns a percentile of sorted values, interpolating linearly between ranks
+func percentile(sorted []float64, p int) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := float64(p) / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+// criticalValue returns the two-sided 95% critical value for the degrees of freedom
+func criticalValue(df int) float64 {
+	if df < len(tCritical) {
+		return tCritical[df]
+	}
+	return 1.96
+}
+
+// hasTag checks if a list of question tags contains a tag
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
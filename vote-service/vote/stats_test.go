@@ -0,0 +1,74 @@
+package vote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestComputeNumericStats tests the mean, spread, percentiles and confidence interval of answers
+func TestComputeNumericStats(t *testing.T) {
+	assert.Nil(t, computeNumericStats(nil), "Expected no statistics without answers")
+
+	stats := computeNumericStats([]int{5, 1, 4, 2, 3})
+	assert.Equal(t, 5, stats.Count, "Expected the answers to be counted")
+	assert.Equal(t, 3.0, stats.Mean, "Expected the mean")
+	assert.Equal(t, 3.0, stats.Median, "Expected the median")
+	assert.Equal(t, 1, stats.Min, "Expected the lowest answer")
+	assert.Equal(t, 5, stats.Max, "Expected the highest answer")
+	assert.InDelta(t, 1.5811, stats.StdDev, 0.0001, "Expected the sample standard deviation")
+	assert.InDeltaMapValues(t, map[int]float64{10: 1.4, 25: 2, 50: 3, 75: 4, 90: 4.6}, stats.Percentiles, 0.0001, "Expected interpolated percentiles")
+
+	// The margin uses the t critical value for 4 degrees of freedom
+	assert.Equal(t, confidenceLevel, stats.ConfidenceInterval.Level, "Expected the confidence level")
+	assert.InDelta(t, 3-1.9629, stats.ConfidenceInterval.Lower, 0.0001, "Expected the lower bound of the interval")
+	assert.InDelta(t, 3+1.9629, stats.ConfidenceInterval.Upper, 0.0001, "Expected the upper bound of the interval")
+
+	single := computeNumericStats([]int{4})
+	assert.Equal(t, 0.0, single.StdDev, "Expected no spread for a single answer")
+	assert.Nil(t, single.ConfidenceInterval, "Expected no interval for a single answer")
+	assert.Equal(t, 4.0, single.Percentiles[90], "Expected every percentile to be the single answer")
+}
+
+// TestCriticalValue tests that large samples use the normal approximation
+func TestCriticalValue(t *testing.T) {
+	tests := []struct {
+		df   int
+		want float64
+	}{
+		{1, 12.706},
+		{4, 2.776},
+		{30, 2.042},
+		{31, 1.96},
+		{1000, 1.96},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, criticalValue(tt.df), "Expected the critical value for %d degrees of freedom", tt.df)
+	}
+}
+
+// TestComputeNPS tests the split of answers into promoters, passives and detractors
+func TestComputeNPS(t *testing.T) {
+	assert.Nil(t, computeNPS(nil), "Expected no score without answers")
+
+	tests := []struct {
+		name   string
+		values []int
+		want   NPSResult
+	}{
+		{"balanced", []int{10, 9, 8, 7, 6, 0}, NPSResult{
+			Promoters: 2, Passives: 2, Detractors: 2,
+			PromoterPercentage: 100.0 / 3, PassivePercentage: 100.0 / 3, DetractorPercentage: 100.0 / 3,
+		}},
+		{"promoters", []int{10, 10, 9, 0}, NPSResult{
+			Promoters: 3, Detractors: 1,
+			PromoterPercentage: 75, DetractorPercentage: 25, Score: 50,
+		}},
+		{"detractors", []int{6, 5}, NPSResult{Detractors: 2, DetractorPercentage: 100, Score: -100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, *computeNPS(tt.values), "Expected the Net Promoter Score")
+		})
+	}
+}
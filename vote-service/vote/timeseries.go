@@ -0,0 +1,117 @@
+package vote
+
+import (
+	"fmt"
+	"time"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+)
+
+// MaxSeriesBuckets is the maximum number of buckets of a time series
+// Longer ranges must be narrowed with a start and end, or counted per day
+const MaxSeriesBuckets = 1000
+
+// Interval defines the width of the buckets of a time series
+type Interval string
+
+// Available time series intervals
+const (
+	IntervalHour Interval = "hour" // Votes are counted per hour
+	IntervalDay  Interval = "day"  // Votes are counted per day
+)
+
+// Duration returns the width of the buckets of the interval
+func (i Interval) Duration() time.Duration {
+	if i == IntervalHour {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// IsValid checks if the interval is supported
+func (i Interval) IsValid() bool {
+	return i == IntervalHour || i == IntervalDay
+}
+
+// TimeSeries describes the votes of a survey over time
+// This struct holds the vote counts of each question bucketed by interval, computed from the vote timestamps
+type TimeSeries struct {
+	Survey    string           `json:"survey"`    // Survey ID associated with the time series
+	Interval  Interval         `json:"interval"`  // Width of the buckets
+	From      int64            `json:"from"`      // Start of the first bucket, zero when there are no votes
+	To        int64            `json:"to"`        // End of the last bucket, zero when there are no votes
+	Questions []QuestionSeries `json:"questions"` // Time series of each question, in survey order
+}
+
+// QuestionSeries describes the votes of a survey question over time
+type QuestionSeries struct {
+	Question int            `json:"question"` // Question ID within the survey
+	Buckets  []SeriesBucket `json:"buckets"`  // Vote counts per bucket, without gaps between From and To
+}
+
+// SeriesBucket describes the votes cast on a question within one interval
+type SeriesBucket struct {
+	Start   int64       `json:"start"`             // Timestamp when the bucket starts, in UTC
+	Votes   int         `json:"votes"`             // Number of votes cast within the bucket
+	Options map[int]int `json:"options,omitempty"` // Number of votes for each option ID, for choice questions
+}
+
+// computeTimeSeries computes the time series of a survey from the votes cast from a start until an end
+// Buckets are aligned to UTC hours or days and every question shares the same range, a zero start or end leaves it open
+// This function returns ErrInvalidRequest when the range needs more than MaxSeriesBuckets buckets
+func computeTimeSeries(surv *protos.SurveyResponse, votes []*Vote, interval Interval, from, to int64) (TimeSeries, error) {
+	questions := surv.GetQuestions()
+	series := TimeSeries{Survey: surv.GetId(), Interval: interval, Questions: make([]QuestionSeries, len(questions))}
+
+	index := map[int]int{}
+	for i, q := range questions {
+		index[int(q.GetId())] = i
+		series.Questions[i] = QuestionSeries{Question: int(q.GetId()), Buckets: []SeriesBucket{}}
+	}
+
+	width := int64(interval.Duration().Seconds())
+	var counted []*Vote
+	for _, v := range votes {
+		if _, ok := index[v.Question]; !ok {
+			continue
+		}
+		if (from != 0 && v.Timestamp < from) || (to != 0 && v.Timestamp >= to) {
+			continue
+		}
+		start := v.Timestamp - v.Timestamp%width
+		if len(counted) == 0 || start < series.From {
+			series.From = start
+		}
+		if start+width > series.To {
+			series.To = start + width
+		}
+		counted = append(counted, v)
+	}
+	if len(counted) == 0 {
+		return series, nil
+	}
+
+	n := int((series.To - series.From) / width)
+	if n > MaxSeriesBuckets {
+		return TimeSeries{}, fmt.Errorf("%w: the votes span %d buckets, at most %d can be counted, narrow the range with from and to", ErrInvalidRequest, n, MaxSeriesBuckets)
+	}
+	for i := range series.Questions {
+		buckets := make([]SeriesBucket, n)
+		for b := range buckets {
+			buckets[b].Start = series.From + int64(b)*width
+		}
+		series.Questions[i].Buckets = buckets
+	}
+
+	for _, v := range counted {
+		bucket := &series.Questions[index[v.Question]].Buckets[(v.Timestamp-series.From)/width]
+		bucket.Votes++
+		for _, id := range v.SelectedOptions() {
+			if bucket.Options == nil {
+				bucket.Options = map[int]int{}
+			}
+			bucket.Options[id]++
+		}
+	}
+	return series, nil
+}
@@ -0,0 +1,68 @@
+package vote
+
+import (
+	"testing"
+
+	protos "github.com/VitaliySynytskyi/microservices-survey-app/survey-service/protos/survey"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSeriesVote creates a vote selecting an option of a question at a timestamp
+func newSeriesVote(question, option int, timestamp int64) *Vote {
+	return &Vote{Question: question, Timestamp: timestamp, AnswerType: AnswerTypeOption, OptionID: &option}
+}
+
+// TestComputeTimeSeries tests that votes are counted in buckets without gaps
+func TestComputeTimeSeries(t *testing.T) {
+	surv := &protos.SurveyResponse{Id: "survey-1", Questions: []*protos.QuestionResponse{{Id: 1}, {Id: 2}}}
+	votes := []*Vote{
+		newSeriesVote(1, 1, 3600+10),
+		newSeriesVote(1, 2, 3600+20),
+		newSeriesVote(2, 1, 3*3600+5),
+		newSeriesVote(3, 1, 3600),
+	}
+
+	tests := []struct {
+		name     string
+		from, to int64
+		want     []QuestionSeries
+	}{
+		{"all votes", 0, 0, []QuestionSeries{
+			{Question: 1, Buckets: []SeriesBucket{{Start: 3600, Votes: 2, Options: map[int]int{1: 1, 2: 1}}, {Start: 7200}, {Start: 10800}}},
+			{Question: 2, Buckets: []SeriesBucket{{Start: 3600}, {Start: 7200}, {Start: 10800, Votes: 1, Options: map[int]int{1: 1}}}},
+		}},
+		{"range", 3600 + 15, 7200, []QuestionSeries{
+			{Question: 1, Buckets: []SeriesBucket{{Start: 3600, Votes: 1, Options: map[int]int{2: 1}}}},
+			{Question: 2, Buckets: []SeriesBucket{{Start: 3600}}},
+		}},
+		{"empty range", 7200, 10800, []QuestionSeries{
+			{Question: 1, Buckets: []SeriesBucket{}},
+			{Question: 2, Buckets: []SeriesBucket{}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, err := computeTimeSeries(surv, votes, IntervalHour, tt.from, tt.to)
+			assert.NoError(t, err, "Expected no error on compute")
+			assert.Equal(t, tt.want, series.Questions, "Expected the votes of each bucket")
+		})
+	}
+}
+
+// TestComputeTimeSeriesMaxBuckets tests that ranges with too many buckets are rejected
+func TestComputeTimeSeriesMaxBuckets(t *testing.T) {
+	surv := &protos.SurveyResponse{Id: "survey-1", Questions: []*protos.QuestionResponse{{Id: 1}}}
+	last := int64(MaxSeriesBuckets) * 3600
+	votes := []*Vote{newSeriesVote(1, 1, 0), newSeriesVote(1, 1, last)}
+
+	_, err := computeTimeSeries(surv, votes, IntervalHour, 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidRequest, "Expected too many hourly buckets to be rejected")
+
+	series, err := computeTimeSeries(surv, votes, IntervalHour, 0, last)
+	assert.NoError(t, err, "Expected a narrowed range to be counted")
+	assert.Len(t, series.Questions[0].Buckets, 1, "Expected the range to end at the last counted vote")
+
+	series, err = computeTimeSeries(surv, votes, IntervalDay, 0, 0)
+	assert.NoError(t, err, "Expected daily buckets to be counted")
+	assert.Len(t, series.Questions[0].Buckets, MaxSeriesBuckets/24+1, "Expected one bucket per day")
+}
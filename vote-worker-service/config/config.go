@@ -1,24 +1,31 @@
 package config
 
 import (
+	"time"
+
 	"github.com/joeshaw/envdecode"
 )
 
 // Config stores complete application configuration
 // This struct aggregates all configuration required for the application
 type Config struct {
-	Rabbit   RabbitConfig   // RabbitMQ configuration
-	Postgres PostgresConfig // PostgreSQL configuration
+	Rabbit    RabbitConfig    // RabbitMQ configuration
+	Postgres  PostgresConfig  // PostgreSQL configuration
+	Retention RetentionConfig // Retention of votes of deleted surveys
 }
 
 // RabbitConfig stores RabbitMQ configuration
 // This struct holds the configuration settings for connecting to RabbitMQ
 type RabbitConfig struct {
-	Hostname  string `env:"RABBITMQ_HOSTNAME,default=localhost"` // RabbitMQ server hostname
-	Port      uint16 `env:"RABBITMQ_PORT,default=5672"`          // RabbitMQ server port
-	User      string `env:"RABBITMQ_USER,default=guest"`         // RabbitMQ username
-	Password  string `env:"RABBITMQ_PASSWORD,default=guest"`     // RabbitMQ password
-	QueueName string `env:"RABBITMQ_QUEUE,default=votes"`        // RabbitMQ queue name
+	Hostname     string        `env:"RABBITMQ_HOSTNAME,default=localhost"`                            // RabbitMQ server hostname
+	Port         uint16        `env:"RABBITMQ_PORT,default=5672"`                                     // RabbitMQ server port
+	User         string        `env:"RABBITMQ_USER,default=guest"`                                    // RabbitMQ username
+	Password     string        `env:"RABBITMQ_PASSWORD,default=guest"`                                // RabbitMQ password
+	QueueName    string        `env:"RABBITMQ_QUEUE,default=votes"`                                   // RabbitMQ queue name
+	Exchange     string        `env:"RABBITMQ_SURVEY_EXCHANGE,default=surveys"`                       // RabbitMQ exchange survey events are published to
+	Events       string        `env:"RABBITMQ_SURVEY_EVENTS_QUEUE,default=vote-worker.survey-events"` // RabbitMQ queue survey events are consumed from
+	ReconnectMin time.Duration `env:"RABBITMQ_RECONNECT_MIN,default=1s"`                              // Initial wait before reconnecting to the survey events
+	ReconnectMax time.Duration `env:"RABBITMQ_RECONNECT_MAX,default=1m"`                              // Maximum wait before reconnecting to the survey events
 }
 
 // PostgresConfig stores Postgres configuration
@@ -39,6 +46,14 @@ type PostgresTablesConfig struct {
 	Results string `env:"POSTGRES_TABLES_RESULTS,default=results"` // Table name for storing results
 }
 
+// RetentionConfig stores the retention policy of votes of deleted surveys
+// This struct holds whether votes are archived or purged and how long archived votes are kept
+type RetentionConfig struct {
+	Mode          string        `env:"VOTES_DELETE_MODE,default=archive"` // "archive" to soft delete votes, "purge" to remove them immediately
+	Window        time.Duration `env:"VOTES_RETENTION,default=720h"`      // Time archived votes can be restored before being purged
+	PurgeInterval time.Duration `env:"VOTES_PURGE_INTERVAL,default=1h"`   // Interval between purges of expired archived votes
+}
+
 // GetConfig loads and returns application configuration
 // This function loads the configuration from environment variables
 func GetConfig() (Config, error) {
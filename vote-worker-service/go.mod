@@ -35,3 +35,8 @@ require (
 	google.golang.org/grpc v1.64.0 // indirect
 	google.golang.org/protobuf v1.34.1 // indirect
 )
+
+replace (
+	github.com/VitaliySynytskyi/microservices-survey-app/survey-service => ../survey-service
+	github.com/VitaliySynytskyi/microservices-survey-app/vote-service => ../vote-service
+)
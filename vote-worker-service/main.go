@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"os"
+	"time"
 
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/serializer"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
@@ -9,6 +11,7 @@ import (
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-worker-service/logger"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-worker-service/queue"
 	"github.com/VitaliySynytskyi/microservices-survey-app/vote-worker-service/storage"
+	"github.com/rs/zerolog"
 )
 
 // TODO: Need to handle if DB or queue connection dies?
@@ -34,39 +37,97 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Load the queue
+	// Load the queues
 	mq := queue.NewRabbitVoteQueue(cfg.Rabbit, sz, &log)
+	eq := queue.NewRabbitSurveyEventQueue(cfg.Rabbit, sz, &log)
 
-	// Create a channel to receive votes from the queue
+	// Create channels to receive votes and survey events from the queues
 	vc := make(chan *vote.Vote)
+	ec := make(chan *vote.SurveyEvent)
 
-	// Consume the queue
+	// Consume the queues, reconnecting to the survey events when they drop
 	go func() {
 		mq.Consume(vc)
 	}()
+	go queue.ConsumeWithRetry(context.Background(), eq, ec, cfg.Rabbit.ReconnectMin, cfg.Rabbit.ReconnectMax, &log)
 
-	// Receive from the queue
-	go func() {
-		for v := range vc {
-			// Store the vote
-			err := stg.Insert(v)
-			if err != nil {
-				log.Error().Err(err).Str("id", v.ID).Msg("Unable to store vote")
-				continue
-			}
-			log.Info().Str("id", v.ID).Msg("Vote stored")
-
-			// Update the results
-			err = stg.UpdateResults(v)
-			if err != nil {
-				log.Error().Err(err).Str("id", v.ID).Msg("Unable to update vote results")
-				continue
-			}
-			log.Info().Str("id", v.ID).Msg("Vote added to results")
+	// Purge archived votes once their retention window has passed
+	purge := time.NewTicker(cfg.Retention.PurgeInterval)
+	defer purge.Stop()
+
+	// Receive from the queues, the storage connection is only used from this loop
+	for {
+		select {
+		case v := <-vc:
+			storeVote(stg, v, &log)
+		case e := <-ec:
+			applySurveyEvent(stg, cfg.Retention, e, &log)
+		case <-purge.C:
+			purgeArchived(stg, cfg.Retention, &log)
 		}
-	}()
+	}
+}
+
+// storeVote stores a vote and adds it to the results
+func storeVote(stg storage.VoteStorage, v *vote.Vote, log *zerolog.Logger) {
+	// Store the vote
+	counted, err := stg.Insert(v)
+	if err != nil {
+		log.Error().Err(err).Str("id", v.ID).Msg("Unable to store vote")
+		return
+	}
+	if !counted {
+		// The vote replaced or duplicated an earlier answer, which is already in the results
+		log.Info().Str("id", v.ID).Bool("replace", v.Replace).Msg("Vote of a user who already answered stored without counting it again")
+		return
+	}
+	log.Info().Str("id", v.ID).Msg("Vote stored")
+
+	// Update the results
+	err = stg.UpdateResults(v)
+	if err != nil {
+		log.Error().Err(err).Str("id", v.ID).Msg("Unable to update vote results")
+		return
+	}
+	log.Info().Str("id", v.ID).Msg("Vote added to results")
+}
+
+// applySurveyEvent cascades survey deletions, archiving and restorations to the stored votes and results
+func applySurveyEvent(stg storage.VoteStorage, cfg config.RetentionConfig, e *vote.SurveyEvent, log *zerolog.Logger) {
+	var err error
+
+	switch e.Type {
+	case vote.SurveyEventDeleted:
+		if cfg.Mode == "purge" {
+			err = stg.PurgeSurvey(e.Survey)
+		} else {
+			err = stg.ArchiveSurvey(e.Survey, e.Timestamp)
+		}
+	case vote.SurveyEventArchived:
+		// Votes of archived surveys are kept so they come back when the survey is restored
+		err = stg.ArchiveSurvey(e.Survey, e.Timestamp)
+	case vote.SurveyEventRestored:
+		// Only votes still inside the retention window can be restored
+		err = stg.RestoreSurvey(e.Survey, time.Now().UTC().Add(-cfg.Window).Unix())
+	default:
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("id", e.Survey).Str("type", string(e.Type)).Msg("Unable to apply survey event to votes")
+		return
+	}
+	log.Info().Str("id", e.Survey).Str("type", string(e.Type)).Msg("Survey event applied to votes")
+}
 
-	// Wait forever
-	forever := make(chan bool)
-	<-forever
+// purgeArchived permanently deletes votes archived longer than the retention window
+func purgeArchived(stg storage.VoteStorage, cfg config.RetentionConfig, log *zerolog.Logger) {
+	purged, err := stg.PurgeArchived(time.Now().UTC().Add(-cfg.Window).Unix())
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to purge archived votes")
+		return
+	}
+	if purged > 0 {
+		log.Info().Int64("votes", purged).Msg("Archived votes purged")
+	}
 }
@@ -0,0 +1,142 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
+	"github.com/VitaliySynytskyi/microservices-survey-app/vote-worker-service/config"
+	"github.com/rs/zerolog"
+	"github.com/streadway/amqp"
+)
+
+// ErrQueueClosed indicates that the queue connection was closed
+var ErrQueueClosed = errors.New("Queue closed")
+
+// SurveyEventQueue contains functions to receive survey change events from a queue
+// This interface defines the methods required for consuming survey events
+type SurveyEventQueue interface {
+	// Consume consumes survey events from a queue and passes them through a channel to be processed
+	// This method reads survey events from the queue and sends them to the provided channel until the queue connection is lost
+	Consume(ec chan<- *vote.SurveyEvent) error
+}
+
+// ConsumeWithRetry consumes survey events until the context is cancelled, reconnecting whenever the queue connection is lost
+// Reconnects wait minWait, doubled after every failed attempt up to maxWait, and reset once a connection lasted longer than maxWait
+func ConsumeWithRetry(ctx context.Context, q SurveyEventQueue, ec chan<- *vote.SurveyEvent, minWait, maxWait time.Duration, log *zerolog.Logger) {
+	wait := minWait
+	for {
+		start := time.Now()
+		err := q.Consume(ec)
+		if time.Since(start) > maxWait {
+			wait = minWait
+		}
+		log.Error().Err(err).Dur("retry", wait).Msg("Survey events unavailable, events wait in the durable queue until reconnected")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		if wait *= 2; wait > maxWait {
+			wait = maxWait
+		}
+	}
+}
+
+type rabbitSurveyEventQueue struct {
+	config     config.RabbitConfig
+	serializer vote.Serializer
+	log        *zerolog.Logger
+}
+
+// NewRabbitSurveyEventQueue creates a new rabbit survey event queue
+// This function initializes and returns a new instance of rabbitSurveyEventQueue
+func NewRabbitSurveyEventQueue(cfg config.RabbitConfig, sz vote.Serializer, l *zerolog.Logger) SurveyEventQueue {
+	return &rabbitSurveyEventQueue{
+		config:     cfg,
+		serializer: sz,
+		log:        l,
+	}
+}
+
+// Consume consumes survey events from a durable RabbitMQ queue bound to the survey exchange
+// The queue survives restarts so deletions published while the worker is down are not lost
+// This method returns an error when the connection fails, and ErrQueueClosed once an open connection is lost
+func (r *rabbitSurveyEventQueue) Consume(ec chan<- *vote.SurveyEvent) error {
+	addr := fmt.Sprintf("amqp://%s:%s@%s:%d/", r.config.User, r.config.Password, r.config.Hostname, r.config.Port)
+	conn, err := amqp.Dial(addr)
+	if err != nil {
+		return fmt.Errorf("cannot connect to survey event queue: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("cannot open survey event channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := r.declare(ch); err != nil {
+		return fmt.Errorf("cannot declare survey event queue: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		r.config.Events,
+		"",
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to consume survey event queue: %w", err)
+	}
+
+	r.log.Info().Str("exchange", r.config.Exchange).Msg("Connected to survey events. Awaiting messages.")
+
+	for msg := range msgs {
+		e, err := r.serializer.DecodeSurveyEvent(msg.Body)
+		if err != nil {
+			r.log.Error().Err(err).Str("body", string(msg.Body)).Msg("Unable to parse survey event from queue message")
+			continue
+		}
+		r.log.Info().Str("id", e.Survey).Str("type", string(e.Type)).Msg("Survey event received from queue")
+		ec <- e
+	}
+
+	return ErrQueueClosed
+}
+
+// declare declares the survey exchange and the worker's durable queue bound to it
+func (r *rabbitSurveyEventQueue) declare(ch *amqp.Channel) error {
+	err := ch.ExchangeDeclare(
+		r.config.Exchange,
+		amqp.ExchangeTopic,
+		true,  // Durable
+		false, // Auto-deleted
+		false, // Internal
+		false, // No-wait
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclare(
+		r.config.Events,
+		true,  // Durable
+		false, // Delete when unused
+		false, // Exclusive
+		false, // No-wait
+		nil,   // Arguments
+	)
+	if err != nil {
+		return err
+	}
+
+	return ch.QueueBind(r.config.Events, "survey.#", r.config.Exchange, false, nil)
+}
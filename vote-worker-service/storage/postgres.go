@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -50,10 +51,36 @@ func (p *postgresVoteStorage) connect() error {
 
 // Insert inserts a new vote into the votes table
 // This method saves the vote details into the PostgreSQL database
-func (p *postgresVoteStorage) Insert(v *vote.Vote) error {
-	q := fmt.Sprintf("INSERT INTO %s(id, survey, question, created) VALUES($1, $2, $3, $4)", p.config.Tables.Votes)
-	_, err := p.connection.Exec(context.Background(), q, v.ID, v.Survey, v.Question, v.Timestamp)
-	return err
+// A user answers each question once, a later vote replaces the answer when allowed and is dropped otherwise
+// The answer values are stored with the vote so results can be recomputed from individual answers
+func (p *postgresVoteStorage) Insert(v *vote.Vote) (bool, error) {
+	answer, err := json.Marshal(v.GetAnswer())
+	if err != nil {
+		return false, err
+	}
+
+	if v.UserID == "" {
+		q := fmt.Sprintf("INSERT INTO %s(id, survey, question, created, respondent, answer) VALUES($1, $2, $3, $4, NULLIF($5, ''), $6)", p.config.Tables.Votes)
+		_, err := p.connection.Exec(context.Background(), q, v.ID, v.Survey, v.Question, v.Timestamp, v.Respondent, string(answer))
+		return err == nil, err
+	}
+
+	conflict := "DO NOTHING"
+	if v.Replace {
+		conflict = "DO UPDATE SET id = EXCLUDED.id, created = EXCLUDED.created, respondent = EXCLUDED.respondent, answer = EXCLUDED.answer"
+	}
+	q := fmt.Sprintf(`INSERT INTO %s(id, survey, question, created, user_id, respondent, answer) VALUES($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+		ON CONFLICT (survey, question, user_id) WHERE user_id IS NOT NULL AND archived_at IS NULL %s
+		RETURNING (xmax = 0)`, p.config.Tables.Votes, conflict)
+
+	// xmax is only zero for freshly inserted rows
+	var inserted bool
+	err = p.connection.QueryRow(context.Background(), q, v.ID, v.Survey, v.Question, v.Timestamp, v.UserID, v.Respondent, string(answer)).Scan(&inserted)
+	if err == pgx.ErrNoRows {
+		// The user already answered and edits are not allowed
+		return false, nil
+	}
+	return inserted, err
 }
 
 // UpdateResults updates the vote results in the results table
@@ -89,3 +116,65 @@ func (p *postgresVoteStorage) initializeResults(v *vote.Vote) error {
 	_, err := p.connection.Exec(context.Background(), q, v.Survey, v.Question, 1, time.Now().UTC().Unix())
 	return err
 }
+
+// ArchiveSurvey marks the votes and results of a survey as archived
+// Both tables are updated in a single transaction
+func (p *postgresVoteStorage) ArchiveSurvey(surveyID string, archivedAt int64) error {
+	return p.forEachTable(func(tx pgx.Tx, table string) error {
+		q := fmt.Sprintf("UPDATE %s SET archived_at = $1 WHERE survey = $2 AND archived_at IS NULL", table)
+		_, err := tx.Exec(context.Background(), q, archivedAt, surveyID)
+		return err
+	})
+}
+
+// RestoreSurvey clears the archived mark of votes and results archived after the given time
+// Both tables are updated in a single transaction
+func (p *postgresVoteStorage) RestoreSurvey(surveyID string, archivedAfter int64) error {
+	return p.forEachTable(func(tx pgx.Tx, table string) error {
+		q := fmt.Sprintf("UPDATE %s SET archived_at = NULL WHERE survey = $1 AND archived_at >= $2", table)
+		_, err := tx.Exec(context.Background(), q, surveyID, archivedAfter)
+		return err
+	})
+}
+
+// PurgeSurvey deletes the votes and results of a survey
+// Both tables are updated in a single transaction
+func (p *postgresVoteStorage) PurgeSurvey(surveyID string) error {
+	return p.forEachTable(func(tx pgx.Tx, table string) error {
+		q := fmt.Sprintf("DELETE FROM %s WHERE survey = $1", table)
+		_, err := tx.Exec(context.Background(), q, surveyID)
+		return err
+	})
+}
+
+// PurgeArchived deletes votes and results archived before the given time
+// Both tables are updated in a single transaction
+func (p *postgresVoteStorage) PurgeArchived(archivedBefore int64) (int64, error) {
+	var purged int64
+	err := p.forEachTable(func(tx pgx.Tx, table string) error {
+		q := fmt.Sprintf("DELETE FROM %s WHERE archived_at IS NOT NULL AND archived_at < $1", table)
+		tag, err := tx.Exec(context.Background(), q, archivedBefore)
+		if table == p.config.Tables.Votes {
+			purged = tag.RowsAffected()
+		}
+		return err
+	})
+	return purged, err
+}
+
+// forEachTable runs a statement against the votes and results tables within a transaction
+func (p *postgresVoteStorage) forEachTable(fn func(tx pgx.Tx, table string) error) error {
+	tx, err := p.connection.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	for _, table := range []string{p.config.Tables.Votes, p.config.Tables.Results} {
+		if err := fn(tx, table); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(context.Background())
+}
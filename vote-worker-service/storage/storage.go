@@ -6,11 +6,27 @@ import "github.com/VitaliySynytskyi/microservices-survey-app/vote-service/vote"
 // This interface defines the methods required for storing votes and updating vote results
 type VoteStorage interface {
 	// Insert inserts a vote into storage
-	// This method saves a new vote to the storage
-	Insert(v *vote.Vote) error
+	// This method saves a new vote to the storage and reports whether it is a new answer,
+	// votes replacing or duplicating an earlier answer of the same user must not be counted again
+	Insert(v *vote.Vote) (bool, error)
 
 	// UpdateResults updates the total vote count results for the survey and
 	// question of a given vote
 	// This method updates the vote count for a specific survey and question
 	UpdateResults(v *vote.Vote) error
+
+	// ArchiveSurvey soft deletes the votes and results of a survey
+	// Archived votes and results are hidden from results but can be restored
+	ArchiveSurvey(surveyID string, archivedAt int64) error
+
+	// RestoreSurvey restores the votes and results of a survey archived after the given time
+	// This method makes archived votes and results visible again
+	RestoreSurvey(surveyID string, archivedAfter int64) error
+
+	// PurgeSurvey permanently deletes the votes and results of a survey
+	PurgeSurvey(surveyID string) error
+
+	// PurgeArchived permanently deletes votes and results archived before the given time
+	// This method returns the number of deleted votes
+	PurgeArchived(archivedBefore int64) (int64, error)
 }